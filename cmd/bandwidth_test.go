@@ -0,0 +1,53 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthLimiter_SharedAcrossReaders(t *testing.T) {
+	const rate = 1 << 20 // 1 MB/s
+	limiter := newBandwidthLimiter(rate)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := io.Copy(io.Discard, limiter.reader(context.Background(), bytes.NewReader(make([]byte, 150<<10))))
+			assert.NoError(t, err)
+			assert.Equal(t, int64(150<<10), n)
+		}()
+	}
+	wg.Wait()
+
+	// 300 KB at 1 MB/s, less the initial burst of ~100 KB, takes at least ~190ms
+	assert.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+}
+
+func TestBandwidthLimiter_Unlimited(t *testing.T) {
+	limiter := newBandwidthLimiter(0)
+	assert.Nil(t, limiter)
+	r := bytes.NewReader([]byte("x"))
+	assert.Same(t, r, limiter.reader(context.Background(), r).(*bytes.Reader))
+	assert.NoError(t, limiter.wait(context.Background(), 1<<30))
+}
+
+func TestBandwidthLimiter_Cancel(t *testing.T) {
+	limiter := newBandwidthLimiter(1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := io.Copy(io.Discard, limiter.reader(ctx, bytes.NewReader(make([]byte, 10<<10))))
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/dockerfile"
+)
+
+// buildLabel is a --label KEY=VALUE pair
+type buildLabel struct {
+	Key   string
+	Value string
+}
+
+// buildOptionsFromFlags reads --build-arg-file, --build-arg and --label. Build args from
+// files are applied first so --build-arg can override them.
+func buildOptionsFromFlags(cmd *cobra.Command) (map[string]string, []buildLabel, error) {
+	argFiles, _ := cmd.Flags().GetStringArray("build-arg-file")
+	argFlags, _ := cmd.Flags().GetStringArray("build-arg")
+	labelFlags, _ := cmd.Flags().GetStringArray("label")
+
+	buildArgs := make(map[string]string)
+	for _, path := range argFiles {
+		if err := readBuildArgFile(path, buildArgs); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, arg := range argFlags {
+		if err := addBuildArg(buildArgs, arg, "--build-arg"); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var labels []buildLabel
+	seen := make(map[string]int)
+	for _, label := range labelFlags {
+		key, value, ok := strings.Cut(label, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, nil, fmt.Errorf("invalid --label %q, expected KEY=VALUE", label)
+		}
+		if i, ok := seen[key]; ok {
+			labels[i].Value = value
+			continue
+		}
+		seen[key] = len(labels)
+		labels = append(labels, buildLabel{Key: key, Value: value})
+	}
+	return buildArgs, labels, nil
+}
+
+// addBuildArg adds a KEY=VALUE build arg. A bare KEY takes its value from the
+// environment, like docker build, and is skipped when the variable is not set.
+func addBuildArg(buildArgs map[string]string, arg, source string) error {
+	key, value, ok := strings.Cut(arg, "=")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("invalid %s %q, expected KEY=VALUE or KEY", source, arg)
+	}
+	if !ok {
+		v, set := os.LookupEnv(key)
+		if !set {
+			return nil
+		}
+		value = v
+	}
+	buildArgs[key] = value
+	return nil
+}
+
+// readBuildArgFile reads KEY=VALUE lines into buildArgs, skipping blank lines and
+// lines starting with #
+func readBuildArgFile(path string, buildArgs map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read build arg file: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := addBuildArg(buildArgs, line, fmt.Sprintf("build arg on line %d of %s", n, path)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// applyBuildOptions returns the Dockerfile content to upload: ARG defaults set from
+// buildArgs and a LABEL instruction for labels appended to the final stage. The build
+// API takes no build arguments, so they are substituted locally. Build args declared
+// in the system-defined lines of an 'image init' template are rejected, as changing
+// those lines fails the build. Names no ARG declares are returned as unused.
+func applyBuildOptions(dockerfilePath string, content []byte, buildArgs map[string]string, labels []buildLabel) ([]byte, []string, error) {
+	if len(buildArgs) == 0 && len(labels) == 0 {
+		return content, nil, nil
+	}
+	out, rewrites, err := dockerfile.SetArgDefaults(content, buildArgs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply build args: %w", err)
+	}
+
+	if len(rewrites) > 0 {
+		lock, err := ReadDockerfileLock(DockerfileLockPath(dockerfilePath))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range rewrites {
+			if lock != nil && r.Line <= lock.NonEditLineNum {
+				return nil, nil, fmt.Errorf("build arg %s is declared on system-defined line %d of the Dockerfile and cannot be set", strings.Join(r.Names, ", "), r.Line)
+			}
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, r := range rewrites {
+		for _, name := range r.Names {
+			used[name] = true
+		}
+	}
+	var unused []string
+	for name := range buildArgs {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+
+	if len(labels) > 0 {
+		df, err := dockerfile.Parse(out)
+		if err != nil {
+			return nil, nil, err
+		}
+		words := make([]string, 0, len(labels))
+		for _, l := range labels {
+			key, err := dockerfile.QuoteWord(l.Key, df.EscapeToken)
+			if err != nil {
+				return nil, nil, fmt.Errorf("label %s: %w", l.Key, err)
+			}
+			value, err := dockerfile.QuoteWord(l.Value, df.EscapeToken)
+			if err != nil {
+				return nil, nil, fmt.Errorf("label %s: %w", l.Key, err)
+			}
+			words = append(words, key+"="+value)
+		}
+		newline := "\n"
+		if bytes.Contains(out, []byte("\r\n")) {
+			newline = "\r\n"
+		}
+		out = out[:len(out):len(out)] // never append into content
+		if len(out) > 0 && !bytes.HasSuffix(out, []byte("\n")) {
+			out = append(out, newline...)
+		}
+		out = append(out, "LABEL "+strings.Join(words, " ")+newline...)
+	}
+	return out, unused, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBuildOptionsCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringArray("build-arg", nil, "")
+	cmd.Flags().StringArray("build-arg-file", nil, "")
+	cmd.Flags().StringArray("label", nil, "")
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestBuildOptionsFromFlags(t *testing.T) {
+	argFile := filepath.Join(t.TempDir(), "args.env")
+	require.NoError(t, os.WriteFile(argFile, []byte("# defaults\nVERSION=1.0\n\nCHANNEL=stable\nFROM_ENV\n"), 0644))
+	t.Setenv("FROM_ENV", "env value")
+
+	cmd := newBuildOptionsCmd(t,
+		"--build-arg-file", argFile,
+		"--build-arg", "VERSION=2.0",
+		"--build-arg", "UNSET_IN_ENV_XYZ",
+		"--label", "team=infra",
+		"--label", "env=dev",
+		"--label", "team=platform",
+	)
+	buildArgs, labels, err := buildOptionsFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"VERSION": "2.0", "CHANNEL": "stable", "FROM_ENV": "env value"}, buildArgs)
+	assert.Equal(t, []buildLabel{{Key: "team", Value: "platform"}, {Key: "env", Value: "dev"}}, labels)
+
+	_, _, err = buildOptionsFromFlags(newBuildOptionsCmd(t, "--label", "novalue"))
+	assert.Error(t, err)
+	_, _, err = buildOptionsFromFlags(newBuildOptionsCmd(t, "--build-arg", "=x"))
+	assert.Error(t, err)
+}
+
+func TestApplyBuildOptions(t *testing.T) {
+	dockerfilePath := filepath.Join(t.TempDir(), "Dockerfile")
+	content := []byte("FROM ubuntu\r\nARG VERSION=1\r\nRUN echo $VERSION")
+
+	out, unused, err := applyBuildOptions(dockerfilePath, content, map[string]string{"VERSION": "2", "TYPO": "x"},
+		[]buildLabel{{Key: "team", Value: "infra"}, {Key: "description", Value: "my app"}})
+	require.NoError(t, err)
+	assert.Equal(t, "FROM ubuntu\r\nARG VERSION=2\r\nRUN echo $VERSION\r\nLABEL team=infra description='my app'\r\n", string(out))
+	assert.Equal(t, []string{"TYPO"}, unused)
+	assert.Equal(t, "FROM ubuntu\r\nARG VERSION=1\r\nRUN echo $VERSION", string(content), "input is not modified")
+
+	out, unused, err = applyBuildOptions(dockerfilePath, content, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, content, out)
+	assert.Empty(t, unused)
+}
+
+func TestApplyBuildOptions_SystemDefinedLines(t *testing.T) {
+	dockerfilePath := filepath.Join(t.TempDir(), "Dockerfile")
+	template := []byte("FROM registry.example.com/base:1.0\nARG BASE_VERSION=1\n")
+	require.NoError(t, WriteDockerfileLock(DockerfileLockPath(dockerfilePath), NewDockerfileLock("code_latest", template, 2)))
+	content := append(template, []byte("ARG VERSION=1\n")...)
+
+	_, _, err := applyBuildOptions(dockerfilePath, content, map[string]string{"BASE_VERSION": "2"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "system-defined line 2")
+
+	out, _, err := applyBuildOptions(dockerfilePath, content, map[string]string{"VERSION": "2"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, string(template)+"ARG VERSION=2\n", string(out))
+}
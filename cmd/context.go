@@ -0,0 +1,28 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// commandContext returns the context the command was executed with. The root command
+// cancels it on SIGINT/SIGTERM; commands invoked directly (e.g. in tests) fall back to
+// context.Background().
+func commandContext(cmd *cobra.Command) context.Context {
+	if cmd != nil {
+		if ctx := cmd.Context(); ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// isCancelled reports whether err (or ctx) reflects a user interrupt rather than a timeout
+func isCancelled(ctx context.Context, err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled)
+}
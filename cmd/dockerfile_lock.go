@@ -0,0 +1,152 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DockerfileLockSuffix is appended to the Dockerfile path to name its lockfile
+const DockerfileLockSuffix = ".agentbay.lock"
+
+// DockerfileLock records the system-defined header of a Dockerfile template downloaded
+// by 'agentbay image init', so 'agentbay image create' can reject edits to it locally
+// instead of failing server-side after the upload.
+type DockerfileLock struct {
+	SourceImageID  string   `json:"sourceImageId"`
+	NonEditLineNum int      `json:"nonEditLineNum"`
+	Header         []string `json:"header"`
+}
+
+// HeaderLineDiff is one protected header line that no longer matches the template
+type HeaderLineDiff struct {
+	Line     int
+	Expected string
+	Actual   string
+	Missing  bool // the Dockerfile is shorter than the header
+}
+
+// DockerfileLockError reports why a Dockerfile does not match its lockfile
+type DockerfileLockError struct {
+	LockPath      string
+	SourceImageID string // set when the lockfile was created for a different source image
+	Diffs         []HeaderLineDiff
+}
+
+func (e *DockerfileLockError) Error() string {
+	if e.SourceImageID != "" {
+		return fmt.Sprintf("Dockerfile template was created for source image '%s'", e.SourceImageID)
+	}
+	return fmt.Sprintf("%d system-defined Dockerfile line(s) were modified", len(e.Diffs))
+}
+
+// DiffLines renders the mismatching header lines as a line-level diff
+func (e *DockerfileLockError) DiffLines() []string {
+	var out []string
+	for _, d := range e.Diffs {
+		out = append(out, fmt.Sprintf("@@ line %d @@", d.Line))
+		out = append(out, "- "+d.Expected)
+		if d.Missing {
+			out = append(out, "+ (missing)")
+		} else {
+			out = append(out, "+ "+d.Actual)
+		}
+	}
+	return out
+}
+
+// DockerfileLockPath returns the lockfile path for a Dockerfile
+func DockerfileLockPath(dockerfilePath string) string {
+	return dockerfilePath + DockerfileLockSuffix
+}
+
+// NewDockerfileLock captures the first nonEditLineNum lines of a template
+func NewDockerfileLock(sourceImageID string, template []byte, nonEditLineNum int) *DockerfileLock {
+	lines := splitPhysicalLines(template)
+	if nonEditLineNum > len(lines) {
+		nonEditLineNum = len(lines)
+	}
+	if nonEditLineNum < 0 {
+		nonEditLineNum = 0
+	}
+	return &DockerfileLock{
+		SourceImageID:  sourceImageID,
+		NonEditLineNum: nonEditLineNum,
+		Header:         append([]string{}, lines[:nonEditLineNum]...),
+	}
+}
+
+// WriteDockerfileLock writes lock to path
+func WriteDockerfileLock(path string, lock *DockerfileLock) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode Dockerfile lockfile: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write Dockerfile lockfile: %w", err)
+	}
+	return nil
+}
+
+// ReadDockerfileLock reads the lockfile at path. It returns nil without an error when
+// there is no lockfile, e.g. for Dockerfiles not created by 'agentbay image init'.
+func ReadDockerfileLock(path string) (*DockerfileLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read Dockerfile lockfile: %w", err)
+	}
+	var lock DockerfileLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("invalid Dockerfile lockfile %s: %w", path, err)
+	}
+	if len(lock.Header) != lock.NonEditLineNum {
+		return nil, fmt.Errorf("invalid Dockerfile lockfile %s: header has %d line(s), expected %d", path, len(lock.Header), lock.NonEditLineNum)
+	}
+	return &lock, nil
+}
+
+// Check verifies that dockerfileContent still starts with the locked header and that it
+// is built from the source image the template was created for. A mismatch is returned
+// as a *DockerfileLockError.
+func (l *DockerfileLock) Check(dockerfileContent []byte, sourceImageID string) error {
+	if l.SourceImageID != "" && sourceImageID != l.SourceImageID {
+		return &DockerfileLockError{SourceImageID: l.SourceImageID}
+	}
+	lines := splitPhysicalLines(dockerfileContent)
+	var diffs []HeaderLineDiff
+	for i, expected := range l.Header {
+		if i >= len(lines) {
+			diffs = append(diffs, HeaderLineDiff{Line: i + 1, Expected: expected, Missing: true})
+			continue
+		}
+		if lines[i] != expected {
+			diffs = append(diffs, HeaderLineDiff{Line: i + 1, Expected: expected, Actual: lines[i]})
+		}
+	}
+	if len(diffs) > 0 {
+		return &DockerfileLockError{Diffs: diffs}
+	}
+	return nil
+}
+
+// splitPhysicalLines splits content into lines as numbered by the server's
+// NonEditLineNum, ignoring line ending differences and trailing whitespace
+func splitPhysicalLines(content []byte) []string {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
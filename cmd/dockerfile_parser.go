@@ -1,63 +1,63 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/agentbay/agentbay-cli/internal/dockerfile"
+)
+
+// Errors returned for COPY/ADD sources the uploader cannot handle
+var (
+	errAbsoluteSource = errors.New("absolute source path not supported")
+	errSourceEscapes  = errors.New("source path escapes context")
+	errSourceNotFound = errors.New("source not found")
+	errSourceIgnored  = errors.New("source excluded by " + DockerIgnoreFile)
 )
 
 func ParseCOPYADDSources(dockerfileContent []byte, contextDir string) ([]string, error) {
-	lines := SplitDockerfileLines(dockerfileContent)
+	files, _, err := CollectCOPYADDSources(dockerfileContent, contextDir)
+	return files, err
+}
+
+// CollectCOPYADDSources returns the local files referenced by COPY/ADD, honoring the
+// .dockerignore in contextDir, along with the files the .dockerignore excluded
+func CollectCOPYADDSources(dockerfileContent []byte, contextDir string) ([]string, []string, error) {
+	ignore, err := LoadDockerIgnore(contextDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	df, err := dockerfile.Parse(dockerfileContent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Dockerfile: %w", err)
+	}
+
 	seen := make(map[string]struct{})
-	var out []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-		upper := strings.ToUpper(line)
-		if !strings.HasPrefix(upper, "COPY ") && !strings.HasPrefix(upper, "ADD ") {
-			continue
+	seenExcluded := make(map[string]struct{})
+	var out, excluded []string
+	err = df.Walk(nil, func(inst *dockerfile.Instruction, vars map[string]string) error {
+		if inst.Keyword != "COPY" && inst.Keyword != "ADD" {
+			return nil
 		}
-		idx := strings.Index(line, " ")
-		if idx < 0 {
-			continue
+		// Sources copied from another stage or image are not in the build context
+		if _, ok := inst.Flag("from"); ok {
+			return nil
 		}
-		rest := strings.TrimSpace(line[idx+1:])
-		tokens, err := TokenizeInstruction(rest)
+		sources, _, err := inst.CopySources(vars)
 		if err != nil {
-			continue
+			return fmt.Errorf("line %d: %w", inst.StartLine, err)
 		}
-		if len(tokens) < 2 {
-			continue
-		}
-		dest := tokens[len(tokens)-1]
-		sources := tokens[:len(tokens)-1]
-		_ = dest
-
-		var i int
-		for _, t := range sources {
-			if strings.HasPrefix(t, "--") {
-				if strings.HasPrefix(strings.ToLower(t), "--from=") {
-					break
-				}
+		for _, src := range sources {
+			if inst.Keyword == "ADD" && (IsURL(src) || strings.HasPrefix(src, "git@")) {
 				continue
 			}
-			sources[i] = t
-			i++
-		}
-		sources = sources[:i]
-		if len(sources) == 0 {
-			continue
-		}
-		if strings.HasPrefix(strings.ToUpper(line), "ADD ") && IsURL(sources[0]) {
-			continue
-		}
-		for _, src := range sources {
-			absPaths, err := ExpandSource(contextDir, src)
+			absPaths, skipped, err := expandSource(contextDir, src, ignore)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			for _, p := range absPaths {
 				if _, ok := seen[p]; ok {
@@ -66,11 +66,25 @@ func ParseCOPYADDSources(dockerfileContent []byte, contextDir string) ([]string,
 				seen[p] = struct{}{}
 				out = append(out, p)
 			}
+			for _, p := range skipped {
+				if _, ok := seenExcluded[p]; ok {
+					continue
+				}
+				seenExcluded[p] = struct{}{}
+				excluded = append(excluded, p)
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	return out, nil
+	return out, excluded, nil
 }
 
+// SplitDockerfileLines splits content into logical lines, joining continuations.
+//
+// Deprecated: use dockerfile.Parse, which also handles directives and heredocs.
 func SplitDockerfileLines(content []byte) []string {
 	var lines []string
 	s := string(content)
@@ -109,6 +123,9 @@ func SplitDockerfileLines(content []byte) []string {
 	return lines
 }
 
+// TokenizeInstruction splits instruction arguments into words, removing quotes.
+//
+// Deprecated: use dockerfile.Parse and Instruction.ExpandArgs.
 func TokenizeInstruction(rest string) ([]string, error) {
 	if strings.HasPrefix(rest, "[") {
 		return tokenizeJSONArray(rest)
@@ -165,64 +182,116 @@ func tokenizeJSONArray(rest string) ([]string, error) {
 }
 
 func ExpandSource(contextDir, source string) ([]string, error) {
+	files, _, err := expandSource(contextDir, source, nil)
+	return files, err
+}
+
+// expandSource resolves a COPY/ADD source to files, splitting off the ones excluded by
+// ignore. Naming an excluded file directly is an error, as it is for docker build.
+func expandSource(contextDir, source string, ignore *DockerIgnore) ([]string, []string, error) {
 	source = filepath.Clean(source)
 	if filepath.IsAbs(source) {
-		return nil, fmt.Errorf("absolute source path not supported: %s", source)
+		return nil, nil, fmt.Errorf("%w: %s", errAbsoluteSource, source)
 	}
 	pattern := filepath.Clean(filepath.Join(contextDir, source))
 	rel, err := filepath.Rel(contextDir, pattern)
-	if err != nil || strings.HasPrefix(rel, "..") {
-		return nil, fmt.Errorf("source path escapes context: %s", source)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, nil, fmt.Errorf("%w: %s", errSourceEscapes, source)
 	}
 	if strings.Contains(source, "*") {
 		matches, err := filepath.Glob(pattern)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		var files []string
+		var files, excluded []string
 		for _, m := range matches {
 			info, err := os.Stat(m)
 			if err != nil {
 				continue
 			}
 			if info.IsDir() {
-				sub, err := walkFiles(m)
+				sub, subExcluded, err := walkFiles(contextDir, m, ignore)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				files = append(files, sub...)
+				excluded = append(excluded, subExcluded...)
+			} else if isIgnored(contextDir, m, ignore) {
+				excluded = append(excluded, m)
 			} else {
 				files = append(files, m)
 			}
 		}
-		return files, nil
+		return files, excluded, nil
 	}
 	info, err := os.Stat(pattern)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("source not found: %s", source)
+			return nil, nil, fmt.Errorf("%w: %s", errSourceNotFound, source)
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	if info.IsDir() {
-		return walkFiles(pattern)
+		return walkFiles(contextDir, pattern, ignore)
 	}
-	return []string{pattern}, nil
+	if isIgnored(contextDir, pattern, ignore) {
+		return nil, nil, fmt.Errorf("%w: %s", errSourceIgnored, source)
+	}
+	return []string{pattern}, nil, nil
 }
 
-func walkFiles(dir string) ([]string, error) {
-	var out []string
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+func walkFiles(contextDir, dir string, ignore *DockerIgnore) ([]string, []string, error) {
+	var out, excluded []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
+		if d.IsDir() {
+			return nil
+		}
+		if isIgnored(contextDir, path, ignore) {
+			excluded = append(excluded, path)
 			return nil
 		}
 		out = append(out, path)
 		return nil
 	})
-	return out, err
+	return out, excluded, err
+}
+
+// isIgnored reports whether absPath, a file inside contextDir, is excluded by ignore
+func isIgnored(contextDir, absPath string, ignore *DockerIgnore) bool {
+	if ignore == nil {
+		return false
+	}
+	rel, err := filepath.Rel(contextDir, absPath)
+	if err != nil {
+		return false
+	}
+	return ignore.Excluded(filepath.ToSlash(rel))
+}
+
+// ResolveContextDir returns the absolute build context directory: contextDir when set,
+// otherwise the directory containing the Dockerfile
+func ResolveContextDir(contextDir, dockerfilePath string) (string, error) {
+	if contextDir == "" {
+		return filepath.Dir(dockerfilePath), nil
+	}
+	abs, err := filepath.Abs(contextDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve build context path: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("build context not found: %s", abs)
+		}
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("build context is not a directory: %s", abs)
+	}
+	return abs, nil
 }
 
 func RelativePathForUpload(contextDir, absolutePath string) (string, error) {
@@ -0,0 +1,146 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DockerIgnoreFile is the name of the ignore file looked up in the build context directory
+const DockerIgnoreFile = ".dockerignore"
+
+// DockerIgnore matches context-relative paths against .dockerignore patterns using
+// Docker's semantics: '*', '?' and '[...]' match within one path segment, '**' matches
+// any number of segments, a leading '!' re-includes a path, a leading '/' is ignored,
+// and the last matching pattern wins. A pattern that matches a directory also matches
+// everything below it.
+type DockerIgnore struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	re      *regexp.Regexp
+	exclude bool // false for '!' patterns
+}
+
+// LoadDockerIgnore reads the .dockerignore in contextDir. A missing file yields a
+// matcher that excludes nothing.
+func LoadDockerIgnore(contextDir string) (*DockerIgnore, error) {
+	content, err := os.ReadFile(filepath.Join(contextDir, DockerIgnoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &DockerIgnore{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", DockerIgnoreFile, err)
+	}
+	return ParseDockerIgnore(content)
+}
+
+// ParseDockerIgnore parses .dockerignore content
+func ParseDockerIgnore(content []byte) (*DockerIgnore, error) {
+	di := &DockerIgnore{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		exclude := true
+		if strings.HasPrefix(line, "!") {
+			exclude = false
+			line = strings.TrimSpace(line[1:])
+		}
+		line = path.Clean(filepath.ToSlash(line))
+		line = strings.TrimPrefix(line, "/")
+		if line == "" || line == "." {
+			continue
+		}
+		re, err := compileIgnorePattern(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: invalid pattern %q: %w", DockerIgnoreFile, lineNum, line, err)
+		}
+		di.patterns = append(di.patterns, ignorePattern{re: re, exclude: exclude})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", DockerIgnoreFile, err)
+	}
+	return di, nil
+}
+
+// compileIgnorePattern converts a cleaned .dockerignore pattern into an anchored regexp
+func compileIgnorePattern(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case ch == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			i++
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				// "**/" matches zero or more leading directories
+				i++
+				sb.WriteString("(.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case ch == '*':
+			sb.WriteString("[^/]*")
+		case ch == '?':
+			sb.WriteString("[^/]")
+		case ch == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case ch == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// Excluded reports whether a context-relative, slash-separated path is excluded
+func (di *DockerIgnore) Excluded(relPath string) bool {
+	if di == nil || len(di.patterns) == 0 {
+		return false
+	}
+	relPath = path.Clean(relPath)
+	excluded := false
+	for _, p := range di.patterns {
+		if p.matches(relPath) {
+			excluded = p.exclude
+		}
+	}
+	return excluded
+}
+
+// matches reports whether the pattern matches relPath or one of its parent directories
+func (p ignorePattern) matches(relPath string) bool {
+	if p.re.MatchString(relPath) {
+		return true
+	}
+	for dir := path.Dir(relPath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if p.re.MatchString(dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import "errors"
+
+// Process exit codes. Commands that wait for a build ('image create', 'image wait')
+// use the specific codes so scripts can tell a failed build from a timeout.
+const (
+	ExitCodeSuccess     = 0
+	ExitCodeFailure     = 1   // any other error
+	ExitCodeBuildFailed = 2   // the build task finished with a failed status
+	ExitCodeTimeout     = 3   // the build task was still running when the timeout elapsed
+	ExitCodeInterrupted = 130 // interrupted by Ctrl-C / SIGTERM
+)
+
+// ExitError carries a specific process exit code alongside an error
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// ExitCode returns the process exit code for an error returned by a command
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitCodeFailure
+}
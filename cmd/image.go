@@ -6,6 +6,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -55,11 +56,89 @@ Examples:
   agentbay image create my-custom-image --dockerfile ./Dockerfile --imageId code_latest
   
   # Short form
-  agentbay image create my-image -f ./Dockerfile -i code_latest`,
+  agentbay image create my-image -f ./Dockerfile -i code_latest
+
+  # Build images/agent.Dockerfile with the repository root as the build context
+  agentbay image create my-agent -f images/agent.Dockerfile --context . -i code_latest
+
+  # Submit the build and return immediately with the task ID
+  agentbay image create my-image -f ./Dockerfile -i code_latest --no-wait
+
+  # Resume an interrupted upload, skipping files that were already uploaded
+  agentbay image create my-image -f ./Dockerfile -i code_latest --resume task-xxxxxxxxxxxxxx
+
+  # Upload every ADD/COPY file again instead of only the ones changed since the last run
+  agentbay image create my-image -f ./Dockerfile -i code_latest --full-upload
+
+  # Set ARG defaults and add labels, e.g. to build a staging variant
+  agentbay image create my-image -f ./Dockerfile -i code_latest --build-arg VERSION=1.2 --label env=staging
+
+  # Show the files that would be uploaded without uploading or building anything
+  agentbay image create my-image -f ./Dockerfile -i code_latest --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: runImageCreate,
 }
 
+var imageBuildStatusCmd = &cobra.Command{
+	Use:   "build-status <task-id>",
+	Short: "Show the status of an image build task",
+	Long: `Query an image build task once and show its status.
+
+Use the task ID printed by 'agentbay image create'.
+
+Examples:
+  # Show build status
+  agentbay image build-status task-xxxxxxxxxxxxxx
+
+  # Show build status as JSON
+  agentbay image build-status task-xxxxxxxxxxxxxx --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImageBuildStatus,
+}
+
+var imageWaitCmd = &cobra.Command{
+	Use:   "wait <task-id>",
+	Short: "Wait for an image build task to finish",
+	Long: `Wait for an existing image build task to finish, e.g. after 'image create --no-wait'
+or when the terminal running 'image create' was closed.
+
+Exit codes:
+  0  build succeeded
+  1  other error (e.g. not authenticated)
+  2  build failed
+  3  timed out while the build was still running
+
+Examples:
+  # Wait with the default timeout (45m)
+  agentbay image wait task-xxxxxxxxxxxxxx
+
+  # Wait at most 10 minutes
+  agentbay image wait task-xxxxxxxxxxxxxx --timeout 10m`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImageWait,
+}
+
+var imageLogsCmd = &cobra.Command{
+	Use:   "logs <task-id>",
+	Short: "Show the build log of an image build task",
+	Long: `Show the build log of an image build task, e.g. to see why a build failed.
+
+With --follow, new lines are printed as the build writes them until it finishes. The
+exit code is then 2 if the build failed, as with 'agentbay image wait'.
+
+Examples:
+  # Show the build log
+  agentbay image logs task-xxxxxxxxxxxxxx
+
+  # Show the last 50 lines
+  agentbay image logs task-xxxxxxxxxxxxxx --tail 50
+
+  # Stream the log of a running build
+  agentbay image logs task-xxxxxxxxxxxxxx --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImageLogs,
+}
+
 var imageListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available AgentBay images",
@@ -91,6 +170,24 @@ Examples:
 	RunE: runImageList,
 }
 
+var imageShowCmd = &cobra.Command{
+	Use:   "show <image-id>",
+	Short: "Show full details of an image",
+	Long: `Show full details of an image in one view.
+
+This command combines the image information (OS, disks, build task) with the
+resource group details of an activated image (VPC, VSwitch, policy, bandwidth).
+
+Examples:
+  # Show image details
+  agentbay image show imgc-xxxxxxxxxxxxxx
+
+  # Show image details as JSON
+  agentbay image show imgc-xxxxxxxxxxxxxx --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImageShow,
+}
+
 var imageActivateCmd = &cobra.Command{
 	Use:   "activate <image-id>",
 	Short: "Activate a User image",
@@ -137,20 +234,72 @@ Examples:
 	RunE: runImageDeactivate,
 }
 
+var imageLintCmd = &cobra.Command{
+	Use:   "lint [dockerfile]",
+	Short: "Check a Dockerfile for problems before creating an image",
+	Long: `Check a Dockerfile offline for problems that would make 'agentbay image create' or
+the build fail. Nothing is uploaded and no login is needed.
+
+The checks cover COPY/ADD sources (absolute paths, paths outside the build context,
+missing files, files excluded by .dockerignore), ADD of URLs, which are not uploaded,
+COPY --from references to stages that are not defined earlier, system-defined lines of
+an 'image init' template that were modified, and large build contexts.
+
+Diagnostics are printed as file:line: severity: message [rule]. Use --output json for
+a JSON result, or --sarif for a SARIF log that code scanning tools and editors can read.
+The command exits with status 1 when any error is found.
+
+Examples:
+  # Check ./Dockerfile
+  agentbay image lint
+
+  # Check a Dockerfile whose build context is the repository root
+  agentbay image lint images/agent.Dockerfile --context .
+
+  # Write SARIF for CI code scanning
+  agentbay image lint --sarif > lint.sarif`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImageLint,
+}
+
+var imageTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List the source images that have a Dockerfile template",
+	Long: `List the system images that 'agentbay image init' can download a Dockerfile
+template for. Use the source image ID with 'image init' and 'image create'.
+
+Examples:
+  # List source images with a Dockerfile template
+  agentbay image templates
+
+  # List them as JSON
+  agentbay image templates --output json`,
+	Args: cobra.NoArgs,
+	RunE: runImageTemplates,
+}
+
 var imageInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Download a Dockerfile template from the cloud",
-	Long: `Download a Dockerfile template from the cloud to the local root directory.
+	Long: `Download a Dockerfile template from the cloud.
 
-This command fetches a Dockerfile template from AgentBay and saves it as 'Dockerfile' 
-in the current directory. The source image ID must be specified via --sourceImageId flag.
+This command fetches a Dockerfile template from AgentBay and saves it as 'Dockerfile'
+in the current directory, or at the path given by --dockerfile. An existing file is only
+replaced with --force. The source image ID must be specified via --sourceImageId flag;
+use 'agentbay image templates' to list the source images that have a template.
 
 Examples:
   # Download Dockerfile template with source image ID
   agentbay image init --sourceImageId code-space-debian-12
   
   # Short form
-  agentbay image init -i code-space-debian-12`,
+  agentbay image init -i code-space-debian-12
+
+  # Save the template as images/agent.Dockerfile, replacing an existing file
+  agentbay image init -i code-space-debian-12 -f images/agent.Dockerfile --force
+
+  # Print the template instead of saving it
+  agentbay image init -i code-space-debian-12 --stdout`,
 	Args: cobra.NoArgs,
 	RunE: runImageInit,
 }
@@ -159,11 +308,34 @@ func init() {
 	// Add flags to image create command
 	imageCreateCmd.Flags().StringP("dockerfile", "f", "", "Path to the Dockerfile (required)")
 	imageCreateCmd.Flags().StringP("imageId", "i", "", "Source image ID to build from (required)")
+	imageCreateCmd.Flags().String("context", "", "Build context directory that ADD/COPY sources are relative to (default: the directory of the Dockerfile)")
+
+	imageCreateCmd.Flags().Bool("no-wait", false, "Return right after the build task is submitted instead of waiting for it")
+	imageCreateCmd.Flags().StringArray("build-arg", nil, "Set a build-time variable declared by ARG, as KEY=VALUE or KEY to use the environment (repeatable)")
+	imageCreateCmd.Flags().StringArray("build-arg-file", nil, "Read build-time variables from a file of KEY=VALUE lines (repeatable)")
+	imageCreateCmd.Flags().StringArray("label", nil, "Add metadata to the image, as KEY=VALUE (repeatable)")
+	imageCreateCmd.Flags().Bool("dry-run", false, "Validate the source image and Dockerfile and list the files to upload, without uploading or building")
+	imageCreateCmd.Flags().String("resume", "", "Resume an interrupted upload using the task ID printed when it failed")
+	imageCreateCmd.Flags().Bool("full-upload", false, "Upload all ADD/COPY files, even those an earlier build already uploaded")
+	imageCreateCmd.Flags().Int("concurrency", 0, fmt.Sprintf("Number of files uploaded in parallel, 1-%d (default %d, or AGENTBAY_CLI_UPLOAD_CONCURRENCY)", config.MaxUploadConcurrency, config.DefaultUploadConcurrency))
+	imageCreateCmd.Flags().String("limit-rate", "", "Cap total upload bandwidth, e.g. 512K or 10M bytes per second (default unlimited, or AGENTBAY_CLI_UPLOAD_LIMIT_RATE)")
 
 	// Mark required flags
 	imageCreateCmd.MarkFlagRequired("dockerfile")
 	imageCreateCmd.MarkFlagRequired("imageId")
 
+	// Add flags to image lint command
+	imageLintCmd.Flags().String("context", "", "Build context directory that ADD/COPY sources are relative to (default: the directory of the Dockerfile)")
+	imageLintCmd.Flags().StringP("imageId", "i", "", "Source image ID the Dockerfile will be built from (default: the one recorded by 'image init')")
+	imageLintCmd.Flags().Bool("sarif", false, "Write the diagnostics as a SARIF 2.1.0 log to stdout")
+
+	// Add flags to image wait command
+	imageWaitCmd.Flags().Duration("timeout", DefaultBuildTimeout, "Maximum time to wait for the build (e.g. 30m, 1h)")
+
+	// Add flags to image logs command
+	imageLogsCmd.Flags().BoolP("follow", "f", false, "Keep printing new log lines until the build finishes")
+	imageLogsCmd.Flags().Int("tail", 0, "Show only the last N lines of the log (default: all)")
+
 	// Add flags to image activate command
 	imageActivateCmd.Flags().IntP("cpu", "c", 0, "CPU cores (2, 4, or 8; default: 2 when not specified)")
 	imageActivateCmd.Flags().IntP("memory", "m", 0, "Memory in GB (4, 8, or 16; default: 4 when not specified)")
@@ -177,22 +349,41 @@ func init() {
 
 	// Add required flag for image init command - use sourceImageId to match API field name
 	imageInitCmd.Flags().StringP("sourceImageId", "i", "", "Source image ID (required)")
+	imageInitCmd.Flags().StringP("dockerfile", "f", "", "Path to save the Dockerfile to; a directory saves it as Dockerfile inside it (default: ./Dockerfile)")
+	imageInitCmd.Flags().Bool("force", false, "Overwrite the Dockerfile if it already exists")
+	imageInitCmd.Flags().Bool("stdout", false, "Write the template to stdout instead of a file")
+	imageInitCmd.MarkFlagsMutuallyExclusive("dockerfile", "stdout")
 
 	// Mark required flag
 	imageInitCmd.MarkFlagRequired("sourceImageId")
 
 	// Add subcommands to image command
 	ImageCmd.AddCommand(imageCreateCmd)
+	ImageCmd.AddCommand(imageBuildStatusCmd)
+	ImageCmd.AddCommand(imageWaitCmd)
+	ImageCmd.AddCommand(imageLogsCmd)
 	ImageCmd.AddCommand(imageListCmd)
+	ImageCmd.AddCommand(imageShowCmd)
 	ImageCmd.AddCommand(imageActivateCmd)
 	ImageCmd.AddCommand(imageDeactivateCmd)
 	ImageCmd.AddCommand(imageInitCmd)
+	ImageCmd.AddCommand(imageTemplatesCmd)
+	ImageCmd.AddCommand(imageLintCmd)
 }
 
 func runImageCreate(cmd *cobra.Command, args []string) error {
 	imageName := args[0]
 	dockerfilePath, _ := cmd.Flags().GetString("dockerfile")
+	contextFlag, _ := cmd.Flags().GetString("context")
 	sourceImageId, _ := cmd.Flags().GetString("imageId")
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	resumeTaskId, _ := cmd.Flags().GetString("resume")
+	fullUpload, _ := cmd.Flags().GetBool("full-upload")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	uploadCfg, err := uploadConfigFromFlags(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Validate required flags with friendly messages
 	if dockerfilePath == "" {
@@ -231,13 +422,46 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read Dockerfile: %w", err)
 	}
-	contextDir := filepath.Dir(dockerfilePath)
-	addCopyFiles, err := ParseCOPYADDSources(dockerfileContent, contextDir)
+	if !dryRun {
+		if err := checkDockerfileLock(dockerfilePath, dockerfileContent, sourceImageId); err != nil {
+			return err
+		}
+	}
+	buildArgs, labels, err := buildOptionsFromFlags(cmd)
 	if err != nil {
 		return err
 	}
+	uploadContent, unusedArgs, err := applyBuildOptions(dockerfilePath, dockerfileContent, buildArgs, labels)
+	if err != nil {
+		return err
+	}
+	if len(unusedArgs) > 0 {
+		fmt.Fprintf(progressOut(), "[WARN] Build args not declared by any ARG instruction were ignored: %s\n", strings.Join(unusedArgs, ", "))
+	}
+	if applied := len(buildArgs) - len(unusedArgs); applied > 0 || len(labels) > 0 {
+		fmt.Fprintf(progressOut(), "[INFO] Applying %d build args and %d labels to the uploaded Dockerfile\n", applied, len(labels))
+	}
+	contextDir, err := ResolveContextDir(contextFlag, dockerfilePath)
+	if err != nil {
+		return err
+	}
+	addCopyFiles, excludedFiles, err := CollectCOPYADDSources(uploadContent, contextDir)
+	if err != nil {
+		return err
+	}
+	uploadFiles, err := newUploadFiles(contextDir, addCopyFiles)
+	if err != nil {
+		return err
+	}
+	if err := checkDockerfileUploadName(dockerfilePath, uploadFiles); err != nil {
+		return err
+	}
 
-	fmt.Printf("[BUILD] Creating image '%s'...\n", imageName)
+	if dryRun {
+		fmt.Fprintf(progressOut(), "[DRY RUN] Planning image '%s', nothing will be uploaded or built\n", imageName)
+	} else {
+		fmt.Fprintf(progressOut(), "[BUILD] Creating image '%s'...\n", imageName)
+	}
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -253,12 +477,13 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 
 	// Create API client
 	apiClient := agentbay.NewClientFromConfig(cfg)
-	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Minute)
-	defer cancel()
+	// Uploads of large files may take long, so only waiting for the build is bounded
+	ctx := commandContext(cmd)
+	limiter := newBandwidthLimiter(uploadCfg.LimitRate)
 
 	// Validate source image ID exists before proceeding
-	fmt.Printf("Validating source image ID '%s'...\n", sourceImageId)
-	validateCtx, validateCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	fmt.Fprintf(progressOut(), "Validating source image ID '%s'...\n", sourceImageId)
+	validateCtx, validateCancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
 	defer validateCancel()
 
 	_, err = GetImageInfo(validateCtx, apiClient, sourceImageId)
@@ -278,29 +503,94 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 			"[NOTE] Example: agentbay image list",
 		)
 	}
-	fmt.Printf(" Done.\n")
+	fmt.Fprintf(progressOut(), " Done.\n")
+
+	if dryRun {
+		return runImageCreateDryRun(imageName, sourceImageId, dockerfilePath, dockerfileContent, uploadContent, contextDir, uploadFiles, excludedFiles)
+	}
+
+	build := &imageBuild{
+		imageName:         imageName,
+		sourceImageId:     sourceImageId,
+		dockerfilePath:    dockerfilePath,
+		dockerfileContent: dockerfileContent,
+		uploadContent:     uploadContent,
+		uploadFiles:       uploadFiles,
+		excludedFiles:     excludedFiles,
+		resumeTaskId:      resumeTaskId,
+		uploadCfg:         uploadCfg,
+		limiter:           limiter,
+		noWait:            noWait,
+	}
+	err = uploadAndBuild(ctx, apiClient, build, fullUpload)
+	if errors.Is(err, errReusedFilesBuildFailed) {
+		// The server may no longer hold the files of the earlier build
+		fmt.Fprintf(progressOut(), "[WARN] The build failed after skipping files uploaded by an earlier build\n")
+		fmt.Fprintf(progressOut(), "[INFO] Uploading all files to a new task and building again...\n")
+		err = uploadAndBuild(ctx, apiClient, build, true)
+	}
+	return err
+}
+
+// imageBuild is what 'image create' uploads and builds, once the Dockerfile and its
+// build context have been checked
+type imageBuild struct {
+	imageName         string
+	sourceImageId     string
+	dockerfilePath    string // absolute
+	dockerfileContent []byte
+	uploadContent     []byte // the Dockerfile with build args and labels applied
+	uploadFiles       []uploadFile
+	excludedFiles     []string
+	resumeTaskId      string
+	uploadCfg         config.UploadConfig
+	limiter           *bandwidthLimiter
+	noWait            bool
+}
+
+// errReusedFilesBuildFailed is returned by uploadAndBuild when a build that skipped
+// files uploaded by an earlier build failed. The server keeping those files is not
+// guaranteed, so the build is worth repeating with all files uploaded.
+var errReusedFilesBuildFailed = errors.New("build failed after reusing files of an earlier build")
+
+// uploadAndBuild uploads the Dockerfile and build context, submits the build and, unless
+// b.noWait is set, waits for it. With fullUpload, no earlier task is reused.
+func uploadAndBuild(ctx context.Context, apiClient agentbay.Client, b *imageBuild, fullUpload bool) error {
+	imageName, sourceImageId, dockerfilePath := b.imageName, b.sourceImageId, b.dockerfilePath
+	dockerfileContent, uploadContent := b.dockerfileContent, b.uploadContent
+	uploadFiles, excludedFiles, resumeTaskId := b.uploadFiles, b.excludedFiles, b.resumeTaskId
+	uploadCfg, limiter, noWait := b.uploadCfg, b.limiter, b.noWait
 
-	fmt.Printf("[STEP 1/4] Getting upload credentials...\n")
+	fmt.Fprintf(progressOut(), "[STEP 1/4] Getting upload credentials...\n")
 	sourceAgentBay := "AgentBay"
 	credReq := &client.GetDockerFileStoreCredentialRequest{
 		Source:       &sourceAgentBay,
-		FilePath:     dara.String("Dockerfile"),
+		FilePath:     dara.String(dockerfileUploadName),
 		IsDockerfile: dara.String("true"),
 	}
+	// Uploading into a task that already holds files of the build context lets them be skipped
+	profile := config.SelectedProfileName()
+	reuseTaskId := resumeTaskId
+	if reuseTaskId == "" && !fullUpload {
+		reuseTaskId = reusableUploadTask(profile, uploadFiles)
+	}
+	if reuseTaskId != "" {
+		credReq.TaskId = &reuseTaskId
+	}
 	if log.GetLevel() >= log.DebugLevel {
 		log.Debugf("[DEBUG] GetDockerFileStoreCredential Request: Source=%s FilePath=%s IsDockerfile=%s", *credReq.Source, *credReq.FilePath, *credReq.IsDockerfile)
 	}
-	fmt.Printf("Requesting upload credentials...")
-	credResp, err := apiClient.GetDockerFileStoreCredential(ctx, credReq)
+	fmt.Fprintf(progressOut(), "Requesting upload credentials...")
+	credResp, err := getDockerfileCredential(ctx, apiClient, credReq, resumeTaskId != "")
 	if err != nil {
 		log.Debugf("[DEBUG] GetDockerFileStoreCredential API call failed: %v", err)
-		fmt.Printf("[ERROR] Failed to get upload credentials. Please check your authentication and try again.\n")
+		fmt.Fprintf(progressOut(), "[ERROR] Failed to get upload credentials. Please check your authentication and try again.\n")
 		if log.GetLevel() >= log.DebugLevel {
-			fmt.Printf("[DEBUG] Error details: %v\n", err)
+			fmt.Fprintf(progressOut(), "[DEBUG] Error details: %v\n", err)
 		}
 		return fmt.Errorf("failed to get upload credentials: %w", err)
 	}
-	fmt.Printf(" Done.\n")
+	fmt.Fprintf(progressOut(), " Done.\n")
 	if credResp.Body == nil || credResp.Body.Data == nil {
 		return fmt.Errorf("invalid response: missing upload credentials")
 	}
@@ -309,88 +599,86 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 	if ossUrl == nil || taskId == nil {
 		return fmt.Errorf("invalid response: missing OSS URL or task ID")
 	}
+	if resumeTaskId != "" && *taskId != resumeTaskId {
+		fmt.Fprintf(progressOut(), "[WARN] Task %s can no longer be resumed; starting over with task %s\n", resumeTaskId, *taskId)
+	} else if reuseTaskId != "" && *taskId != reuseTaskId {
+		log.Debugf("[DEBUG] Previous upload task %s was not reused, uploading all files to %s", reuseTaskId, *taskId)
+	}
+	journal, err := loadUploadJournal(*taskId)
+	if err != nil {
+		return err
+	}
 
-	fmt.Printf("[STEP 2/4] Uploading Dockerfile...\n")
-	fmt.Printf("Uploading file...")
-	if err = uploadFileToOSS(dockerfilePath, *ossUrl); err != nil {
-		fmt.Printf("[ERROR] Failed to upload Dockerfile. Please check your network connection and try again.\n")
+	fmt.Fprintf(progressOut(), "[STEP 2/4] Uploading Dockerfile...\n")
+	uploadDockerfilePath := dockerfilePath
+	if !bytes.Equal(uploadContent, dockerfileContent) {
+		// Build args and labels were applied; upload the rewritten Dockerfile
+		tmpFile, err := os.CreateTemp("", "agentbay-Dockerfile-*")
+		if err != nil {
+			return fmt.Errorf("create temp Dockerfile: %w", err)
+		}
+		defer os.Remove(tmpFile.Name())
+		if _, err := tmpFile.Write(uploadContent); err != nil {
+			_ = tmpFile.Close()
+			return fmt.Errorf("write temp Dockerfile: %w", err)
+		}
+		if err := tmpFile.Close(); err != nil {
+			return fmt.Errorf("close temp Dockerfile: %w", err)
+		}
+		uploadDockerfilePath = tmpFile.Name()
+	}
+	fmt.Fprintf(progressOut(), "Uploading file...")
+	if _, err = uploadFileToOSS(ctx, uploadDockerfilePath, *ossUrl, nil, limiter); err != nil {
+		fmt.Fprintf(progressOut(), "[ERROR] Failed to upload Dockerfile. Please check your network connection and try again.\n")
 		if log.GetLevel() >= log.DebugLevel {
-			fmt.Printf("[DEBUG] Error details: %v\n", err)
+			fmt.Fprintf(progressOut(), "[DEBUG] Error details: %v\n", err)
 		}
 		return fmt.Errorf("failed to upload Dockerfile: %w", err)
 	}
-	fmt.Printf(" Done.\n")
+	fmt.Fprintf(progressOut(), " Done.\n")
 
-	if len(addCopyFiles) > 0 {
-		fmt.Printf("[STEP 3/4] Uploading ADD/COPY files (%d files)...\n", len(addCopyFiles))
-		type fileItem struct{ absPath, relPath string }
-		var files []fileItem
-		for _, absPath := range addCopyFiles {
-			relPath, err := RelativePathForUpload(contextDir, absPath)
-			if err != nil {
-				return fmt.Errorf("failed to get relative path for %s: %w", absPath, err)
+	if len(excludedFiles) > 0 {
+		fmt.Fprintf(progressOut(), "[INFO] %d files excluded by %s\n", len(excludedFiles), DockerIgnoreFile)
+		for _, absPath := range excludedFiles {
+			log.Debugf("[DEBUG] Excluded: %s", absPath)
+		}
+	}
+	var reusedFiles int
+	var bytesSaved int64
+	if len(uploadFiles) > 0 {
+		fmt.Fprintf(progressOut(), "[STEP 3/4] Uploading ADD/COPY files (%d files)...\n", len(uploadFiles))
+		var files []uploadFile
+		for _, f := range uploadFiles {
+			if size, ok := journal.completed(f.relPath, f.absPath); ok {
+				log.Debugf("[DEBUG] Already uploaded, skipping: %s", f.relPath)
+				reusedFiles++
+				bytesSaved += size
+				continue
 			}
-			files = append(files, fileItem{absPath: absPath, relPath: relPath})
-		}
-		const maxConcurrent = 10
-		sem := make(chan struct{}, maxConcurrent)
-		var credsMu sync.Mutex
-		creds := make(map[string]string)
-		var firstCredErr error
-		var credWg sync.WaitGroup
-		fmt.Printf("Requesting upload credentials for %d files (parallel)...\n", len(files))
-		for _, f := range files {
-			f := f
-			credWg.Add(1)
-			go func() {
-				defer credWg.Done()
-				sem <- struct{}{}
-				defer func() { <-sem }()
-				credReq := &client.GetDockerFileStoreCredentialRequest{
-					Source:       &sourceAgentBay,
-					FilePath:     &f.relPath,
-					IsDockerfile: dara.String("false"),
-					TaskId:       taskId,
-				}
-				resp, err := apiClient.GetDockerFileStoreCredential(ctx, credReq)
-				if err != nil {
-					credsMu.Lock()
-					if firstCredErr == nil {
-						firstCredErr = fmt.Errorf("failed to get upload credentials for %s: %w", f.relPath, err)
-					}
-					credsMu.Unlock()
-					return
-				}
-				if resp.Body == nil || resp.Body.Data == nil {
-					credsMu.Lock()
-					if firstCredErr == nil {
-						firstCredErr = fmt.Errorf("invalid response: missing upload credentials for %s", f.relPath)
-					}
-					credsMu.Unlock()
-					return
-				}
-				ossUrl := resp.Body.Data.GetOssUrl()
-				if ossUrl == nil || *ossUrl == "" {
-					credsMu.Lock()
-					if firstCredErr == nil {
-						firstCredErr = fmt.Errorf("invalid response: missing OSS URL for %s", f.relPath)
-					}
-					credsMu.Unlock()
-					return
-				}
-				credsMu.Lock()
-				creds[f.absPath] = *ossUrl
-				credsMu.Unlock()
-			}()
+			files = append(files, f)
 		}
-		credWg.Wait()
-		if firstCredErr != nil {
-			fmt.Printf("[ERROR] %v\n", firstCredErr)
-			return firstCredErr
+		if reusedFiles > 0 {
+			if resumeTaskId != "" {
+				fmt.Fprintf(progressOut(), "[INFO] Resuming: %d of %d files already uploaded\n", reusedFiles, len(uploadFiles))
+			} else {
+				fmt.Fprintf(progressOut(), "[INFO] %d of %d files already uploaded by an earlier build, skipping them (%s saved)\n", reusedFiles, len(uploadFiles), formatBytes(bytesSaved))
+			}
+		}
+		fmt.Fprintf(progressOut(), "Requesting upload credentials for %d files (parallel)...\n", len(files))
+		creds, err := requestUploadCredentials(ctx, apiClient, *taskId, files, uploadCfg.Concurrency)
+		if err != nil {
+			fmt.Fprintf(progressOut(), "[ERROR] %v\n", err)
+			return err
 		}
+		sem := make(chan struct{}, uploadCfg.Concurrency)
+		var errMu sync.Mutex
 		var firstUploadErr error
 		var uploadWg sync.WaitGroup
-		fmt.Printf("Uploading %d files (parallel)...\n", len(files))
+		var totalBytes int64
+		for _, f := range files {
+			totalBytes += f.size
+		}
+		progress := newTransferProgress(progressOut(), "Uploading", len(files), totalBytes)
 		for _, f := range files {
 			f := f
 			ossUrl := creds[f.absPath]
@@ -399,24 +687,34 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 				defer uploadWg.Done()
 				sem <- struct{}{}
 				defer func() { <-sem }()
-				if err := uploadFileToOSS(f.absPath, ossUrl); err != nil {
-					credsMu.Lock()
-					if firstUploadErr == nil {
-						firstUploadErr = fmt.Errorf("failed to upload %s: %w", f.relPath, err)
+				res, err := uploadFileToOSS(ctx, f.absPath, ossUrl, progress.file(f.relPath, f.size), limiter)
+				if err == nil {
+					if err := journal.record(f.relPath, f.absPath, res); err != nil {
+						log.Debugf("[DEBUG] Failed to update upload journal: %v", err)
 					}
-					credsMu.Unlock()
+					return
 				}
+				errMu.Lock()
+				if firstUploadErr == nil {
+					firstUploadErr = fmt.Errorf("failed to upload %s: %w", f.relPath, err)
+				}
+				errMu.Unlock()
 			}()
 		}
 		uploadWg.Wait()
 		if firstUploadErr != nil {
-			fmt.Printf("[ERROR] %v\n", firstUploadErr)
+			progress.abort()
+			fmt.Fprintf(progressOut(), "[ERROR] %v\n", firstUploadErr)
+			if ctx.Err() == nil {
+				fmt.Fprintf(progressOut(), "[TIP] Files uploaded so far are kept. Resume with:\n")
+				fmt.Fprintf(progressOut(), "[TIP]   agentbay image create %s -f %s -i %s --resume %s\n", imageName, dockerfilePath, sourceImageId, *taskId)
+			}
 			return firstUploadErr
 		}
-		fmt.Printf(" Done.\n")
+		progress.finish()
 	}
 
-	fmt.Printf("[STEP 4/4] Creating Docker image task...\n")
+	fmt.Fprintf(progressOut(), "[STEP 4/4] Creating Docker image task...\n")
 
 	createReq := &client.CreateDockerImageTaskRequest{
 		ImageName:     &imageName,
@@ -442,21 +740,21 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("Creating image task...")
+	fmt.Fprintf(progressOut(), "Creating image task...")
 	createResp, err := apiClient.CreateDockerImageTask(ctx, createReq)
 	if err != nil {
 		// Show user-friendly error message in non-verbose mode
-		fmt.Printf("[ERROR] Failed to create Docker image task. Please try again.\n")
+		fmt.Fprintf(progressOut(), "[ERROR] Failed to create Docker image task. Please try again.\n")
 		if log.GetLevel() >= log.DebugLevel {
-			fmt.Printf("[DEBUG] Error details: %v\n", err)
+			fmt.Fprintf(progressOut(), "[DEBUG] Error details: %v\n", err)
 		}
 		// Try to extract Request ID from response if available
 		if createResp != nil && createResp.Body != nil && createResp.Body.GetRequestId() != nil {
-			fmt.Printf("[DEBUG] Request ID: %s\n", *createResp.Body.GetRequestId())
+			fmt.Fprintf(progressOut(), "[DEBUG] Request ID: %s\n", *createResp.Body.GetRequestId())
 		}
 		return fmt.Errorf("failed to create Docker image task: %w", err)
 	}
-	fmt.Printf(" Done.\n")
+	fmt.Fprintf(progressOut(), " Done.\n")
 
 	// Debug: Print create task response (simplified)
 	if log.GetLevel() >= log.DebugLevel && createResp.Body != nil && createResp.Body.Data != nil {
@@ -471,7 +769,7 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 	if createResp.Body == nil || createResp.Body.Data == nil {
 		// Print Request ID for debugging if available
 		if createResp != nil && createResp.Body != nil && createResp.Body.GetRequestId() != nil {
-			fmt.Printf("[DEBUG] Request ID: %s\n", *createResp.Body.GetRequestId())
+			fmt.Fprintf(progressOut(), "[DEBUG] Request ID: %s\n", *createResp.Body.GetRequestId())
 		}
 		return fmt.Errorf("invalid response: missing task data")
 	}
@@ -480,147 +778,145 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 	if finalTaskId == nil {
 		// Print Request ID for debugging
 		if createResp.Body.GetRequestId() != nil {
-			fmt.Printf("[DEBUG] Request ID: %s\n", *createResp.Body.GetRequestId())
+			fmt.Fprintf(progressOut(), "[DEBUG] Request ID: %s\n", *createResp.Body.GetRequestId())
 		}
 		return fmt.Errorf("invalid response: missing final task ID")
 	}
+	if noWait {
+		fmt.Fprintf(progressOut(), "[INFO] Build submitted (Task ID: %s)\n", *finalTaskId)
+		fmt.Fprintf(progressOut(), "[TIP] Check progress with 'agentbay image build-status %s'\n", *finalTaskId)
+		fmt.Fprintf(progressOut(), "[TIP] Wait for completion with 'agentbay image wait %s'\n", *finalTaskId)
+		if reusedFiles > 0 && resumeTaskId == "" {
+			fmt.Fprintf(progressOut(), "[TIP] Files uploaded by an earlier build were skipped; if the build fails, run again with --full-upload\n")
+		}
+		return renderOutput(imageCreateOutput{
+			ImageName:     imageName,
+			SourceImageID: sourceImageId,
+			TaskID:        *finalTaskId,
+			Status:        "SUBMITTED",
+			ReusedFiles:   reusedFiles,
+			BytesSaved:    bytesSaved,
+		}, nil)
+	}
 
-	fmt.Printf("[STEP 4/4] Building image (Task ID: %s)...\n", *finalTaskId)
+	fmt.Fprintf(progressOut(), "[STEP 4/4] Building image (Task ID: %s)...\n", *finalTaskId)
 
 	// Step 4: Poll for task completion
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("build timeout: %w", ctx.Err())
-		case <-ticker.C:
-			sourceAgentBay := "AgentBay"
-			taskReq := &client.GetDockerImageTaskRequest{
-				Source: &sourceAgentBay,
-				TaskId: finalTaskId,
-			}
+	pollCtx, cancel := context.WithTimeout(ctx, DefaultBuildTimeout)
+	defer cancel()
+	st, err := pollBuildTask(pollCtx, apiClient, *finalTaskId, buildPollInterval)
+	if err != nil {
+		return err
+	}
+	if isBuildFailed(st.Status) {
+		printBuildLogTail(ctx, apiClient, st.TaskID, buildLogTailLines)
+		if reusedFiles > 0 && resumeTaskId == "" && !isDockerfileValidationError(st.Message) {
+			forgetUploadTask(*taskId)
+			return errReusedFilesBuildFailed
+		}
+		return reportBuildFailure(st)
+	}
+	// Only the files of a successful build are worth reusing
+	if isBuildSucceeded(st.Status) {
+		if err := recordUploadTask(journal, profile, dockerfilePath); err != nil {
+			log.Debugf("[DEBUG] Failed to update upload manifest: %v", err)
+		}
+	}
 
-			// Debug: Print polling request (simplified)
-			if log.GetLevel() >= log.DebugLevel {
-				log.Debugf("[DEBUG] GetDockerImageTask Request:")
-				if taskReq.Source != nil {
-					log.Debugf("[DEBUG] - Source: %s", *taskReq.Source)
-				}
-				if taskReq.TaskId != nil {
-					log.Debugf("[DEBUG] - TaskId: %s", *taskReq.TaskId)
-				}
-			}
+	fmt.Fprintf(progressOut(), "[SUCCESS] ✅ Image '%s' created successfully!\n", imageName)
+	if st.ImageID != "" {
+		fmt.Fprintf(progressOut(), "[RESULT] Image ID: %s\n", st.ImageID)
+	}
+	fmt.Fprintf(progressOut(), "[DOC] Task ID: %s\n", *finalTaskId)
+	return renderOutput(imageCreateOutput{
+		ImageName:     imageName,
+		SourceImageID: sourceImageId,
+		TaskID:        *finalTaskId,
+		ImageID:       st.ImageID,
+		Status:        st.Status,
+		ReusedFiles:   reusedFiles,
+		BytesSaved:    bytesSaved,
+	}, nil)
+}
 
-			taskResp, err := apiClient.GetDockerImageTask(ctx, taskReq)
-			if err != nil {
-				log.Debugf("[DEBUG] GetDockerImageTask Polling Error: %v", err)
-				fmt.Printf("[WARN] Warning: Failed to check task status: %v\n", err)
-				// Try to extract Request ID from response if available
-				if taskResp != nil && taskResp.Body != nil && taskResp.Body.GetRequestId() != nil {
-					fmt.Printf("[DEBUG] Request ID: %s\n", *taskResp.Body.GetRequestId())
-				}
-				continue // Continue polling on API errors
-			}
+func runImageBuildStatus(cmd *cobra.Command, args []string) error {
+	taskId := args[0]
 
-			// Debug: Print polling response (simplified)
-			if log.GetLevel() >= log.DebugLevel && taskResp.Body != nil && taskResp.Body.Data != nil {
-				status := taskResp.Body.Data.GetStatus()
-				taskMsg := taskResp.Body.Data.GetTaskMsg()
-				imageId := taskResp.Body.Data.GetImageId()
+	apiClient, err := newAuthenticatedClient()
+	if err != nil {
+		return err
+	}
 
-				log.Debugf("[DEBUG] GetDockerImageTask Response:")
-				if status != nil {
-					log.Debugf("[DEBUG] - Status: %s", *status)
-				}
-				if taskMsg != nil && *taskMsg != "" {
-					log.Debugf("[DEBUG] - Message: %s", *taskMsg)
-				}
-				if imageId != nil && *imageId != "" {
-					log.Debugf("[DEBUG] - ImageId: %s", *imageId)
-				}
-			}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
 
-			if taskResp.Body == nil || taskResp.Body.Data == nil {
-				fmt.Printf("[WARN] Warning: Invalid response format\n")
-				// Print Request ID for debugging if available
-				if taskResp != nil && taskResp.Body != nil && taskResp.Body.GetRequestId() != nil {
-					fmt.Printf("[DEBUG] Request ID: %s\n", *taskResp.Body.GetRequestId())
-				}
-				continue
-			}
+	fmt.Fprintf(progressOut(), "Checking build task '%s'...", taskId)
+	st, err := getBuildTaskStatus(ctx, apiClient, taskId)
+	if err != nil {
+		fmt.Fprintf(progressOut(), " Failed.\n")
+		return err
+	}
+	fmt.Fprintf(progressOut(), " Done.\n")
 
-			status := taskResp.Body.Data.GetStatus()
-			taskMsg := taskResp.Body.Data.GetTaskMsg()
-			imageId := taskResp.Body.Data.GetImageId()
+	return renderOutput(st, func() {
+		fmt.Printf("%-*s %s\n", imageDetailLabelW, "Task ID:", st.TaskID)
+		fmt.Printf("%-*s %s\n", imageDetailLabelW, "Status:", st.Status)
+		if st.ImageID != "" {
+			fmt.Printf("%-*s %s\n", imageDetailLabelW, "Image ID:", st.ImageID)
+		}
+		if st.Message != "" {
+			fmt.Printf("%-*s %s\n", imageDetailLabelW, "Message:", st.Message)
+		}
+	})
+}
 
-			if status == nil {
-				fmt.Printf("[WARN] Warning: Missing status in response\n")
-				// Print Request ID for debugging
-				if taskResp.Body.GetRequestId() != nil {
-					fmt.Printf("[DEBUG] Request ID: %s\n", *taskResp.Body.GetRequestId())
-				}
-				continue
-			}
+func runImageWait(cmd *cobra.Command, args []string) error {
+	taskId := args[0]
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than 0")
+	}
 
-			fmt.Printf("[STATUS] Build status: %s\n", *status)
+	apiClient, err := newAuthenticatedClient()
+	if err != nil {
+		return err
+	}
 
-			if taskMsg != nil && *taskMsg != "" {
-				fmt.Printf("[MESSAGE] %s\n", *taskMsg)
-			}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), timeout)
+	defer cancel()
 
-			switch *status {
-			case "SUCCESS", "Finished":
-				fmt.Printf("[SUCCESS] ✅ Image '%s' created successfully!\n", imageName)
-				if imageId != nil && *imageId != "" {
-					fmt.Printf("[RESULT] Image ID: %s\n", *imageId)
-				}
-				fmt.Printf("[DOC] Task ID: %s\n", *finalTaskId)
-				return nil
-			case "FAILED", "Failed":
-				// Check if this is a Dockerfile validation error
-				isValidationError := false
-				if taskMsg != nil && *taskMsg != "" {
-					isValidationError = isDockerfileValidationError(*taskMsg)
-				}
+	fmt.Fprintf(progressOut(), "[WAIT] Waiting for build task '%s' (timeout: %v)...\n", taskId, timeout)
+	st, err := pollBuildTask(ctx, apiClient, taskId, buildPollInterval)
+	if err != nil {
+		return err
+	}
+	if isBuildFailed(st.Status) {
+		printBuildLogTail(ctx, apiClient, st.TaskID, buildLogTailLines)
+		return reportBuildFailure(st)
+	}
 
-				if isValidationError {
-					// Dockerfile validation failed
-					fmt.Printf("[ERROR] ❌ Dockerfile validation failed\n")
-					if taskMsg != nil && *taskMsg != "" {
-						fmt.Printf("[ERROR] Validation error: %s\n", *taskMsg)
-					}
-					fmt.Printf("[TIP] Please check your Dockerfile and ensure you haven't modified system-defined lines.\n")
-					fmt.Printf("[TIP] Use 'agentbay image init' to download a valid template.\n")
-					// Print Request ID for debugging
-					if taskResp.Body.GetRequestId() != nil {
-						fmt.Printf("[DEBUG] Request ID: %s\n", *taskResp.Body.GetRequestId())
-					}
-					fmt.Printf("[DOC] Task ID: %s\n", *finalTaskId)
-					return fmt.Errorf("dockerfile validation failed")
-				} else {
-					// Actual build failure
-					fmt.Printf("[ERROR] ❌ Image build failed\n")
-					if taskMsg != nil && *taskMsg != "" {
-						fmt.Printf("[ERROR] Error details: %s\n", *taskMsg)
-					}
-					// Print Request ID for debugging
-					if taskResp.Body.GetRequestId() != nil {
-						fmt.Printf("[DEBUG] Request ID: %s\n", *taskResp.Body.GetRequestId())
-					}
-					fmt.Printf("[DOC] Task ID: %s\n", *finalTaskId)
-					return fmt.Errorf("image build failed")
-				}
-			case "RUNNING", "PENDING", "Preparing":
-				// Continue polling
-				continue
-			default:
-				fmt.Printf("[WARN] Warning: Unknown status: %s\n", *status)
-				continue
-			}
-		}
+	fmt.Fprintf(progressOut(), "[SUCCESS] ✅ Image build completed!\n")
+	if st.ImageID != "" {
+		fmt.Fprintf(progressOut(), "[RESULT] Image ID: %s\n", st.ImageID)
 	}
+	return renderOutput(st, nil)
+}
+
+// newAuthenticatedClient loads the configuration and returns an API client, failing
+// with the usual messages when the user is not logged in
+func newAuthenticatedClient() (agentbay.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] Failed to load configuration: %v\n", err)
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if !cfg.IsAuthenticated() {
+		fmt.Fprintf(os.Stderr, "[ERROR] Not authenticated. Please run 'agentbay login' first\n")
+		return nil, fmt.Errorf("not authenticated. Please run 'agentbay login' first")
+	}
+
+	return agentbay.NewClientFromConfig(cfg), nil
 }
 
 func runImageList(cmd *cobra.Command, args []string) error {
@@ -640,7 +936,7 @@ func runImageList(cmd *cobra.Command, args []string) error {
 	} else {
 		fetchMessage = "[LIST] Fetching available AgentBay user images...\n"
 	}
-	fmt.Print(fetchMessage)
+	fmt.Fprint(progressOut(), fetchMessage)
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -656,7 +952,7 @@ func runImageList(cmd *cobra.Command, args []string) error {
 
 	// Create API client
 	apiClient := agentbay.NewClientFromConfig(cfg)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
 	defer cancel()
 
 	// Prepare request
@@ -712,17 +1008,17 @@ func runImageList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Make API call
-	fmt.Printf("Requesting image list...")
+	fmt.Fprintf(progressOut(), "Requesting image list...")
 	resp, err := apiClient.ListMcpImages(ctx, req)
 	if err != nil {
 		log.Debugf("[DEBUG] ListMcpImages API call failed: %v", err)
-		fmt.Printf("[ERROR] Failed to fetch image list. Please check your authentication and try again.\n")
+		fmt.Fprintf(progressOut(), "[ERROR] Failed to fetch image list. Please check your authentication and try again.\n")
 		if log.GetLevel() >= log.DebugLevel {
-			fmt.Printf("[DEBUG] Error details: %v\n", err)
+			fmt.Fprintf(progressOut(), "[DEBUG] Error details: %v\n", err)
 		}
 		return fmt.Errorf("failed to fetch image list: %w", err)
 	}
-	fmt.Printf(" Done.\n")
+	fmt.Fprintf(progressOut(), " Done.\n")
 
 	// Debug: Print response details
 	if log.GetLevel() >= log.DebugLevel && resp.Body != nil {
@@ -752,66 +1048,66 @@ func runImageList(cmd *cobra.Command, args []string) error {
 	}
 
 	images := resp.Body.GetData()
-	if len(images) == 0 {
-		fmt.Printf("\n[EMPTY] No images found.\n")
-		return nil
-	}
-
-	// Display results
-	fmt.Printf("\n[OK] Found %d images", len(images))
+	var totalCount int32
 	if resp.Body.GetTotalCount() != nil {
-		fmt.Printf(" (Total: %d)", *resp.Body.GetTotalCount())
+		totalCount = *resp.Body.GetTotalCount()
 	}
-	fmt.Printf("\n")
 
-	if resp.Body.GetPageStart() != nil && resp.Body.GetPageSize() != nil && resp.Body.GetTotalCount() != nil {
-		pageSize := *resp.Body.GetPageSize()
-		if pageSize > 0 {
-			totalPages := (*resp.Body.GetTotalCount() + pageSize - 1) / pageSize
-			fmt.Printf("[PAGE] Page %d of %d (Page Size: %d)\n\n", *resp.Body.GetPageStart(), totalPages, pageSize)
+	return renderOutput(newImageListOutput(images, totalCount, resp.Body), func() {
+		if len(images) == 0 {
+			fmt.Printf("\n[EMPTY] No images found.\n")
+			return
 		}
-	}
 
-	if len(images) == 0 {
-		fmt.Println("[EMPTY] No images found.")
-		return nil
-	}
+		// Display results
+		fmt.Printf("\n[OK] Found %d images", len(images))
+		if resp.Body.GetTotalCount() != nil {
+			fmt.Printf(" (Total: %d)", *resp.Body.GetTotalCount())
+		}
+		fmt.Printf("\n")
 
-	// Display image table with consistent formatting
-	fmt.Printf("%s %s %s %s %s %s\n",
-		padString("IMAGE ID", 25),
-		padString("IMAGE NAME", 30),
-		padString("TYPE", 20),
-		padString("STATUS", 15),
-		padString("OS", 18),
-		"APPLY SCENE")
-	fmt.Printf("%s %s %s %s %s %s\n",
-		padString("--------", 25),
-		padString("----------", 30),
-		padString("----", 20),
-		padString("------", 15),
-		padString("--", 18),
-		"-----------")
-
-	for _, image := range images {
-		imageId := getStringValue(image.GetImageId())
-		imageName := getStringValue(image.GetImageName())
-		imageType := getStringValue(image.GetImageBuildType())
-		status := formatImageStatus(getStringValue(image.GetImageResourceStatus()))
-		osInfo := formatOSInfo(image.GetImageInfo())
-		applyScene := getStringValue(image.GetImageApplyScene())
-
-		// 使用支持中文的填充和截断函数，手动控制列间距
-		fmt.Printf("%s %s %s %s %s %s\n",
-			padString(truncateString(imageId, 25), 25),
-			padString(truncateString(imageName, 30), 30),
-			padString(truncateString(imageType, 20), 20),
-			padString(truncateString(status, 15), 15),
-			padString(truncateString(osInfo, 18), 18),
-			truncateString(applyScene, 15)) // 最后一列不需要填充
-	}
+		if resp.Body.GetPageStart() != nil && resp.Body.GetPageSize() != nil && resp.Body.GetTotalCount() != nil {
+			pageSize := *resp.Body.GetPageSize()
+			if pageSize > 0 {
+				totalPages := (*resp.Body.GetTotalCount() + pageSize - 1) / pageSize
+				fmt.Printf("[PAGE] Page %d of %d (Page Size: %d)\n\n", *resp.Body.GetPageStart(), totalPages, pageSize)
+			}
+		}
 
-	return nil
+		// Display image table with consistent formatting
+		fmt.Printf("%s %s %s %s %s %s\n",
+			padString("IMAGE ID", 25),
+			padString("IMAGE NAME", 30),
+			padString("TYPE", 20),
+			padString("STATUS", 15),
+			padString("OS", 18),
+			"APPLY SCENE")
+		fmt.Printf("%s %s %s %s %s %s\n",
+			padString("--------", 25),
+			padString("----------", 30),
+			padString("----", 20),
+			padString("------", 15),
+			padString("--", 18),
+			"-----------")
+
+		for _, image := range images {
+			imageId := getStringValue(image.GetImageId())
+			imageName := getStringValue(image.GetImageName())
+			imageType := getStringValue(image.GetImageBuildType())
+			status := formatImageStatus(getStringValue(image.GetImageResourceStatus()))
+			osInfo := formatOSInfo(image.GetImageInfo())
+			applyScene := getStringValue(image.GetImageApplyScene())
+
+			// 使用支持中文的填充和截断函数，手动控制列间距
+			fmt.Printf("%s %s %s %s %s %s\n",
+				padString(truncateString(imageId, 25), 25),
+				padString(truncateString(imageName, 30), 30),
+				padString(truncateString(imageType, 20), 20),
+				padString(truncateString(status, 15), 15),
+				padString(truncateString(osInfo, 18), 18),
+				truncateString(applyScene, 15)) // 最后一列不需要填充
+		}
+	})
 }
 
 // Helper functions for formatting table output
@@ -986,39 +1282,69 @@ func formatOSInfo(imageInfo *client.ListMcpImagesResponseBodyDataImageInfo) stri
 	return osName
 }
 
-func uploadFileToOSS(localPath, ossUrl string) error {
-	log.Debugf("[DEBUG] Starting file upload: %s", localPath)
-	content, err := os.ReadFile(localPath)
-	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+// newImageStateOutput builds the structured result for activate/deactivate
+func newImageStateOutput(imageId string, info *ImageInfo, resourceStatus string, changed bool) imageStateOutput {
+	return imageStateOutput{
+		ImageID:        imageId,
+		ImageType:      info.ImageType,
+		ResourceStatus: resourceStatus,
+		Status:         TranslateImageResourceStatus(resourceStatus),
+		Changed:        changed,
 	}
-	req, err := http.NewRequest(http.MethodPut, ossUrl, bytes.NewReader(content))
+}
+
+// DefaultActivateCPU and DefaultActivateMemory are the default resource allocation when user does not specify --cpu/--memory
+const DefaultActivateCPU = 2
+const DefaultActivateMemory = 4
+
+func runImageShow(cmd *cobra.Command, args []string) error {
+	imageId := args[0]
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
 	if err != nil {
-		return fmt.Errorf("failed to create upload request: %w", err)
+		fmt.Fprintf(os.Stderr, "[ERROR] Failed to load configuration: %v\n", err)
+		return fmt.Errorf("failed to load configuration: %w", err)
 	}
-	// OSS may return 307/308 redirect; without GetBody the redirect request sends an empty body and the object is stored empty.
-	req.GetBody = func() (io.ReadCloser, error) {
-		return io.NopCloser(bytes.NewReader(content)), nil
+
+	if !cfg.IsAuthenticated() {
+		fmt.Fprintf(os.Stderr, "[ERROR] Not authenticated. Please run 'agentbay login' first\n")
+		return fmt.Errorf("not authenticated. Please run 'agentbay login' first")
 	}
-	req.Header.Set("Content-Type", "application/octet-stream")
-	req.Header.Set("User-Agent", "AgentBay-CLI/1.0")
-	req.ContentLength = int64(len(content))
-	httpClient := &http.Client{Timeout: 60 * time.Second}
-	resp, err := httpClient.Do(req)
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 60*time.Second)
+	defer cancel()
+
+	fmt.Fprintf(progressOut(), "Fetching image info...")
+	data, err := getMcpImageInfoData(ctx, apiClient, imageId)
 	if err != nil {
-		return fmt.Errorf("failed to upload: %w", err)
+		fmt.Fprintf(progressOut(), " Failed.\n")
+		if IsAuthenticationError(err) {
+			fmt.Fprintf(os.Stderr, "[ERROR] Authentication failed. Please run 'agentbay login' again\n")
+		}
+		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(body))
+	fmt.Fprintf(progressOut(), " Done.\n")
+
+	// Resource group details only come from ListMcpImages; the rest is still useful without them
+	fmt.Fprintf(progressOut(), "Fetching resource group info...")
+	listed, err := findListedImage(ctx, apiClient, imageId, listImageType(data))
+	if err != nil {
+		fmt.Fprintf(progressOut(), " Failed.\n")
+		log.Debugf("[DEBUG] ListMcpImages lookup for %s failed: %v", imageId, err)
+		fmt.Fprintf(progressOut(), "[WARN] Resource group details unavailable: %v\n", err)
+	} else {
+		fmt.Fprintf(progressOut(), " Done.\n")
 	}
-	return nil
-}
 
-// DefaultActivateCPU and DefaultActivateMemory are the default resource allocation when user does not specify --cpu/--memory
-const DefaultActivateCPU = 2
-const DefaultActivateMemory = 4
+	detail := newImageDetailOutput(imageId, data, listed)
+
+	return renderOutput(detail, func() {
+		printImageDetail(detail)
+	})
+}
 
 func runImageActivate(cmd *cobra.Command, args []string) error {
 	imageId := args[0]
@@ -1036,8 +1362,8 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 		return printCPUMemoryValidationError(err)
 	}
 
-	fmt.Printf("[ACTIVATE] Activating image '%s'...\n", imageId)
-	fmt.Printf("[RESOURCE] CPU: %d cores, Memory: %d GB\n", cpu, memory)
+	fmt.Fprintf(progressOut(), "[ACTIVATE] Activating image '%s'...\n", imageId)
+	fmt.Fprintf(progressOut(), "[RESOURCE] CPU: %d cores, Memory: %d GB\n", cpu, memory)
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -1055,27 +1381,27 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 	apiClient := agentbay.NewClientFromConfig(cfg)
 
 	// Use longer timeout for status check (not for the full polling)
-	statusCtx, statusCancel := context.WithTimeout(context.Background(), 60*time.Second)
+	statusCtx, statusCancel := context.WithTimeout(commandContext(cmd), 60*time.Second)
 	defer statusCancel()
 
 	// Check current image status and type using GetMcpImageInfo
-	fmt.Printf("Checking current image status...")
+	fmt.Fprintf(progressOut(), "Checking current image status...")
 	imageInfo, err := GetImageInfo(statusCtx, apiClient, imageId)
 	if err != nil {
-		fmt.Printf(" Failed.\n")
+		fmt.Fprintf(progressOut(), " Failed.\n")
 		return fmt.Errorf("failed to get image info: %w", err)
 	}
-	fmt.Printf(" Done.\n")
-	fmt.Printf("[INFO] Image Type: %s\n", imageInfo.ImageType)
-	fmt.Printf("[INFO] Current Status: %s\n", TranslateImageResourceStatus(imageInfo.ResourceStatus))
+	fmt.Fprintf(progressOut(), " Done.\n")
+	fmt.Fprintf(progressOut(), "[INFO] Image Type: %s\n", imageInfo.ImageType)
+	fmt.Fprintf(progressOut(), "[INFO] Current Status: %s\n", TranslateImageResourceStatus(imageInfo.ResourceStatus))
 
 	// Check if this is a System image
 	if IsSystemImage(imageInfo.ImageType) {
-		fmt.Printf("[INFO] This is a System image.\n")
-		fmt.Printf("[INFO] System images are always available and do not need to be activated.\n")
-		fmt.Printf("[INFO] You can use this image directly without activation.\n")
-		fmt.Printf("[INFO] Image ID: %s\n", imageId)
-		return nil
+		fmt.Fprintf(progressOut(), "[INFO] This is a System image.\n")
+		fmt.Fprintf(progressOut(), "[INFO] System images are always available and do not need to be activated.\n")
+		fmt.Fprintf(progressOut(), "[INFO] You can use this image directly without activation.\n")
+		fmt.Fprintf(progressOut(), "[INFO] Image ID: %s\n", imageId)
+		return renderOutput(newImageStateOutput(imageId, imageInfo, imageInfo.ResourceStatus, false), nil)
 	}
 
 	// Check if this is a User image
@@ -1085,15 +1411,15 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 
 	// Check if image is already activated
 	if IsActivated(imageInfo.ResourceStatus) {
-		fmt.Printf("[OK] Image is already activated! No action needed.\n")
-		fmt.Printf("[INFO] Image ID: %s\n", imageId)
-		return nil
+		fmt.Fprintf(progressOut(), "[OK] Image is already activated! No action needed.\n")
+		fmt.Fprintf(progressOut(), "[INFO] Image ID: %s\n", imageId)
+		return renderOutput(newImageStateOutput(imageId, imageInfo, imageInfo.ResourceStatus, false), nil)
 	}
 
 	// Check if image is currently activating
 	shouldCreateResourceGroup := true
 	if IsActivating(imageInfo.ResourceStatus) {
-		fmt.Printf("[INFO] Image is currently activating, waiting for completion...\n")
+		fmt.Fprintf(progressOut(), "[INFO] Image is currently activating, waiting for completion...\n")
 		shouldCreateResourceGroup = false
 	} else if IsDeactivated(imageInfo.ResourceStatus) {
 		// Image is deactivated, proceed with activation
@@ -1105,7 +1431,7 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 
 	// Create resource group if needed
 	if shouldCreateResourceGroup {
-		fmt.Printf("Creating resource group...")
+		fmt.Fprintf(progressOut(), "Creating resource group...")
 		createReq := &client.CreateResourceGroupRequest{
 			ImageId: dara.String(imageId),
 		}
@@ -1141,18 +1467,18 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 		}
 
 		// Use a separate context for the create operation
-		createCtx, createCancel := context.WithTimeout(context.Background(), 60*time.Second)
+		createCtx, createCancel := context.WithTimeout(commandContext(cmd), 60*time.Second)
 		defer createCancel()
 
 		createResp, err := apiClient.CreateResourceGroup(createCtx, createReq)
 		if err != nil {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(progressOut(), " Failed.\n")
 			return fmt.Errorf("failed to create resource group: %w", err)
 		}
 
 		// Check response
 		if createResp.Body == nil {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(progressOut(), " Failed.\n")
 			return fmt.Errorf("invalid response from server")
 		}
 
@@ -1163,7 +1489,7 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 
 		success := createResp.Body.GetSuccess()
 		if success == nil || !*success {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(progressOut(), " Failed.\n")
 			code := createResp.Body.GetCode()
 			message := createResp.Body.GetMessage()
 			if code != nil && message != nil {
@@ -1178,28 +1504,31 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to create resource group")
 		}
 
-		fmt.Printf(" Done.\n")
+		fmt.Fprintf(progressOut(), " Done.\n")
 	}
 
 	// Poll for activation completion
-	fmt.Printf("Waiting for activation to complete...\n")
-	pollingCtx := context.Background() // Don't use timeout context, polling has its own timeout
+	fmt.Fprintf(progressOut(), "Waiting for activation to complete...\n")
+	pollingCtx := commandContext(cmd) // Don't use timeout context, polling has its own timeout
 	config := DefaultActivatePollingConfig()
 
 	if err := PollForActivation(pollingCtx, apiClient, imageId, config); err != nil {
 		return fmt.Errorf("activation failed: %w", err)
 	}
 
-	fmt.Printf("[SUCCESS] Image activated successfully!\n")
-	fmt.Printf("[INFO] Image ID: %s\n", imageId)
+	fmt.Fprintf(progressOut(), "[SUCCESS] Image activated successfully!\n")
+	fmt.Fprintf(progressOut(), "[INFO] Image ID: %s\n", imageId)
 
-	return nil
+	result := newImageStateOutput(imageId, imageInfo, string(StatusResourcePublished), true)
+	result.CPU = cpu
+	result.Memory = memory
+	return renderOutput(result, nil)
 }
 
 func runImageDeactivate(cmd *cobra.Command, args []string) error {
 	imageId := args[0]
 
-	fmt.Printf("[DEACTIVATE] Deactivating image '%s'...\n", imageId)
+	fmt.Fprintf(progressOut(), "[DEACTIVATE] Deactivating image '%s'...\n", imageId)
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -1217,26 +1546,26 @@ func runImageDeactivate(cmd *cobra.Command, args []string) error {
 	apiClient := agentbay.NewClientFromConfig(cfg)
 
 	// Use longer timeout for status check (not for the full polling)
-	statusCtx, statusCancel := context.WithTimeout(context.Background(), 60*time.Second)
+	statusCtx, statusCancel := context.WithTimeout(commandContext(cmd), 60*time.Second)
 	defer statusCancel()
 
 	// Check current image status and type using GetMcpImageInfo
-	fmt.Printf("Checking current image status...")
+	fmt.Fprintf(progressOut(), "Checking current image status...")
 	imageInfo, err := GetImageInfo(statusCtx, apiClient, imageId)
 	if err != nil {
-		fmt.Printf(" Failed.\n")
+		fmt.Fprintf(progressOut(), " Failed.\n")
 		return fmt.Errorf("failed to get image info: %w", err)
 	}
-	fmt.Printf(" Done.\n")
-	fmt.Printf("[INFO] Image Type: %s\n", imageInfo.ImageType)
-	fmt.Printf("[INFO] Current Status: %s\n", TranslateImageResourceStatus(imageInfo.ResourceStatus))
+	fmt.Fprintf(progressOut(), " Done.\n")
+	fmt.Fprintf(progressOut(), "[INFO] Image Type: %s\n", imageInfo.ImageType)
+	fmt.Fprintf(progressOut(), "[INFO] Current Status: %s\n", TranslateImageResourceStatus(imageInfo.ResourceStatus))
 
 	// Check if this is a System image
 	if IsSystemImage(imageInfo.ImageType) {
-		fmt.Printf("[INFO] This is a System image.\n")
-		fmt.Printf("[INFO] System images cannot be deactivated as they are always available.\n")
-		fmt.Printf("[INFO] Image ID: %s\n", imageId)
-		return nil
+		fmt.Fprintf(progressOut(), "[INFO] This is a System image.\n")
+		fmt.Fprintf(progressOut(), "[INFO] System images cannot be deactivated as they are always available.\n")
+		fmt.Fprintf(progressOut(), "[INFO] Image ID: %s\n", imageId)
+		return renderOutput(newImageStateOutput(imageId, imageInfo, imageInfo.ResourceStatus, false), nil)
 	}
 
 	// Check if this is a User image
@@ -1246,25 +1575,25 @@ func runImageDeactivate(cmd *cobra.Command, args []string) error {
 
 	// Check if image is already deactivated
 	if IsDeactivated(imageInfo.ResourceStatus) {
-		fmt.Printf("[OK] Image is already deactivated! No action needed.\n")
-		fmt.Printf("[INFO] Image ID: %s\n", imageId)
-		return nil
+		fmt.Fprintf(progressOut(), "[OK] Image is already deactivated! No action needed.\n")
+		fmt.Fprintf(progressOut(), "[INFO] Image ID: %s\n", imageId)
+		return renderOutput(newImageStateOutput(imageId, imageInfo, imageInfo.ResourceStatus, false), nil)
 	}
 
 	// Check if image is currently deactivating
 	shouldDeleteResourceGroup := true
 	if IsDeactivating(imageInfo.ResourceStatus) {
-		fmt.Printf("[INFO] Image is currently deactivating, waiting for completion...\n")
+		fmt.Fprintf(progressOut(), "[INFO] Image is currently deactivating, waiting for completion...\n")
 		shouldDeleteResourceGroup = false
 	} else if IsActivated(imageInfo.ResourceStatus) {
 		// Image is activated, proceed with deactivation
 		shouldDeleteResourceGroup = true
 	} else if IsFailed(imageInfo.ResourceStatus) {
 		// Activation failed - cannot delete without ResourceGroupId
-		fmt.Printf("[INFO] Image is in Activation Failed state.\n")
-		fmt.Printf("[INFO] The image may recover automatically to Available state. Please try again later.\n")
-		fmt.Printf("[INFO] Alternatively, use the web console to deactivate this image.\n")
-		return nil
+		fmt.Fprintf(progressOut(), "[INFO] Image is in Activation Failed state.\n")
+		fmt.Fprintf(progressOut(), "[INFO] The image may recover automatically to Available state. Please try again later.\n")
+		fmt.Fprintf(progressOut(), "[INFO] Alternatively, use the web console to deactivate this image.\n")
+		return renderOutput(newImageStateOutput(imageId, imageInfo, imageInfo.ResourceStatus, false), nil)
 	} else {
 		// Image is in an unexpected state
 		return fmt.Errorf("cannot deactivate image in current state: %s", TranslateImageResourceStatus(imageInfo.ResourceStatus))
@@ -1272,23 +1601,23 @@ func runImageDeactivate(cmd *cobra.Command, args []string) error {
 
 	// Delete resource group if needed
 	if shouldDeleteResourceGroup {
-		fmt.Printf("Fetching resource group info...")
+		fmt.Fprintf(progressOut(), "Fetching resource group info...")
 		resourceGroupId, err := GetResourceGroupIdForImage(statusCtx, apiClient, imageId)
 		if err != nil {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(progressOut(), " Failed.\n")
 			log.Debugf("[DEBUG] GetResourceGroupIdForImage failed: %v", err)
 			return fmt.Errorf("failed to get resource group info: %w", err)
 		}
-		fmt.Printf(" Done.\n")
+		fmt.Fprintf(progressOut(), " Done.\n")
 
 		if resourceGroupId == "" {
-			fmt.Printf("[WARN] Could not find ResourceGroupId for this image.\n")
-			fmt.Printf("[INFO] The image may recover automatically to Available state. Please try again later.\n")
-			fmt.Printf("[INFO] Alternatively, use the web console to deactivate this image.\n")
-			return nil
+			fmt.Fprintf(progressOut(), "[WARN] Could not find ResourceGroupId for this image.\n")
+			fmt.Fprintf(progressOut(), "[INFO] The image may recover automatically to Available state. Please try again later.\n")
+			fmt.Fprintf(progressOut(), "[INFO] Alternatively, use the web console to deactivate this image.\n")
+			return renderOutput(newImageStateOutput(imageId, imageInfo, imageInfo.ResourceStatus, false), nil)
 		}
 
-		fmt.Printf("Deleting resource group...")
+		fmt.Fprintf(progressOut(), "Deleting resource group...")
 		deleteReq := &client.DeleteResourceGroupRequest{}
 		deleteReq.SetImageId(imageId)
 		deleteReq.SetResourceGroupId(resourceGroupId)
@@ -1301,19 +1630,19 @@ func runImageDeactivate(cmd *cobra.Command, args []string) error {
 		}
 
 		// Use a separate context for the delete operation
-		deleteCtx, deleteCancel := context.WithTimeout(context.Background(), 60*time.Second)
+		deleteCtx, deleteCancel := context.WithTimeout(commandContext(cmd), 60*time.Second)
 		defer deleteCancel()
 
 		deleteResp, err := apiClient.DeleteResourceGroup(deleteCtx, deleteReq)
 		if err != nil {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(progressOut(), " Failed.\n")
 			log.Debugf("[DEBUG] DeleteResourceGroup API call failed: %v", err)
 			return fmt.Errorf("failed to delete resource group: %w", err)
 		}
 
 		// Check response
 		if deleteResp.Body == nil {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(progressOut(), " Failed.\n")
 			return fmt.Errorf("invalid response from server")
 		}
 
@@ -1324,7 +1653,7 @@ func runImageDeactivate(cmd *cobra.Command, args []string) error {
 
 		success := deleteResp.Body.GetSuccess()
 		if success == nil || !*success {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(progressOut(), " Failed.\n")
 			code := deleteResp.Body.GetCode()
 			message := deleteResp.Body.GetMessage()
 			if code != nil && message != nil {
@@ -1339,26 +1668,38 @@ func runImageDeactivate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to delete resource group")
 		}
 
-		fmt.Printf(" Done.\n")
+		fmt.Fprintf(progressOut(), " Done.\n")
 	}
 
 	// Poll for deactivation completion
-	fmt.Printf("Waiting for deactivation to complete...\n")
-	pollingCtx := context.Background() // Don't use timeout context, polling has its own timeout
+	fmt.Fprintf(progressOut(), "Waiting for deactivation to complete...\n")
+	pollingCtx := commandContext(cmd) // Don't use timeout context, polling has its own timeout
 	config := DefaultDeactivatePollingConfig()
 
 	if err := PollForDeactivation(pollingCtx, apiClient, imageId, config); err != nil {
 		return fmt.Errorf("deactivation failed: %w", err)
 	}
 
-	fmt.Printf("[SUCCESS] Image deactivated successfully!\n")
-	fmt.Printf("[INFO] Image ID: %s\n", imageId)
+	fmt.Fprintf(progressOut(), "[SUCCESS] Image deactivated successfully!\n")
+	fmt.Fprintf(progressOut(), "[INFO] Image ID: %s\n", imageId)
 
-	return nil
+	return renderOutput(newImageStateOutput(imageId, imageInfo, string(StatusImageAvailable), true), nil)
 }
 
 func runImageInit(cmd *cobra.Command, args []string) error {
-	fmt.Printf("[INIT] Downloading Dockerfile template...\n")
+	outputPath, _ := cmd.Flags().GetString("dockerfile")
+	force, _ := cmd.Flags().GetBool("force")
+	toStdout, _ := cmd.Flags().GetBool("stdout")
+	if toStdout && isStructuredOutput() {
+		return fmt.Errorf("--stdout cannot be combined with --output %s", GetOutputFormat())
+	}
+	// With --stdout, stdout carries only the template
+	out := progressOut()
+	if toStdout {
+		out = os.Stderr
+	}
+
+	fmt.Fprintf(out, "[INIT] Downloading Dockerfile template...\n")
 
 	// Source is always AgentBay
 	source := "AgentBay"
@@ -1375,6 +1716,25 @@ func runImageInit(cmd *cobra.Command, args []string) error {
 		)
 	}
 
+	// Refuse to overwrite before downloading anything
+	var dockerfilePath string
+	if !toStdout {
+		var exists bool
+		var err error
+		dockerfilePath, exists, err = resolveInitDockerfilePath(outputPath)
+		if err != nil {
+			return err
+		}
+		if exists && !force {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Dockerfile already exists at %s", dockerfilePath),
+				"",
+				"[TIP] Use --force to overwrite it, or --dockerfile <path> to save the template elsewhere.",
+				fmt.Sprintf("[NOTE] Example: agentbay image init -i %s --dockerfile ./template.Dockerfile", sourceImageId),
+			)
+		}
+	}
+
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -1389,7 +1749,7 @@ func runImageInit(cmd *cobra.Command, args []string) error {
 
 	// Create API client
 	apiClient := agentbay.NewClientFromConfig(cfg)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
 	defer cancel()
 
 	// Prepare request - Source and SourceImageId are required
@@ -1410,7 +1770,7 @@ func runImageInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Make API call to get Dockerfile template
-	fmt.Printf("Requesting Dockerfile template...")
+	fmt.Fprintf(out, "Requesting Dockerfile template...")
 	resp, err := apiClient.GetDockerfileTemplate(ctx, req)
 	if err != nil {
 		log.Debugf("[DEBUG] GetDockerfileTemplate API call failed: %v", err)
@@ -1418,7 +1778,7 @@ func runImageInit(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "\n[ERROR] Failed to get Dockerfile template: %v\n", err)
 		return fmt.Errorf("failed to get Dockerfile template: %w", err)
 	}
-	fmt.Printf(" Done.\n")
+	fmt.Fprintf(out, " Done.\n")
 
 	// Validate response
 	if resp.Body == nil || resp.Body.Data == nil {
@@ -1442,14 +1802,14 @@ func runImageInit(cmd *cobra.Command, args []string) error {
 		log.Debugf("[DEBUG] OSS Download URL: %s", *ossUrl)
 
 		// Download Dockerfile from OSS URL
-		fmt.Printf("Downloading Dockerfile from OSS...")
+		fmt.Fprintf(out, "Downloading Dockerfile from OSS...")
 		var err error
-		dockerfileContent, err = downloadDockerfileFromOSS(*ossUrl)
+		dockerfileContent, err = downloadDockerfileFromOSS(ctx, *ossUrl)
 		if err != nil {
-			fmt.Printf(" Failed.\n")
+			fmt.Fprintf(out, " Failed.\n")
 			return fmt.Errorf("failed to download Dockerfile from OSS: %w", err)
 		}
-		fmt.Printf(" Done.\n")
+		fmt.Fprintf(out, " Done.\n")
 	}
 
 	// Get NonEditLineNum if available
@@ -1460,46 +1820,94 @@ func runImageInit(cmd *cobra.Command, args []string) error {
 		log.Debugf("[DEBUG] NonEditLineNum is nil or not present in response")
 	}
 
-	// Get current working directory
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current working directory: %w", err)
+	if toStdout {
+		if _, err := os.Stdout.Write(dockerfileContent); err != nil {
+			return fmt.Errorf("failed to write Dockerfile: %w", err)
+		}
+		if nonEditLineNum != nil && *nonEditLineNum > 0 {
+			fmt.Fprintf(out, "[IMPORTANT] The first %d line(s) of the Dockerfile are system-defined and cannot be modified.\n", *nonEditLineNum)
+			fmt.Fprintf(out, "[NOTE] No lockfile was written, so 'image create' cannot check these lines.\n")
+		}
+		return nil
 	}
 
-	// Write Dockerfile to current directory
-	dockerfilePath := filepath.Join(cwd, "Dockerfile")
-	fmt.Printf("Writing Dockerfile to %s...", dockerfilePath)
-
-	// Check if Dockerfile already exists
-	if _, err := os.Stat(dockerfilePath); err == nil {
-		fmt.Printf("\n[WARN] Dockerfile already exists at %s\n", dockerfilePath)
-		fmt.Printf("[INFO] The existing file will be overwritten.\n")
+	fmt.Fprintf(out, "Writing Dockerfile to %s...", dockerfilePath)
+	if err := os.MkdirAll(filepath.Dir(dockerfilePath), 0755); err != nil {
+		fmt.Fprintf(out, " Failed.\n")
+		return fmt.Errorf("failed to create directory for Dockerfile: %w", err)
 	}
-
-	// Write the content to file
 	err = os.WriteFile(dockerfilePath, dockerfileContent, 0644)
 	if err != nil {
-		fmt.Printf(" Failed.\n")
+		fmt.Fprintf(out, " Failed.\n")
 		return fmt.Errorf("failed to write Dockerfile: %w", err)
 	}
-	fmt.Printf(" Done.\n")
+	fmt.Fprintf(out, " Done.\n")
+
+	// Record the system-defined header so 'image create' can check it before uploading
+	lockPath := DockerfileLockPath(dockerfilePath)
+	lockLines := 0
+	if nonEditLineNum != nil {
+		lockLines = int(*nonEditLineNum)
+	}
+	lock := NewDockerfileLock(sourceImageId, dockerfileContent, lockLines)
+	if err := WriteDockerfileLock(lockPath, lock); err != nil {
+		return err
+	}
+	log.Debugf("[DEBUG] Dockerfile lockfile written to %s", lockPath)
 
-	fmt.Printf("[SUCCESS] ✅ Dockerfile template downloaded successfully!\n")
-	fmt.Printf("[INFO] Dockerfile saved to: %s\n", dockerfilePath)
+	fmt.Fprintf(out, "[SUCCESS] ✅ Dockerfile template downloaded successfully!\n")
+	fmt.Fprintf(out, "[INFO] Dockerfile saved to: %s\n", dockerfilePath)
+	fmt.Fprintf(out, "[INFO] Template lockfile saved to: %s (keep it next to the Dockerfile)\n", lockPath)
 
 	// Display non-editable lines information if available
 	if nonEditLineNum != nil && *nonEditLineNum > 0 {
-		fmt.Printf("[IMPORTANT] The first %d line(s) of the Dockerfile are system-defined and cannot be modified.\n", *nonEditLineNum)
-		fmt.Printf("[IMPORTANT] Please only modify content after line %d.\n", *nonEditLineNum)
+		fmt.Fprintf(out, "[IMPORTANT] The first %d line(s) of the Dockerfile are system-defined and cannot be modified.\n", *nonEditLineNum)
+		fmt.Fprintf(out, "[IMPORTANT] Please only modify content after line %d.\n", *nonEditLineNum)
 	}
 
-	return nil
+	result := imageInitOutput{
+		SourceImageID:  sourceImageId,
+		DockerfilePath: dockerfilePath,
+		LockfilePath:   lockPath,
+	}
+	if nonEditLineNum != nil {
+		result.NonEditLineNum = *nonEditLineNum
+	}
+	return renderOutput(result, nil)
+}
+
+// resolveInitDockerfilePath returns the absolute path 'image init' saves the template to
+// and whether a file already exists there. An empty path means ./Dockerfile, and an
+// existing directory means Dockerfile inside it.
+func resolveInitDockerfilePath(path string) (string, bool, error) {
+	if path == "" {
+		path = "Dockerfile"
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to resolve Dockerfile path: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err == nil && info.IsDir() {
+		abs = filepath.Join(abs, "Dockerfile")
+		info, err = os.Stat(abs)
+	}
+	if err == nil {
+		if info.IsDir() {
+			return "", false, fmt.Errorf("cannot write Dockerfile, %s is a directory", abs)
+		}
+		return abs, true, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", false, err
+	}
+	return abs, false, nil
 }
 
 // downloadDockerfileFromOSS downloads Dockerfile content from OSS URL
-func downloadDockerfileFromOSS(ossUrl string) ([]byte, error) {
+func downloadDockerfileFromOSS(ctx context.Context, ossUrl string) ([]byte, error) {
 	log.Debugf("[DEBUG] Downloading from OSS URL: %s", ossUrl)
-	req, err := http.NewRequest(http.MethodGet, ossUrl, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ossUrl, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create download request: %w", err)
 	}
@@ -1522,6 +1930,58 @@ func downloadDockerfileFromOSS(ossUrl string) ([]byte, error) {
 	return content, nil
 }
 
+// checkDockerfileLock compares a Dockerfile against the lockfile written by 'image init'
+// and prints a line-level diff when the system-defined header was modified
+func checkDockerfileLock(dockerfilePath string, content []byte, sourceImageId string) error {
+	lock, lockErr, err := dockerfileLockMismatch(dockerfilePath, content, sourceImageId)
+	if err != nil || lockErr == nil {
+		return err
+	}
+	lockPath := lockErr.LockPath
+	if lockErr.SourceImageID != "" {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] ❌ The Dockerfile template was created for source image '%s', not '%s'", lockErr.SourceImageID, sourceImageId),
+			"",
+			fmt.Sprintf("[TIP] Use --imageId %s, or run 'agentbay image init -i %s' to download the matching template.", lockErr.SourceImageID, sourceImageId),
+			fmt.Sprintf("[NOTE] Lockfile: %s", lockPath),
+		)
+	}
+	lines := []string{
+		fmt.Sprintf("[ERROR] ❌ %d system-defined line(s) of the Dockerfile were modified:", len(lockErr.Diffs)),
+		"",
+	}
+	lines = append(lines, lockErr.DiffLines()...)
+	lines = append(lines,
+		"",
+		fmt.Sprintf("[TIP] The first %d line(s) of the Dockerfile are system-defined and cannot be modified.", lock.NonEditLineNum),
+		"[TIP] Restore them, or use 'agentbay image init' to download a valid template.",
+		fmt.Sprintf("[NOTE] Lockfile: %s", lockPath),
+	)
+	return printErrorMessage(lines...)
+}
+
+// dockerfileLockMismatch checks content against the lockfile of dockerfilePath. It
+// returns the lockfile and a non-nil *DockerfileLockError if they do not match.
+func dockerfileLockMismatch(dockerfilePath string, content []byte, sourceImageId string) (*DockerfileLock, *DockerfileLockError, error) {
+	lockPath := DockerfileLockPath(dockerfilePath)
+	lock, err := ReadDockerfileLock(lockPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lock == nil {
+		log.Debugf("[DEBUG] No Dockerfile lockfile at %s, skipping header check", lockPath)
+		return nil, nil, nil
+	}
+
+	err = lock.Check(content, sourceImageId)
+	var lockErr *DockerfileLockError
+	if !errors.As(err, &lockErr) {
+		return lock, nil, err
+	}
+	lockErr.LockPath = lockPath
+	return lock, lockErr, nil
+}
+
 // isDockerfileValidationError checks if the error message indicates a Dockerfile validation failure
 func isDockerfileValidationError(taskMsg string) bool {
 	// Check for the specific Dockerfile validation error message
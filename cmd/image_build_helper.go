@@ -0,0 +1,148 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+const (
+	// DefaultBuildTimeout bounds how long 'image create' and 'image wait' wait for a build
+	DefaultBuildTimeout = 45 * time.Minute
+	// buildPollInterval is the delay between GetDockerImageTask polls
+	buildPollInterval = 10 * time.Second
+)
+
+// isBuildSucceeded reports whether a GetDockerImageTask status means the image was built
+func isBuildSucceeded(status string) bool {
+	return status == "SUCCESS" || status == "Finished"
+}
+
+// isBuildFailed reports whether a GetDockerImageTask status means the build failed
+func isBuildFailed(status string) bool {
+	return status == "FAILED" || status == "Failed"
+}
+
+// isBuildInProgress reports whether a GetDockerImageTask status means the build is still running
+func isBuildInProgress(status string) bool {
+	return status == "RUNNING" || status == "PENDING" || status == "Preparing"
+}
+
+// getBuildTaskStatus queries a build task once
+func getBuildTaskStatus(ctx context.Context, apiClient agentbay.Client, taskId string) (*buildStatusOutput, error) {
+	sourceAgentBay := "AgentBay"
+	taskReq := &client.GetDockerImageTaskRequest{
+		Source: &sourceAgentBay,
+		TaskId: &taskId,
+	}
+	log.Debugf("[DEBUG] GetDockerImageTask Request: Source=%s TaskId=%s", sourceAgentBay, taskId)
+
+	taskResp, err := apiClient.GetDockerImageTask(ctx, taskReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check task status: %w", err)
+	}
+	if taskResp.Body == nil || taskResp.Body.Data == nil {
+		// Print Request ID for debugging if available
+		if taskResp.Body != nil && taskResp.Body.GetRequestId() != nil {
+			fmt.Fprintf(progressOut(), "[DEBUG] Request ID: %s\n", *taskResp.Body.GetRequestId())
+		}
+		return nil, fmt.Errorf("invalid response format")
+	}
+
+	data := taskResp.Body.Data
+	out := &buildStatusOutput{
+		TaskID:    taskId,
+		Status:    getStringValue(data.GetStatus()),
+		ImageID:   getStringValue(data.GetImageId()),
+		Message:   getStringValue(data.GetTaskMsg()),
+		requestID: getStringValue(taskResp.Body.GetRequestId()),
+	}
+	log.Debugf("[DEBUG] GetDockerImageTask Response: Status=%s ImageId=%s Message=%s", out.Status, out.ImageID, out.Message)
+
+	if out.Status == "" {
+		if out.requestID != "" {
+			fmt.Fprintf(progressOut(), "[DEBUG] Request ID: %s\n", out.requestID)
+		}
+		return nil, fmt.Errorf("missing status in response")
+	}
+	return out, nil
+}
+
+// pollBuildTask polls a build task until it succeeds or fails, showing its status and
+// the time elapsed. Query errors are reported and polling continues. When ctx expires
+// the returned error is an ExitError with ExitCodeTimeout; an interrupt returns the
+// context error.
+func pollBuildTask(ctx context.Context, apiClient agentbay.Client, taskId string, interval time.Duration) (*buildStatusOutput, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	progress := newStatusProgress(progressOut(), "[STATUS] Build status: ")
+	defer progress.stop()
+
+	var lastMessage string
+	for {
+		st, err := getBuildTaskStatus(ctx, apiClient, taskId)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Debugf("[DEBUG] GetDockerImageTask Polling Error: %v", err)
+			progress.printf("[WARN] Warning: %v\n", err)
+		case err == nil:
+			progress.update(st.Status, "")
+			if st.Message != "" && st.Message != lastMessage {
+				progress.printf("[MESSAGE] %s\n", st.Message)
+			}
+			lastMessage = st.Message
+			if isBuildSucceeded(st.Status) || isBuildFailed(st.Status) {
+				return st, nil
+			}
+			if !isBuildInProgress(st.Status) {
+				progress.printf("[WARN] Warning: Unknown status: %s\n", st.Status)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if isCancelled(ctx, ctx.Err()) {
+				return nil, fmt.Errorf("build status polling cancelled (Task ID: %s): %w", taskId, ctx.Err())
+			}
+			return nil, &ExitError{Code: ExitCodeTimeout, Err: fmt.Errorf("build timeout (Task ID: %s): %w", taskId, ctx.Err())}
+		case <-ticker.C:
+		}
+	}
+}
+
+// reportBuildFailure prints why a build failed and returns an ExitError with
+// ExitCodeBuildFailed
+func reportBuildFailure(st *buildStatusOutput) error {
+	if isDockerfileValidationError(st.Message) {
+		fmt.Fprintf(progressOut(), "[ERROR] ❌ Dockerfile validation failed\n")
+		if st.Message != "" {
+			fmt.Fprintf(progressOut(), "[ERROR] Validation error: %s\n", st.Message)
+		}
+		fmt.Fprintf(progressOut(), "[TIP] Please check your Dockerfile and ensure you haven't modified system-defined lines.\n")
+		fmt.Fprintf(progressOut(), "[TIP] Use 'agentbay image init' to download a valid template.\n")
+		if st.requestID != "" {
+			fmt.Fprintf(progressOut(), "[DEBUG] Request ID: %s\n", st.requestID)
+		}
+		fmt.Fprintf(progressOut(), "[DOC] Task ID: %s\n", st.TaskID)
+		return &ExitError{Code: ExitCodeBuildFailed, Err: errors.New("dockerfile validation failed")}
+	}
+
+	fmt.Fprintf(progressOut(), "[ERROR] ❌ Image build failed\n")
+	if st.Message != "" {
+		fmt.Fprintf(progressOut(), "[ERROR] Error details: %s\n", st.Message)
+	}
+	if st.requestID != "" {
+		fmt.Fprintf(progressOut(), "[DEBUG] Request ID: %s\n", st.requestID)
+	}
+	fmt.Fprintf(progressOut(), "[DOC] Task ID: %s\n", st.TaskID)
+	return &ExitError{Code: ExitCodeBuildFailed, Err: errors.New("image build failed")}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// mockBuildTaskClient returns the configured statuses from GetDockerImageTask in order,
+// repeating the last one once they are exhausted
+type mockBuildTaskClient struct {
+	mockImageListClient
+	statuses []string
+	taskErr  error
+	calls    int
+}
+
+func (m *mockBuildTaskClient) GetDockerImageTask(ctx context.Context, request *client.GetDockerImageTaskRequest) (*client.GetDockerImageTaskResponse, error) {
+	m.calls++
+	if m.taskErr != nil {
+		return nil, m.taskErr
+	}
+	i := m.calls - 1
+	if i >= len(m.statuses) {
+		i = len(m.statuses) - 1
+	}
+	return &client.GetDockerImageTaskResponse{
+		Body: &client.GetDockerImageTaskResponseBody{
+			RequestId: dara.String("req-1"),
+			Data: &client.GetDockerImageTaskResponseBodyData{
+				Status:  dara.String(m.statuses[i]),
+				ImageId: dara.String("imgc-built"),
+				TaskMsg: dara.String("msg"),
+			},
+		},
+	}, nil
+}
+
+func TestGetBuildTaskStatus(t *testing.T) {
+	mockClient := &mockBuildTaskClient{statuses: []string{"RUNNING"}}
+
+	st, err := getBuildTaskStatus(context.Background(), mockClient, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", st.TaskID)
+	assert.Equal(t, "RUNNING", st.Status)
+	assert.Equal(t, "imgc-built", st.ImageID)
+	assert.Equal(t, "req-1", st.requestID)
+
+	mockClient.taskErr = fmt.Errorf("boom")
+	_, err = getBuildTaskStatus(context.Background(), mockClient, "task-1")
+	require.Error(t, err)
+}
+
+func TestPollBuildTask(t *testing.T) {
+	mockClient := &mockBuildTaskClient{statuses: []string{"PENDING", "RUNNING", "SUCCESS"}}
+
+	st, err := pollBuildTask(context.Background(), mockClient, "task-1", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCESS", st.Status)
+	assert.Equal(t, 3, mockClient.calls)
+}
+
+func TestPollBuildTaskTimeout(t *testing.T) {
+	mockClient := &mockBuildTaskClient{statuses: []string{"RUNNING"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := pollBuildTask(ctx, mockClient, "task-1", time.Millisecond)
+	require.Error(t, err)
+	assert.Equal(t, ExitCodeTimeout, ExitCode(err))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestReportBuildFailure(t *testing.T) {
+	err := reportBuildFailure(&buildStatusOutput{TaskID: "task-1", Status: "FAILED", Message: "compile error"})
+	require.Error(t, err)
+	assert.Equal(t, ExitCodeBuildFailed, ExitCode(err))
+	assert.Equal(t, "image build failed", err.Error())
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitCodeSuccess, ExitCode(nil))
+	assert.Equal(t, ExitCodeFailure, ExitCode(fmt.Errorf("plain")))
+	wrapped := fmt.Errorf("outer: %w", &ExitError{Code: ExitCodeTimeout, Err: fmt.Errorf("inner")})
+	assert.Equal(t, ExitCodeTimeout, ExitCode(wrapped))
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// newImageCreatePlan lists what 'agentbay image create' would upload: the Dockerfile,
+// then the ADD/COPY files by upload path
+func newImageCreatePlan(imageName, sourceImageId, dockerfilePath string, dockerfileSize int64, contextDir string, files []uploadFile, excluded []string) imageCreatePlanOutput {
+	plan := imageCreatePlanOutput{
+		ImageName:     imageName,
+		SourceImageID: sourceImageId,
+		Dockerfile:    dockerfilePath,
+		ContextDir:    contextDir,
+		Files:         []uploadPlanFile{{Path: dockerfileUploadName, Size: dockerfileSize}},
+		TotalBytes:    dockerfileSize,
+	}
+	for _, f := range files {
+		plan.Files = append(plan.Files, uploadPlanFile{Path: f.relPath, Size: f.size})
+		plan.TotalBytes += f.size
+	}
+	plan.TotalFiles = len(plan.Files)
+	for _, absPath := range excluded {
+		rel, err := filepath.Rel(contextDir, absPath)
+		if err != nil {
+			rel = absPath
+		}
+		plan.ExcludedFiles = append(plan.ExcludedFiles, filepath.ToSlash(rel))
+	}
+	if len(excluded) > 0 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%d files excluded by %s", len(excluded), DockerIgnoreFile))
+	}
+	return plan
+}
+
+// runImageCreateDryRun prints the plan of 'agentbay image create --dry-run'. A Dockerfile
+// that does not match its lockfile is reported as a warning and fails the command, as
+// the real run would refuse to upload it.
+func runImageCreateDryRun(imageName, sourceImageId, dockerfilePath string, dockerfileContent, uploadContent []byte, contextDir string, files []uploadFile, excluded []string) error {
+	plan := newImageCreatePlan(imageName, sourceImageId, dockerfilePath, int64(len(uploadContent)), contextDir, files, excluded)
+	_, lockErr, err := dockerfileLockMismatch(dockerfilePath, dockerfileContent, sourceImageId)
+	if err != nil {
+		return err
+	}
+	if lockErr != nil {
+		plan.Warnings = append([]string{lockErr.Error()}, plan.Warnings...)
+	}
+
+	if err := renderOutput(plan, func() { printImageCreatePlan(plan, lockErr) }); err != nil {
+		return err
+	}
+	if lockErr != nil {
+		return fmt.Errorf("image create would be rejected: %w", lockErr)
+	}
+	return nil
+}
+
+// printImageCreatePlan prints the dry-run plan as a table followed by its warnings
+func printImageCreatePlan(plan imageCreatePlanOutput, lockErr *DockerfileLockError) {
+	fmt.Printf("%-*s %s\n", imageDetailLabelW, "Source Image:", plan.SourceImageID)
+	fmt.Printf("%-*s %s\n", imageDetailLabelW, "Dockerfile:", plan.Dockerfile)
+	fmt.Printf("%-*s %s\n", imageDetailLabelW, "Context:", plan.ContextDir)
+	fmt.Println()
+
+	fmt.Printf("%s %s\n", padString("UPLOAD PATH", 50), "SIZE")
+	fmt.Printf("%s %s\n", padString("-----------", 50), "----")
+	for _, f := range plan.Files {
+		fmt.Printf("%s %s\n", padString(f.Path, 50), formatBytes(f.Size))
+	}
+	fmt.Println()
+	fmt.Printf("Total: %d files, %s\n", plan.TotalFiles, formatBytes(plan.TotalBytes))
+
+	if lockErr != nil {
+		fmt.Printf("[WARN] %s\n", lockErr.Error())
+		for _, line := range lockErr.DiffLines() {
+			fmt.Printf("  %s\n", line)
+		}
+		fmt.Printf("[TIP] 'agentbay image create' will refuse this Dockerfile. Lockfile: %s\n", lockErr.LockPath)
+	}
+	if len(plan.ExcludedFiles) > 0 {
+		fmt.Printf("[WARN] %d files excluded by %s:\n", len(plan.ExcludedFiles), DockerIgnoreFile)
+		for _, path := range plan.ExcludedFiles {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	if lockErr == nil {
+		fmt.Println("[INFO] Dry run complete. Run without --dry-run to upload these files and build the image.")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewImageCreatePlan(t *testing.T) {
+	contextDir := t.TempDir()
+	files := []uploadFile{
+		{absPath: filepath.Join(contextDir, "app", "main.py"), relPath: "app/main.py", size: 300},
+		{absPath: filepath.Join(contextDir, "requirements.txt"), relPath: "requirements.txt", size: 20},
+	}
+	excluded := []string{filepath.Join(contextDir, "node_modules", "x.js")}
+
+	plan := newImageCreatePlan("my-image", "code_latest", filepath.Join(contextDir, "Dockerfile"), 100, contextDir, files, excluded)
+	assert.Equal(t, []uploadPlanFile{
+		{Path: "Dockerfile", Size: 100},
+		{Path: "app/main.py", Size: 300},
+		{Path: "requirements.txt", Size: 20},
+	}, plan.Files)
+	assert.Equal(t, 3, plan.TotalFiles)
+	assert.Equal(t, int64(420), plan.TotalBytes)
+	assert.Equal(t, []string{"node_modules/x.js"}, plan.ExcludedFiles)
+	assert.Equal(t, []string{"1 files excluded by .dockerignore"}, plan.Warnings)
+}
+
+func TestRunImageCreateDryRun_LockMismatch(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer devNull.Close()
+	oldStdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = oldStdout }()
+
+	contextDir := t.TempDir()
+	dockerfilePath := filepath.Join(contextDir, "Dockerfile")
+	template := []byte("FROM registry.example.com/base:1.0\nUSER root\n")
+	require.NoError(t, WriteDockerfileLock(DockerfileLockPath(dockerfilePath), NewDockerfileLock("code_latest", template, 2)))
+
+	assert.NoError(t, runImageCreateDryRun("my-image", "code_latest", dockerfilePath, template, template, contextDir, nil, nil))
+
+	modified := []byte("FROM ubuntu\nUSER root\n")
+	err = runImageCreateDryRun("my-image", "code_latest", dockerfilePath, modified, modified, contextDir, nil, nil)
+	var lockErr *DockerfileLockError
+	require.True(t, errors.As(err, &lockErr))
+	assert.Len(t, lockErr.Diffs, 1)
+}
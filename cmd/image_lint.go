@@ -0,0 +1,332 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/dockerfile"
+)
+
+// Severities of lint diagnostics
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+// lintLargeContextBytes is the build context size above which 'image lint' warns
+const lintLargeContextBytes = 1 << 30
+
+// lintRule is a check of 'agentbay image lint'
+type lintRule struct {
+	ID          string
+	Description string
+}
+
+// lintRules lists every check, in the order they are documented
+var lintRules = []lintRule{
+	{"parse-error", "The Dockerfile cannot be parsed"},
+	{"source-image", "The Dockerfile template was created for a different source image"},
+	{"protected-line", "A system-defined line of an 'image init' template was modified"},
+	{"absolute-path", "COPY/ADD source is an absolute path, which the uploader does not support"},
+	{"context-escape", "COPY/ADD source is outside the build context"},
+	{"missing-source", "COPY/ADD source does not exist in the build context"},
+	{"ignored-source", "COPY/ADD source is excluded by .dockerignore"},
+	{"upload-conflict", "COPY/ADD source would be uploaded in place of the Dockerfile"},
+	{"add-url", "ADD source is fetched by the builder instead of uploaded"},
+	{"unknown-stage", "COPY --from does not refer to an earlier build stage"},
+	{"large-context", "The files to upload exceed the recommended build context size"},
+}
+
+var errorLinePattern = regexp.MustCompile(`^line (\d+): `)
+
+func runImageLint(cmd *cobra.Command, args []string) error {
+	dockerfileArg := "Dockerfile"
+	if len(args) > 0 {
+		dockerfileArg = args[0]
+	}
+	contextFlag, _ := cmd.Flags().GetString("context")
+	sourceImageId, _ := cmd.Flags().GetString("imageId")
+	sarif, _ := cmd.Flags().GetBool("sarif")
+	if sarif && isStructuredOutput() {
+		return fmt.Errorf("--sarif cannot be combined with --output %s", GetOutputFormat())
+	}
+
+	dockerfilePath, err := filepath.Abs(dockerfileArg)
+	if err != nil {
+		return fmt.Errorf("failed to resolve dockerfile path: %w", err)
+	}
+	content, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("dockerfile not found: %s", dockerfilePath)
+		}
+		return fmt.Errorf("failed to read Dockerfile: %w", err)
+	}
+	contextDir, err := ResolveContextDir(contextFlag, dockerfilePath)
+	if err != nil {
+		return err
+	}
+
+	result, err := lintDockerfile(filepath.Clean(dockerfileArg), dockerfilePath, content, contextDir, sourceImageId)
+	if err != nil {
+		return err
+	}
+	if sarif {
+		err = writeLintSARIF(os.Stdout, result)
+	} else {
+		err = renderOutput(result, func() { printLintResult(result) })
+	}
+	if err != nil {
+		return err
+	}
+	if result.Errors > 0 {
+		// Findings are not usage mistakes
+		cmd.SilenceUsage = true
+		return &ExitError{Code: ExitCodeFailure, Err: fmt.Errorf("%s has %d lint error(s)", result.Dockerfile, result.Errors)}
+	}
+	return nil
+}
+
+// dockerfileLinter collects the diagnostics of one Dockerfile
+type dockerfileLinter struct {
+	result     *imageLintOutput
+	path       string // absolute Dockerfile path
+	contextDir string
+	ignore     *DockerIgnore
+	seen       map[string]bool
+	stages     map[string]int // stage index by lower-case name
+	stageIndex int            // index of the stage being walked
+}
+
+// lintDockerfile checks a Dockerfile the way 'image create' would use it. file is the
+// path shown in diagnostics. The returned error is only set when the check itself
+// could not run, e.g. because the lockfile or .dockerignore is unreadable.
+func lintDockerfile(file, dockerfilePath string, content []byte, contextDir, sourceImageId string) (imageLintOutput, error) {
+	result := imageLintOutput{Dockerfile: file, ContextDir: contextDir, Diagnostics: []lintDiagnostic{}}
+	l := &dockerfileLinter{result: &result, path: dockerfilePath, contextDir: contextDir, seen: make(map[string]bool)}
+
+	if err := l.checkLock(content, sourceImageId); err != nil {
+		return result, err
+	}
+	ignore, err := LoadDockerIgnore(contextDir)
+	if err != nil {
+		return result, err
+	}
+	l.ignore = ignore
+
+	df, err := dockerfile.Parse(content)
+	if err != nil {
+		l.addError(0, "parse-error", err)
+	} else {
+		l.checkInstructions(df)
+	}
+	if result.TotalBytes > lintLargeContextBytes {
+		l.add(0, lintWarning, "large-context", "the build context to upload is %s in %d files, more than %s; exclude files the image does not need with %s",
+			formatBytes(result.TotalBytes), result.TotalFiles, formatBytes(lintLargeContextBytes), DockerIgnoreFile)
+	}
+
+	sort.SliceStable(result.Diagnostics, func(i, j int) bool {
+		return result.Diagnostics[i].Line < result.Diagnostics[j].Line
+	})
+	for _, d := range result.Diagnostics {
+		if d.Severity == lintError {
+			result.Errors++
+		} else {
+			result.Warnings++
+		}
+	}
+	return result, nil
+}
+
+func (l *dockerfileLinter) add(line int, severity, rule, format string, args ...interface{}) {
+	l.result.Diagnostics = append(l.result.Diagnostics, lintDiagnostic{
+		File:     l.result.Dockerfile,
+		Line:     line,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// addError adds err as an error, taking its line from a "line N: " prefix if present
+func (l *dockerfileLinter) addError(line int, rule string, err error) {
+	msg := err.Error()
+	if m := errorLinePattern.FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		msg = msg[len(m[0]):]
+	}
+	l.add(line, lintError, rule, "%s", msg)
+}
+
+// checkLock compares the Dockerfile with the lockfile written by 'image init'
+func (l *dockerfileLinter) checkLock(content []byte, sourceImageId string) error {
+	lock, err := ReadDockerfileLock(DockerfileLockPath(l.path))
+	if err != nil || lock == nil {
+		return err
+	}
+	if sourceImageId == "" {
+		sourceImageId = lock.SourceImageID
+	}
+	var lockErr *DockerfileLockError
+	if !errors.As(lock.Check(content, sourceImageId), &lockErr) {
+		return nil
+	}
+	if lockErr.SourceImageID != "" {
+		l.add(1, lintError, "source-image", "the template was created for source image '%s', not '%s'", lockErr.SourceImageID, sourceImageId)
+	}
+	for _, d := range lockErr.Diffs {
+		if d.Missing {
+			l.add(d.Line, lintError, "protected-line", "system-defined line is missing, expected %q", d.Expected)
+		} else {
+			l.add(d.Line, lintError, "protected-line", "system-defined line was modified, expected %q", d.Expected)
+		}
+	}
+	return nil
+}
+
+func (l *dockerfileLinter) checkInstructions(df *dockerfile.Dockerfile) {
+	l.stages = make(map[string]int)
+	index := 0
+	for _, inst := range df.Instructions {
+		if inst.Keyword != "FROM" {
+			continue
+		}
+		if name := stageName(inst); name != "" {
+			if _, ok := l.stages[name]; !ok {
+				l.stages[name] = index
+			}
+		}
+		index++
+	}
+
+	l.stageIndex = -1
+	err := df.Walk(nil, func(inst *dockerfile.Instruction, vars map[string]string) error {
+		switch inst.Keyword {
+		case "FROM":
+			l.stageIndex++
+		case "COPY", "ADD":
+			if from, ok := inst.Flag("from"); ok {
+				l.checkCopyFrom(inst, from, vars)
+				return nil
+			}
+			l.checkSources(inst, vars)
+		}
+		return nil
+	})
+	if err != nil {
+		l.addError(0, "parse-error", err)
+	}
+}
+
+// stageName returns the lower-case name of a FROM ... AS name stage
+func stageName(inst *dockerfile.Instruction) string {
+	if len(inst.Args) >= 3 && strings.EqualFold(inst.Args[1], "AS") {
+		return strings.ToLower(inst.Args[2])
+	}
+	return ""
+}
+
+// checkCopyFrom checks that COPY --from names an earlier stage. Values that look like
+// image references are left alone, as docker build pulls them.
+func (l *dockerfileLinter) checkCopyFrom(inst *dockerfile.Instruction, from string, vars map[string]string) {
+	if expanded, err := dockerfile.ExpandVars(from, vars); err == nil {
+		from = expanded
+	}
+	if n, err := strconv.Atoi(from); err == nil {
+		if n < 0 || n >= l.stageIndex {
+			l.add(inst.StartLine, lintError, "unknown-stage", "--from=%d does not refer to an earlier stage; %d stage(s) are defined before this one", n, l.stageIndex)
+		}
+		return
+	}
+	if index, ok := l.stages[strings.ToLower(from)]; ok {
+		if index < l.stageIndex {
+			return
+		}
+		if index == l.stageIndex {
+			l.add(inst.StartLine, lintError, "unknown-stage", "--from=%s refers to the stage it is used in", from)
+		} else {
+			l.add(inst.StartLine, lintError, "unknown-stage", "--from=%s refers to a stage defined later in the Dockerfile", from)
+		}
+		return
+	}
+	if !strings.ContainsAny(from, "/:@.") {
+		l.add(inst.StartLine, lintWarning, "unknown-stage", "no stage is named %q, so it will be pulled as an image", from)
+	}
+}
+
+// checkSources resolves the sources of a COPY/ADD the way the uploader does
+func (l *dockerfileLinter) checkSources(inst *dockerfile.Instruction, vars map[string]string) {
+	sources, _, err := inst.CopySources(vars)
+	if err != nil {
+		l.add(inst.StartLine, lintError, "parse-error", "%s: %v", inst.Keyword, err)
+		return
+	}
+	for _, src := range sources {
+		if inst.Keyword == "ADD" && (IsURL(src) || strings.HasPrefix(src, "git@")) {
+			l.add(inst.StartLine, lintWarning, "add-url", "%s is not uploaded; the builder downloads it, so it must be reachable from the build service", src)
+			continue
+		}
+		files, excluded, err := expandSource(l.contextDir, src, l.ignore)
+		switch {
+		case errors.Is(err, errAbsoluteSource):
+			l.add(inst.StartLine, lintError, "absolute-path", "%s is an absolute path; use a path relative to the build context", src)
+		case errors.Is(err, errSourceEscapes):
+			l.add(inst.StartLine, lintError, "context-escape", "%s is outside the build context %s", src, l.contextDir)
+		case errors.Is(err, errSourceNotFound):
+			l.add(inst.StartLine, lintError, "missing-source", "%s does not exist in the build context %s", src, l.contextDir)
+		case errors.Is(err, errSourceIgnored):
+			l.add(inst.StartLine, lintError, "ignored-source", "%s is excluded by %s", src, DockerIgnoreFile)
+		case err != nil:
+			l.add(inst.StartLine, lintError, "missing-source", "%s: %v", src, err)
+		case len(files) == 0 && len(excluded) > 0:
+			l.add(inst.StartLine, lintError, "ignored-source", "every file matched by %s is excluded by %s", src, DockerIgnoreFile)
+		case len(files) == 0:
+			l.add(inst.StartLine, lintError, "missing-source", "%s matches no files in the build context %s", src, l.contextDir)
+		}
+		for _, f := range files {
+			l.countFile(inst, f)
+		}
+	}
+}
+
+// countFile adds a file to the upload totals and checks its upload path
+func (l *dockerfileLinter) countFile(inst *dockerfile.Instruction, absPath string) {
+	if l.seen[absPath] {
+		return
+	}
+	l.seen[absPath] = true
+	if info, err := os.Stat(absPath); err == nil {
+		l.result.TotalFiles++
+		l.result.TotalBytes += info.Size()
+	}
+	if rel, err := RelativePathForUpload(l.contextDir, absPath); err == nil && rel == dockerfileUploadName && absPath != l.path {
+		l.add(inst.StartLine, lintError, "upload-conflict", "%s would be uploaded as %s and replace this Dockerfile; rename it or use a different build context", rel, dockerfileUploadName)
+	}
+}
+
+// printLintResult prints diagnostics as file:line: severity: message [rule]
+func printLintResult(result imageLintOutput) {
+	for _, d := range result.Diagnostics {
+		location := d.File
+		if d.Line > 0 {
+			location = fmt.Sprintf("%s:%d", d.File, d.Line)
+		}
+		fmt.Printf("%s: %s: %s [%s]\n", location, d.Severity, d.Message, d.Rule)
+	}
+	if len(result.Diagnostics) == 0 {
+		fmt.Printf("[OK] No problems found in %s (%d ADD/COPY files, %s to upload)\n", result.Dockerfile, result.TotalFiles, formatBytes(result.TotalBytes))
+		return
+	}
+	fmt.Printf("\nFound %d error(s) and %d warning(s)\n", result.Errors, result.Warnings)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lintRulesByLine returns "severity/rule@line" for each diagnostic
+func lintRulesByLine(result imageLintOutput) []string {
+	var out []string
+	for _, d := range result.Diagnostics {
+		out = append(out, fmt.Sprintf("%s/%s@%d", d.Severity, d.Rule, d.Line))
+	}
+	return out
+}
+
+func TestLintDockerfile(t *testing.T) {
+	contextDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(contextDir, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, "src", "main.py"), []byte("print()"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, "secret.env"), []byte("x"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, DockerIgnoreFile), []byte("secret.env\n"), 0644))
+	dockerfilePath := filepath.Join(contextDir, "Dockerfile")
+	content := []byte(`FROM ubuntu AS build
+COPY src/ /app/
+COPY missing.txt /app/
+COPY ../outside /app/
+COPY /etc/passwd /app/
+COPY secret.env /app/
+COPY *.md /docs/
+ADD https://example.com/tool.tgz /opt/
+COPY --from=later /a /b
+FROM alpine AS later
+COPY --from=build /app /app
+COPY --from=0 /app /app
+COPY --from=5 /app /app
+COPY --from=biuld /app /app
+COPY --from=nginx:latest /etc/nginx /etc/nginx
+`)
+
+	result, err := lintDockerfile("Dockerfile", dockerfilePath, content, contextDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"error/missing-source@3",
+		"error/context-escape@4",
+		"error/absolute-path@5",
+		"error/ignored-source@6",
+		"error/missing-source@7",
+		"warning/add-url@8",
+		"error/unknown-stage@9",
+		"error/unknown-stage@13",
+		"warning/unknown-stage@14",
+	}, lintRulesByLine(result))
+	assert.Equal(t, 7, result.Errors)
+	assert.Equal(t, 2, result.Warnings)
+	assert.Equal(t, 1, result.TotalFiles)
+	assert.Equal(t, int64(7), result.TotalBytes)
+}
+
+func TestLintDockerfile_ProtectedLinesAndParseErrors(t *testing.T) {
+	contextDir := t.TempDir()
+	dockerfilePath := filepath.Join(contextDir, "Dockerfile")
+	template := []byte("FROM registry.example.com/base:1.0\nUSER root\n")
+	require.NoError(t, WriteDockerfileLock(DockerfileLockPath(dockerfilePath), NewDockerfileLock("code_latest", template, 2)))
+
+	result, err := lintDockerfile("Dockerfile", dockerfilePath, template, contextDir, "")
+	require.NoError(t, err)
+	assert.Empty(t, result.Diagnostics, "the lockfile's source image is used by default")
+
+	result, err = lintDockerfile("Dockerfile", dockerfilePath, []byte("FROM registry.example.com/base:1.0\nUSER admin\n"), contextDir, "other_image")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"error/source-image@1"}, lintRulesByLine(result))
+
+	result, err = lintDockerfile("Dockerfile", dockerfilePath, []byte("FROM registry.example.com/base:1.0\nUSER admin\n"), contextDir, "code_latest")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"error/protected-line@2"}, lintRulesByLine(result))
+
+	result, err = lintDockerfile("Dockerfile", dockerfilePath, []byte("FROM registry.example.com/base:1.0\nUSER root\nCOPY \"unclosed /app\n"), contextDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"error/parse-error@3"}, lintRulesByLine(result))
+}
+
+func TestWriteLintSARIF(t *testing.T) {
+	result := imageLintOutput{
+		Dockerfile: "images/my app.Dockerfile",
+		Diagnostics: []lintDiagnostic{
+			{File: "images/my app.Dockerfile", Line: 3, Severity: lintError, Rule: "missing-source", Message: "missing.txt does not exist"},
+			{File: "images/my app.Dockerfile", Severity: lintWarning, Rule: "large-context", Message: "too big"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeLintSARIF(&buf, result))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, len(lintRules))
+	require.Len(t, run.Results, 2)
+
+	first := run.Results[0]
+	assert.Equal(t, "missing-source", first.RuleID)
+	assert.Equal(t, "missing-source", run.Tool.Driver.Rules[first.RuleIndex].ID)
+	assert.Equal(t, "error", first.Level)
+	assert.Equal(t, "images/my%20app.Dockerfile", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 3, first.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region, "file-level results have no region")
+}
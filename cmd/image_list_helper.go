@@ -20,7 +20,7 @@ func runImageListWithBothTypes(ctx context.Context, apiClient agentbay.Client, o
 	var totalCount int32
 
 	// First, get user images
-	fmt.Printf("Requesting user images...")
+	fmt.Fprintf(progressOut(), "Requesting user images...")
 	userReq := &client.ListMcpImagesRequest{}
 	userImageType := "User"
 	userReq.ImageType = &userImageType
@@ -39,11 +39,11 @@ func runImageListWithBothTypes(ctx context.Context, apiClient agentbay.Client, o
 
 	userResp, err := apiClient.ListMcpImages(ctx, userReq)
 	if err != nil {
-		fmt.Printf(" Failed.\n")
+		fmt.Fprintf(progressOut(), " Failed.\n")
 		log.Debugf("[DEBUG] Failed to get user images: %v", err)
 		return fmt.Errorf("failed to get user images: %w", err)
 	}
-	fmt.Printf(" Done.")
+	fmt.Fprintf(progressOut(), " Done.")
 
 	// Process user images response
 	if userResp != nil && userResp.Body != nil && userResp.Body.Data != nil {
@@ -54,7 +54,7 @@ func runImageListWithBothTypes(ctx context.Context, apiClient agentbay.Client, o
 	}
 
 	// Then, get system images
-	fmt.Printf(" Requesting system images...")
+	fmt.Fprintf(progressOut(), " Requesting system images...")
 	systemReq := &client.ListMcpImagesRequest{}
 	systemImageType := "System"
 	systemReq.ImageType = &systemImageType
@@ -73,12 +73,12 @@ func runImageListWithBothTypes(ctx context.Context, apiClient agentbay.Client, o
 
 	systemResp, err := apiClient.ListMcpImages(ctx, systemReq)
 	if err != nil {
-		fmt.Printf(" Failed.\n")
+		fmt.Fprintf(progressOut(), " Failed.\n")
 		log.Debugf("[DEBUG] Failed to get system images: %v", err)
 		// Don't fail completely if system images fail, just show user images
-		fmt.Printf("[WARN] Failed to fetch system images, showing user images only\n")
+		fmt.Fprintf(progressOut(), "[WARN] Failed to fetch system images, showing user images only\n")
 	} else {
-		fmt.Printf(" Done.\n")
+		fmt.Fprintf(progressOut(), " Done.\n")
 		// Process system images response
 		if systemResp != nil && systemResp.Body != nil && systemResp.Body.Data != nil {
 			allImages = append(allImages, systemResp.Body.Data...)
@@ -106,26 +106,26 @@ func runImageListWithBothTypes(ctx context.Context, apiClient agentbay.Client, o
 	}
 
 	// Display results
-	if len(allImages) == 0 {
-		fmt.Printf("\n[EMPTY] No images found.\n")
-		return nil
-	}
+	return renderOutput(newImageListOutput(allImages, totalCount, nil), func() {
+		if len(allImages) == 0 {
+			fmt.Printf("\n[EMPTY] No images found.\n")
+			return
+		}
 
-	fmt.Printf("\n[OK] Found %d images (Total: %d)\n", len(allImages), totalCount)
+		fmt.Printf("\n[OK] Found %d images (Total: %d)\n", len(allImages), totalCount)
 
-	// Display user images first
-	if len(userImages) > 0 {
-		fmt.Printf("\n=== USER IMAGES (%d) ===\n", len(userImages))
-		printImageTable(userImages)
-	}
-
-	// Display system images
-	if len(systemImages) > 0 {
-		fmt.Printf("\n=== SYSTEM IMAGES (%d) ===\n", len(systemImages))
-		printImageTable(systemImages)
-	}
+		// Display user images first
+		if len(userImages) > 0 {
+			fmt.Printf("\n=== USER IMAGES (%d) ===\n", len(userImages))
+			printImageTable(userImages)
+		}
 
-	return nil
+		// Display system images
+		if len(systemImages) > 0 {
+			fmt.Printf("\n=== SYSTEM IMAGES (%d) ===\n", len(systemImages))
+			printImageTable(systemImages)
+		}
+	})
 }
 
 // printImageTable prints a formatted table of images
@@ -169,3 +169,39 @@ func printImageTable(images []*client.ListMcpImagesResponseBodyData) {
 			applyScene)
 	}
 }
+
+// newImageListOutput converts ListMcpImages data into the structured image list result.
+// page is optional; when set, its PageStart/PageSize are copied into the result.
+func newImageListOutput(images []*client.ListMcpImagesResponseBodyData, totalCount int32, page *client.ListMcpImagesResponseBody) imageListOutput {
+	out := imageListOutput{
+		Images:     make([]imageOutput, 0, len(images)),
+		TotalCount: totalCount,
+	}
+	if page != nil {
+		if page.PageStart != nil {
+			out.PageStart = *page.PageStart
+		}
+		if page.PageSize != nil {
+			out.PageSize = *page.PageSize
+		}
+	}
+	for _, image := range images {
+		if image == nil {
+			continue
+		}
+		item := imageOutput{
+			ImageID:        getStringValue(image.GetImageId()),
+			ImageName:      getStringValue(image.GetImageName()),
+			ImageType:      getStringValue(image.GetImageBuildType()),
+			ResourceStatus: getStringValue(image.GetImageResourceStatus()),
+			Status:         formatImageStatus(getStringValue(image.GetImageResourceStatus())),
+			ApplyScene:     getStringValue(image.GetImageApplyScene()),
+		}
+		if info := image.GetImageInfo(); info != nil {
+			item.OsName = getStringValue(info.GetOsName())
+			item.OsVersion = getStringValue(info.GetOsVersion())
+		}
+		out.Images = append(out.Images, item)
+	}
+	return out
+}
@@ -26,6 +26,7 @@ type mockImageListClient struct {
 	systemError  error
 	userTotal    int32
 	systemTotal  int32
+	imageInfo    *client.GetMcpImageInfoResponseBodyData
 }
 
 func (m *mockImageListClient) ListMcpImages(ctx context.Context, req *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
@@ -74,10 +75,19 @@ func (m *mockImageListClient) GetDockerImageTask(ctx context.Context, request *c
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (m *mockImageListClient) GetMcpImageInfo(ctx context.Context, request *client.GetMcpImageInfoRequest) (*client.GetMcpImageInfoResponse, error) {
+func (m *mockImageListClient) GetDockerImageTaskLog(ctx context.Context, request *client.GetDockerImageTaskLogRequest) (*client.GetDockerImageTaskLogResponse, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (m *mockImageListClient) GetMcpImageInfo(ctx context.Context, request *client.GetMcpImageInfoRequest) (*client.GetMcpImageInfoResponse, error) {
+	if m.imageInfo == nil {
+		return nil, fmt.Errorf("not implemented")
+	}
+	return &client.GetMcpImageInfoResponse{
+		Body: &client.GetMcpImageInfoResponseBody{Data: m.imageInfo, Success: boolPtr(true)},
+	}, nil
+}
+
 func (m *mockImageListClient) CreateResourceGroup(ctx context.Context, request *client.CreateResourceGroupRequest) (*client.CreateResourceGroupResponse, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -0,0 +1,218 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+const (
+	// buildLogPageSize is the number of log lines requested per GetDockerImageTaskLog call
+	buildLogPageSize = 500
+	// buildLogFollowInterval is the delay between log polls with 'image logs --follow'
+	buildLogFollowInterval = 3 * time.Second
+	// buildLogTailLines is how many log lines are shown when a build fails
+	buildLogTailLines = 20
+)
+
+func runImageLogs(cmd *cobra.Command, args []string) error {
+	taskId := args[0]
+	follow, _ := cmd.Flags().GetBool("follow")
+	tail, _ := cmd.Flags().GetInt("tail")
+	if tail < 0 {
+		return fmt.Errorf("--tail must not be negative")
+	}
+	if follow && isStructuredOutput() {
+		return fmt.Errorf("--follow cannot be combined with --output %s", GetOutputFormat())
+	}
+
+	apiClient, err := newAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	if !follow {
+		ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+		defer cancel()
+		lines, _, err := readBuildLog(ctx, apiClient, taskId, "")
+		if err != nil {
+			return err
+		}
+		result := buildLogOutput{TaskID: taskId, Lines: tailLines(lines, tail)}
+		return renderOutput(result, func() {
+			for _, line := range result.Lines {
+				fmt.Println(line)
+			}
+		})
+	}
+
+	// Following has no timeout; it ends when the build does or on interrupt
+	ctx := commandContext(cmd)
+	lines, token, err := readBuildLog(ctx, apiClient, taskId, "")
+	if err != nil {
+		return err
+	}
+	for _, line := range tailLines(lines, tail) {
+		fmt.Println(line)
+	}
+	st, err := followBuildLog(ctx, apiClient, taskId, token, buildLogFollowInterval, os.Stdout)
+	if err != nil {
+		if isCancelled(ctx, err) {
+			return nil
+		}
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "[INFO] Build finished with status %s\n", st.Status)
+	if isBuildFailed(st.Status) {
+		return &ExitError{Code: ExitCodeBuildFailed, Err: errors.New("image build failed")}
+	}
+	return nil
+}
+
+// getBuildLogPage fetches the log lines after token and returns them with the token to
+// continue from. The token is returned even at the end of the log so that it can be
+// used to wait for further lines.
+func getBuildLogPage(ctx context.Context, apiClient agentbay.Client, taskId, token string) ([]string, string, error) {
+	sourceAgentBay := "AgentBay"
+	req := &client.GetDockerImageTaskLogRequest{
+		Source: &sourceAgentBay,
+		TaskId: &taskId,
+	}
+	req.SetMaxResults(buildLogPageSize)
+	if token != "" {
+		req.NextToken = &token
+	}
+	log.Debugf("[DEBUG] GetDockerImageTaskLog Request: TaskId=%s NextToken=%s", taskId, token)
+
+	resp, err := apiClient.GetDockerImageTaskLog(ctx, req)
+	if err != nil {
+		return nil, token, fmt.Errorf("failed to get build log: %w", err)
+	}
+	if resp == nil || resp.Body == nil {
+		return nil, token, fmt.Errorf("invalid response: missing response body")
+	}
+	if resp.Body.Success != nil && !*resp.Body.Success {
+		return nil, token, fmt.Errorf("failed to get build log: %s", getStringValue(resp.Body.Message))
+	}
+	if resp.Body.Data == nil {
+		return nil, token, nil
+	}
+
+	next := getStringValue(resp.Body.Data.GetNextToken())
+	if next == "" {
+		next = token
+	}
+	return splitLogLines(getStringValue(resp.Body.Data.GetLogs())), next, nil
+}
+
+// readBuildLog reads the log lines after token until it has caught up with the build,
+// returning them with the token to continue from
+func readBuildLog(ctx context.Context, apiClient agentbay.Client, taskId, token string) ([]string, string, error) {
+	var lines []string
+	for {
+		page, next, err := getBuildLogPage(ctx, apiClient, taskId, token)
+		if err != nil {
+			return nil, token, err
+		}
+		lines = append(lines, page...)
+		if len(page) == 0 || next == token {
+			return lines, next, nil
+		}
+		token = next
+	}
+}
+
+// followBuildLog writes new log lines to w as they appear while the build is in
+// progress, starting after token. It returns the final status once the build has
+// finished and the rest of its log has been written.
+func followBuildLog(ctx context.Context, apiClient agentbay.Client, taskId, token string, interval time.Duration, w io.Writer) (*buildStatusOutput, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		lines, next, err := readBuildLog(ctx, apiClient, taskId, token)
+		if err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "[WARN] Warning: %v\n", err)
+		}
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+		}
+		token = next
+
+		st, err := getBuildTaskStatus(ctx, apiClient, taskId)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			fmt.Fprintf(os.Stderr, "[WARN] Warning: %v\n", err)
+		case err == nil && !isBuildInProgress(st.Status):
+			// Lines written between the last read and the status change
+			lines, _, err := readBuildLog(ctx, apiClient, taskId, token)
+			if err != nil {
+				return nil, err
+			}
+			for _, line := range lines {
+				fmt.Fprintln(w, line)
+			}
+			return st, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// printBuildLogTail prints the last lines of a failed build's log. Errors are only
+// logged: the log is a hint, the build failure is reported either way.
+func printBuildLogTail(ctx context.Context, apiClient agentbay.Client, taskId string, n int) {
+	lines, _, err := readBuildLog(ctx, apiClient, taskId, "")
+	if err != nil {
+		fmt.Fprintf(progressOut(), "[WARN] Could not read the build log: %v\n", err)
+		return
+	}
+	if len(lines) == 0 {
+		fmt.Fprintf(progressOut(), "[WARN] The build log of task %s is empty\n", taskId)
+		return
+	}
+	tail := tailLines(lines, n)
+	if len(tail) < len(lines) {
+		fmt.Fprintf(progressOut(), "[LOG] Last %d lines of the build log:\n", len(tail))
+	} else {
+		fmt.Fprintf(progressOut(), "[LOG] Build log:\n")
+	}
+	for _, line := range tail {
+		fmt.Fprintf(progressOut(), "  %s\n", line)
+	}
+	fmt.Fprintf(progressOut(), "[TIP] Show the full log with 'agentbay image logs %s'\n", taskId)
+}
+
+// splitLogLines splits a chunk of log text into lines, dropping the final newline
+func splitLogLines(text string) []string {
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// tailLines returns the last n lines, or all of them when n is 0
+func tailLines(lines []string, n int) []string {
+	if n <= 0 || n >= len(lines) {
+		return lines
+	}
+	return lines[len(lines)-n:]
+}
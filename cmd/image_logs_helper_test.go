@@ -0,0 +1,91 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// mockBuildLogClient serves chunks as pages of the build log, using the chunk index as
+// the NextToken. Each status query makes one more chunk available, as a running build
+// would write them.
+type mockBuildLogClient struct {
+	mockBuildTaskClient
+	chunks   []string
+	logCalls int
+}
+
+func (m *mockBuildLogClient) GetDockerImageTaskLog(ctx context.Context, request *client.GetDockerImageTaskLogRequest) (*client.GetDockerImageTaskLogResponse, error) {
+	m.logCalls++
+	i := 0
+	if request.NextToken != nil {
+		i, _ = strconv.Atoi(*request.NextToken)
+	}
+	data := &client.GetDockerImageTaskLogResponseBodyData{}
+	if available := m.calls + 1; i < len(m.chunks) && i < available {
+		data.SetLogs(m.chunks[i])
+		i++
+	}
+	data.SetNextToken(strconv.Itoa(i))
+	return &client.GetDockerImageTaskLogResponse{
+		Body: &client.GetDockerImageTaskLogResponseBody{Success: dara.Bool(true), Data: data},
+	}, nil
+}
+
+func TestReadBuildLog(t *testing.T) {
+	mockClient := &mockBuildLogClient{chunks: []string{"step 1\r\nstep 2\n", "step 3\n"}}
+	mockClient.calls = 10
+
+	lines, token, err := readBuildLog(context.Background(), mockClient, "task-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"step 1", "step 2", "step 3"}, lines)
+	assert.Equal(t, "2", token)
+
+	lines, token, err = readBuildLog(context.Background(), mockClient, "task-1", token)
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+	assert.Equal(t, "2", token, "the token is kept at the end of the log")
+}
+
+func TestFollowBuildLog(t *testing.T) {
+	mockClient := &mockBuildLogClient{chunks: []string{"a\n", "b\n", "c\n", "d\n"}}
+	mockClient.statuses = []string{"PENDING", "RUNNING", "FAILED"}
+
+	var out bytes.Buffer
+	st, err := followBuildLog(context.Background(), mockClient, "task-1", "", time.Millisecond, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "FAILED", st.Status)
+	assert.Equal(t, "a\nb\nc\nd\n", out.String(), "every line is printed once, including those written as the build ended")
+	assert.Equal(t, 3, mockClient.calls)
+}
+
+func TestFollowBuildLogCancelled(t *testing.T) {
+	mockClient := &mockBuildLogClient{}
+	mockClient.statuses = []string{"RUNNING"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := followBuildLog(ctx, mockClient, "task-1", "", time.Millisecond, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTailLines(t *testing.T) {
+	lines := []string{"1", "2", "3"}
+	assert.Equal(t, lines, tailLines(lines, 0))
+	assert.Equal(t, lines, tailLines(lines, 5))
+	assert.Equal(t, []string{"2", "3"}, tailLines(lines, 2))
+	assert.Nil(t, splitLogLines(""))
+	assert.Equal(t, []string{"", "x"}, splitLogLines("\nx\n"))
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alibabacloud-go/tea/dara"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// imageDetailLabelW is the label column width of 'agentbay image show'
+const imageDetailLabelW = 20
+
+// findListedImage pages through ListMcpImages for the given image type and returns the
+// entry for imageId, or nil if the image is not listed.
+func findListedImage(ctx context.Context, apiClient agentbay.Client, imageId, imageType string) (*client.ListMcpImagesResponseBodyData, error) {
+	req := &client.ListMcpImagesRequest{}
+	req.ImageType = &imageType
+
+	// Use a larger page size to reduce pagination; we only need to find one image
+	pageSize := int32(100)
+	req.PageSize = &pageSize
+	pageStart := int32(0)
+	req.PageStart = &pageStart
+
+	for {
+		resp, err := apiClient.ListMcpImages(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list images: %w", err)
+		}
+		if resp == nil || resp.Body == nil || resp.Body.Data == nil {
+			return nil, nil
+		}
+
+		for _, img := range resp.Body.Data {
+			if img != nil && getStringValue(img.ImageId) == imageId {
+				return img, nil
+			}
+		}
+
+		// Check for more pages
+		if resp.Body.NextToken == nil || *resp.Body.NextToken == "" {
+			return nil, nil
+		}
+		req.NextToken = resp.Body.NextToken
+	}
+}
+
+// getMcpImageInfoData returns the GetMcpImageInfo data of an image
+func getMcpImageInfoData(ctx context.Context, apiClient agentbay.Client, imageId string) (*client.GetMcpImageInfoResponseBodyData, error) {
+	request := &client.GetMcpImageInfoRequest{}
+	request.SetImageId(imageId)
+
+	resp, err := apiClient.GetMcpImageInfo(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image info: %w", err)
+	}
+	if resp == nil || resp.Body == nil {
+		return nil, fmt.Errorf("invalid response from GetMcpImageInfo")
+	}
+	if resp.Body.Success != nil && !*resp.Body.Success {
+		return nil, fmt.Errorf("GetMcpImageInfo failed: %s - %s", getStringValue(resp.Body.Code), getStringValue(resp.Body.Message))
+	}
+	if resp.Body.Data == nil {
+		return nil, fmt.Errorf("image not found: %s", imageId)
+	}
+	return resp.Body.Data, nil
+}
+
+// listImageType returns the ListMcpImages image type to search for an image,
+// falling back to User like GetResourceGroupIdForImage
+func listImageType(data *client.GetMcpImageInfoResponseBodyData) string {
+	if data.ImageInfo != nil && getStringValue(data.ImageInfo.ImageType) != "" {
+		return getStringValue(data.ImageInfo.ImageType)
+	}
+	return "User"
+}
+
+// newImageDetailOutput builds the 'image show' result from GetMcpImageInfo data and the
+// optional ListMcpImages entry of the same image.
+func newImageDetailOutput(imageId string, data *client.GetMcpImageInfoResponseBodyData, listed *client.ListMcpImagesResponseBodyData) *imageDetailOutput {
+	out := &imageDetailOutput{
+		ImageID:        getStringValue(data.ImageId),
+		ImageName:      getStringValue(data.ImageName),
+		BuildType:      getStringValue(data.ImageBuildType),
+		ResourceStatus: getStringValue(data.ImageResourceStatus),
+		ApplyScene:     getStringValue(data.ImageApplyScene),
+	}
+	if out.ImageID == "" {
+		out.ImageID = imageId
+	}
+
+	if info := data.ImageInfo; info != nil {
+		out.ImageType = getStringValue(info.ImageType)
+		out.OS = &imageOSOutput{
+			OsName:         getStringValue(info.OsName),
+			OsVersion:      getStringValue(info.OsVersion),
+			PlatformName:   getStringValue(info.PlatformName),
+			SystemDiskSize: dara.Int32Value(info.SystemDiskSize),
+			DataDiskSize:   dara.Int32Value(info.DataDiskSize),
+			UpdateTime:     getStringValue(info.UpdateTime),
+		}
+		// Same fallback as GetImageInfo when ImageResourceStatus is not returned
+		if out.ResourceStatus == "" {
+			out.ResourceStatus = getStringValue(info.Status)
+		}
+	}
+
+	if build := data.ImageBuildInfo; build != nil {
+		out.Build = &imageBuildOutput{
+			TaskID:        getStringValue(build.TaskId),
+			VersionID:     getStringValue(build.VersionId),
+			ApiKeyID:      getStringValue(build.ApiKeyId),
+			InstanceReady: dara.BoolValue(build.InstanceReady),
+		}
+	}
+
+	if listed != nil {
+		out.Description = getStringValue(listed.ImageIntro)
+		if out.ImageName == "" {
+			out.ImageName = getStringValue(listed.ImageName)
+		}
+		if out.ResourceStatus == "" {
+			out.ResourceStatus = getStringValue(listed.ImageResourceStatus)
+		}
+		if rg := listed.ImageResourceGroupInfo; rg != nil {
+			out.ResourceGroup = &imageResourceGroupOutput{
+				ResourceGroupID:  getStringValue(rg.ResourceGroupId),
+				Status:           getStringValue(rg.ResourceGroupStatus),
+				RegionID:         getStringValue(rg.BizRegionId),
+				VpcID:            getStringValue(rg.VpcId),
+				VSwitchID:        getStringValue(rg.VSwitchId),
+				PolicyID:         getStringValue(rg.PolicyId),
+				SessionBandwidth: dara.Int32Value(rg.SessionBandwidth),
+			}
+		}
+	}
+
+	out.Status = TranslateImageResourceStatus(out.ResourceStatus)
+	return out
+}
+
+// printImageDetail prints the table view of 'agentbay image show'
+func printImageDetail(d *imageDetailOutput) {
+	row := func(label, value string) {
+		if value != "" {
+			fmt.Printf("%-*s %s\n", imageDetailLabelW, label+":", value)
+		}
+	}
+	number := func(label string, value int32, unit string) {
+		if value != 0 {
+			row(label, fmt.Sprintf("%d%s", value, unit))
+		}
+	}
+
+	fmt.Printf("\n=== IMAGE ===\n")
+	row("Image ID", d.ImageID)
+	row("Image Name", d.ImageName)
+	row("Image Type", d.ImageType)
+	row("Build Type", d.BuildType)
+	if d.ResourceStatus != "" {
+		row("Status", fmt.Sprintf("%s (%s)", d.Status, d.ResourceStatus))
+	} else {
+		row("Status", d.Status)
+	}
+	row("Apply Scene", d.ApplyScene)
+	row("Description", d.Description)
+
+	if info := d.OS; info != nil {
+		fmt.Printf("\n=== OS ===\n")
+		row("OS Name", info.OsName)
+		row("OS Version", info.OsVersion)
+		row("Platform", info.PlatformName)
+		number("System Disk", info.SystemDiskSize, " GB")
+		number("Data Disk", info.DataDiskSize, " GB")
+		row("Updated", info.UpdateTime)
+	}
+
+	if b := d.Build; b != nil {
+		fmt.Printf("\n=== BUILD ===\n")
+		row("Task ID", b.TaskID)
+		row("Version ID", b.VersionID)
+		row("API Key ID", b.ApiKeyID)
+		row("Instance Ready", fmt.Sprintf("%t", b.InstanceReady))
+	}
+
+	if rg := d.ResourceGroup; rg != nil {
+		fmt.Printf("\n=== RESOURCE GROUP ===\n")
+		row("Resource Group ID", rg.ResourceGroupID)
+		row("Status", rg.Status)
+		row("Region", rg.RegionID)
+		row("VPC ID", rg.VpcID)
+		row("VSwitch ID", rg.VSwitchID)
+		row("Policy ID", rg.PolicyID)
+		number("Session Bandwidth", rg.SessionBandwidth, " Mbps")
+	}
+}
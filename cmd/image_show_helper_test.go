@@ -0,0 +1,124 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func newMockImageInfo() *client.GetMcpImageInfoResponseBodyData {
+	return &client.GetMcpImageInfoResponseBodyData{
+		ImageId:             dara.String("imgc-1234567890"),
+		ImageName:           dara.String("my-image"),
+		ImageBuildType:      dara.String("DockerBuilder"),
+		ImageResourceStatus: dara.String("RESOURCE_PUBLISHED"),
+		ImageApplyScene:     dara.String("CodeSpace"),
+		ImageInfo: &client.GetMcpImageInfoResponseBodyDataImageInfo{
+			ImageType:      dara.String("User"),
+			OsName:         dara.String("Linux"),
+			OsVersion:      dara.String("Debian 12"),
+			SystemDiskSize: dara.Int32(40),
+		},
+		ImageBuildInfo: &client.GetMcpImageInfoResponseBodyDataImageBuildInfo{
+			TaskId:        dara.String("task-1"),
+			VersionId:     dara.String("v-1"),
+			ApiKeyId:      dara.String("ak-1"),
+			InstanceReady: dara.Bool(true),
+		},
+	}
+}
+
+func TestNewImageDetailOutput(t *testing.T) {
+	listed := createMockImage("imgc-1234567890", "my-image", "DockerBuilder", "RESOURCE_PUBLISHED")
+	listed.ImageIntro = dara.String("my first image")
+	listed.ImageResourceGroupInfo = &client.ListMcpImagesResponseBodyDataImageResourceGroupInfo{
+		ResourceGroupId:     dara.String("rg-1"),
+		ResourceGroupStatus: dara.String("Running"),
+		VpcId:               dara.String("vpc-1"),
+		VSwitchId:           dara.String("vsw-1"),
+		PolicyId:            dara.String("pg-1"),
+		SessionBandwidth:    dara.Int32(10),
+	}
+
+	out := newImageDetailOutput("imgc-1234567890", newMockImageInfo(), listed)
+
+	assert.Equal(t, "User", out.ImageType)
+	assert.Equal(t, "DockerBuilder", out.BuildType)
+	assert.Equal(t, "Activated", out.Status)
+	assert.Equal(t, "my first image", out.Description)
+	require.NotNil(t, out.OS)
+	assert.Equal(t, int32(40), out.OS.SystemDiskSize)
+	require.NotNil(t, out.Build)
+	assert.Equal(t, "task-1", out.Build.TaskID)
+	assert.True(t, out.Build.InstanceReady)
+	require.NotNil(t, out.ResourceGroup)
+	assert.Equal(t, "vpc-1", out.ResourceGroup.VpcID)
+	assert.Equal(t, "vsw-1", out.ResourceGroup.VSwitchID)
+	assert.Equal(t, "pg-1", out.ResourceGroup.PolicyID)
+	assert.Equal(t, int32(10), out.ResourceGroup.SessionBandwidth)
+	assert.Equal(t, "Running", out.ResourceGroup.Status)
+
+	data, err := json.Marshal(out)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "imgc-1234567890", decoded["imageId"])
+	assert.Equal(t, "rg-1", decoded["resourceGroup"].(map[string]interface{})["resourceGroupId"])
+	assert.Equal(t, "ak-1", decoded["build"].(map[string]interface{})["apiKeyId"])
+}
+
+func TestNewImageDetailOutputWithoutListEntry(t *testing.T) {
+	info := newMockImageInfo()
+	info.ImageResourceStatus = nil
+	info.ImageInfo.Status = dara.String("IMAGE_AVAILABLE")
+
+	out := newImageDetailOutput("imgc-1234567890", info, nil)
+	assert.Equal(t, "IMAGE_AVAILABLE", out.ResourceStatus, "falls back to ImageInfo.Status")
+	assert.Nil(t, out.ResourceGroup)
+}
+
+func TestFindListedImage(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &mockImageListClient{
+		userImages: []*client.ListMcpImagesResponseBodyData{
+			createMockImage("imgc-aaa", "a", "User", "IMAGE_AVAILABLE"),
+			createMockImage("imgc-bbb", "b", "User", "RESOURCE_PUBLISHED"),
+		},
+	}
+
+	img, err := findListedImage(ctx, mockClient, "imgc-bbb", "User")
+	require.NoError(t, err)
+	require.NotNil(t, img)
+	assert.Equal(t, "b", getStringValue(img.ImageName))
+
+	img, err = findListedImage(ctx, mockClient, "imgc-missing", "User")
+	require.NoError(t, err)
+	assert.Nil(t, img)
+
+	mockClient.userError = fmt.Errorf("boom")
+	_, err = findListedImage(ctx, mockClient, "imgc-bbb", "User")
+	require.Error(t, err)
+}
+
+func TestGetMcpImageInfoData(t *testing.T) {
+	mockClient := &mockImageListClient{imageInfo: newMockImageInfo()}
+
+	data, err := getMcpImageInfoData(context.Background(), mockClient, "imgc-1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "User", listImageType(data))
+
+	data.ImageInfo = nil
+	assert.Equal(t, "User", listImageType(data))
+	data.ImageInfo = &client.GetMcpImageInfoResponseBodyDataImageInfo{ImageType: dara.String("System")}
+	assert.Equal(t, "System", listImageType(data))
+}
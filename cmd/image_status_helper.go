@@ -190,18 +190,11 @@ func GetImageInfo(ctx context.Context, apiClient agentbay.Client, imageId string
 
 	info := &ImageInfo{}
 
-	// Try to get ImageResourceStatus from response headers (stored during XML parsing)
-	if resp.Headers != nil {
-		if resourceStatus, ok := resp.Headers["X-Image-Resource-Status"]; ok && resourceStatus != nil {
-			info.ResourceStatus = dara.StringValue(resourceStatus)
-			log.Debugf("[DEBUG] Got ImageResourceStatus from header: %s", info.ResourceStatus)
-		}
-
-		if imageType, ok := resp.Headers["X-Image-Type"]; ok && imageType != nil {
-			info.ImageType = dara.StringValue(imageType)
-			log.Debugf("[DEBUG] Got ImageType from header: %s", info.ImageType)
-		}
+	info.ResourceStatus = dara.StringValue(resp.Body.Data.ImageResourceStatus)
+	if resp.Body.Data.ImageInfo != nil {
+		info.ImageType = dara.StringValue(resp.Body.Data.ImageInfo.ImageType)
 	}
+	log.Debugf("[DEBUG] ImageResourceStatus: %s, ImageType: %s", info.ResourceStatus, info.ImageType)
 
 	// Fallback to ImageInfo.Status if ImageResourceStatus not available
 	if info.ResourceStatus == "" && resp.Body.Data.ImageInfo != nil && resp.Body.Data.ImageInfo.Status != nil {
@@ -231,51 +224,15 @@ func GetImageResourceStatus(ctx context.Context, apiClient agentbay.Client, imag
 // GetResourceGroupIdForImage fetches ResourceGroupId for the given image from ListMcpImages (user images).
 // Returns empty string if not found or if the image has no ResourceGroupId.
 func GetResourceGroupIdForImage(ctx context.Context, apiClient agentbay.Client, imageId string) (string, error) {
-	req := &client.ListMcpImagesRequest{}
-	userImageType := "User"
-	req.ImageType = &userImageType
-
-	// Use a larger page size to reduce pagination; we only need to find one image
-	pageSize := int32(100)
-	req.PageSize = &pageSize
-	pageStart := int32(0)
-	req.PageStart = &pageStart
-
-	for {
-		resp, err := apiClient.ListMcpImages(ctx, req)
-		if err != nil {
-			return "", fmt.Errorf("failed to list images: %w", err)
-		}
-		if resp == nil || resp.Body == nil || resp.Body.Data == nil {
-			return "", nil
-		}
-
-		for _, img := range resp.Body.Data {
-			if img == nil {
-				continue
-			}
-			imgId := ""
-			if img.ImageId != nil {
-				imgId = *img.ImageId
-			}
-			if imgId != imageId {
-				continue
-			}
-			rgInfo := img.GetImageResourceGroupInfo()
-			if rgInfo != nil && rgInfo.ResourceGroupId != nil && *rgInfo.ResourceGroupId != "" {
-				return *rgInfo.ResourceGroupId, nil
-			}
-			return "", nil // found image but no ResourceGroupId
-		}
-
-		// Check for more pages
-		if resp.Body.NextToken == nil || *resp.Body.NextToken == "" {
-			break
-		}
-		req.NextToken = resp.Body.NextToken
+	img, err := findListedImage(ctx, apiClient, imageId, "User")
+	if err != nil || img == nil {
+		return "", err // image not found
 	}
-
-	return "", nil // image not found
+	rgInfo := img.GetImageResourceGroupInfo()
+	if rgInfo != nil && rgInfo.ResourceGroupId != nil && *rgInfo.ResourceGroupId != "" {
+		return *rgInfo.ResourceGroupId, nil
+	}
+	return "", nil // found image but no ResourceGroupId
 }
 
 // IsUserImage checks if the image is a User type image
@@ -315,6 +272,9 @@ func pollForStatus(
 	timeoutCtx, cancel := context.WithTimeout(ctx, config.Timeout)
 	defer cancel()
 
+	progress := newStatusProgress(progressOut(), "  Status: ")
+	defer progress.stop()
+
 	for {
 		attempts++
 
@@ -326,6 +286,9 @@ func pollForStatus(
 		// Check if context is cancelled or timed out
 		select {
 		case <-timeoutCtx.Done():
+			if ctx.Err() != nil {
+				return fmt.Errorf("%s polling cancelled after %v: %w", operationName, time.Since(startTime).Round(time.Second), ctx.Err())
+			}
 			return fmt.Errorf("%s polling timed out after %v", operationName, time.Since(startTime))
 		default:
 		}
@@ -345,7 +308,8 @@ func pollForStatus(
 			// Check if we've reached a target status
 			for _, expectedStatus := range expectedStatuses {
 				if currentStatus == expectedStatus {
-					fmt.Printf("[SUCCESS] %s completed! Current status: %s\n",
+					progress.stop()
+					fmt.Fprintf(progressOut(), "[SUCCESS] %s completed! Current status: %s\n",
 						operationName, translatedStatus)
 					return nil
 				}
@@ -357,13 +321,15 @@ func pollForStatus(
 			}
 
 			// Update user with current status
-			fmt.Printf("  Status: %s (elapsed: %v, attempt: %d/%d)\n",
-				translatedStatus, time.Since(startTime).Round(time.Second), attempts, config.MaxAttempts)
+			progress.update(translatedStatus, fmt.Sprintf(", attempt: %d/%d", attempts, config.MaxAttempts))
 		}
 
 		// Wait before next attempt
 		select {
 		case <-timeoutCtx.Done():
+			if ctx.Err() != nil {
+				return fmt.Errorf("%s polling cancelled after %v: %w", operationName, time.Since(startTime).Round(time.Second), ctx.Err())
+			}
 			return fmt.Errorf("%s polling timed out after %v", operationName, time.Since(startTime))
 		case <-time.After(interval):
 			// Exponential backoff with max interval
@@ -0,0 +1,112 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// templateApplyScene is the apply scene of the system images that have a Dockerfile
+// template; the CLI only builds CodeSpace images
+const templateApplyScene = "CodeSpace"
+
+func runImageTemplates(cmd *cobra.Command, args []string) error {
+	fmt.Fprintf(progressOut(), "[LIST] Fetching source images with a Dockerfile template...\n")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] Failed to load configuration: %v\n", err)
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		fmt.Fprintf(os.Stderr, "[ERROR] Not authenticated. Please run 'agentbay login' first\n")
+		return fmt.Errorf("not authenticated. Please run 'agentbay login' first")
+	}
+
+	apiClient := agentbay.NewClientFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	fmt.Fprintf(progressOut(), "Requesting system images...")
+	templates, err := listTemplateImages(ctx, apiClient)
+	if err != nil {
+		fmt.Fprintf(progressOut(), " Failed.\n")
+		return err
+	}
+	fmt.Fprintf(progressOut(), " Done.\n")
+
+	result := imageTemplateListOutput{
+		Templates:  newImageListOutput(templates, 0, nil).Images,
+		TotalCount: len(templates),
+	}
+	return renderOutput(result, func() { printImageTemplates(result) })
+}
+
+// listTemplateImages pages through the System images and returns those that have a
+// Dockerfile template
+func listTemplateImages(ctx context.Context, apiClient agentbay.Client) ([]*client.ListMcpImagesResponseBodyData, error) {
+	req := &client.ListMcpImagesRequest{}
+	imageType := "System"
+	req.ImageType = &imageType
+	pageSize := int32(100)
+	req.PageSize = &pageSize
+	pageStart := int32(0)
+	req.PageStart = &pageStart
+
+	var templates []*client.ListMcpImagesResponseBodyData
+	for {
+		resp, err := apiClient.ListMcpImages(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list system images: %w", err)
+		}
+		if resp == nil || resp.Body == nil {
+			return nil, fmt.Errorf("invalid response: missing response body")
+		}
+		if resp.Body.Success != nil && !*resp.Body.Success {
+			return nil, fmt.Errorf("API request failed: %s", getStringValue(resp.Body.Message))
+		}
+		for _, img := range resp.Body.Data {
+			if img != nil && getStringValue(img.ImageApplyScene) == templateApplyScene {
+				templates = append(templates, img)
+			}
+		}
+		if resp.Body.NextToken == nil || *resp.Body.NextToken == "" {
+			return templates, nil
+		}
+		req.NextToken = resp.Body.NextToken
+	}
+}
+
+// printImageTemplates prints the template source images as a table
+func printImageTemplates(result imageTemplateListOutput) {
+	if len(result.Templates) == 0 {
+		fmt.Printf("\n[EMPTY] No Dockerfile templates found.\n")
+		return
+	}
+
+	fmt.Printf("\n[OK] Found %d source images with a Dockerfile template\n\n", result.TotalCount)
+	fmt.Printf("%s %s %s\n", padString("SOURCE IMAGE ID", 35), padString("IMAGE NAME", 30), "OS")
+	fmt.Printf("%s %s %s\n", padString("---------------", 35), padString("----------", 30), "--")
+	for _, t := range result.Templates {
+		osInfo := strings.TrimSpace(t.OsName + " " + t.OsVersion)
+		if osInfo == "" {
+			osInfo = "-"
+		}
+		fmt.Printf("%s %s %s\n",
+			padString(truncateString(t.ImageID, 35), 35),
+			padString(truncateString(t.ImageName, 30), 30),
+			osInfo)
+	}
+	fmt.Printf("\n[TIP] Download a template with: agentbay image init -i <source-image-id>\n")
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// pagedImageListClient returns one page of System images per NextToken
+type pagedImageListClient struct {
+	mockImageListClient
+	pages [][]*client.ListMcpImagesResponseBodyData
+}
+
+func (m *pagedImageListClient) ListMcpImages(ctx context.Context, req *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
+	page := 0
+	if req.NextToken != nil {
+		page = len(*req.NextToken)
+	}
+	body := &client.ListMcpImagesResponseBody{Data: m.pages[page], Success: boolPtr(true)}
+	if page+1 < len(m.pages) {
+		body.NextToken = stringPtr(string(make([]byte, page+1)))
+	}
+	return &client.ListMcpImagesResponse{Body: body}, nil
+}
+
+func TestListTemplateImages(t *testing.T) {
+	mobile := createMockImage("mobile-use-android-14", "Mobile Use Android 14", "System", "IMAGE_AVAILABLE")
+	mobile.ImageApplyScene = stringPtr("MobileUse")
+	mockClient := &pagedImageListClient{pages: [][]*client.ListMcpImagesResponseBodyData{
+		{createMockImage("code-space-debian-12", "Debian 12", "System", "IMAGE_AVAILABLE"), mobile, nil},
+		{createMockImage("code-space-debian-12-enhanced", "Debian 12 Enhanced", "System", "IMAGE_AVAILABLE")},
+	}}
+
+	templates, err := listTemplateImages(context.Background(), mockClient)
+	require.NoError(t, err)
+	var ids []string
+	for _, img := range templates {
+		ids = append(ids, getStringValue(img.ImageId))
+	}
+	assert.Equal(t, []string{"code-space-debian-12", "code-space-debian-12-enhanced"}, ids)
+}
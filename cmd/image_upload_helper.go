@@ -0,0 +1,355 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc64"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// ossUploadRetryConfig returns the retry policy of a single file upload
+var ossUploadRetryConfig = func() *client.RetryConfig {
+	cfg := client.DefaultRetryConfig()
+	cfg.MaxRetries = 5
+	return cfg
+}
+
+// ossHTTPClient uploads build-context files. It has no overall timeout so large files
+// can take as long as they need; cancellation comes from the request context.
+var ossHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 2 * time.Minute,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConnsPerHost:   16,
+	},
+}
+
+var crc64Table = crc64.MakeTable(crc64.ECMA)
+
+// ossUploadResult describes an uploaded file as verified against OSS
+type ossUploadResult struct {
+	Size  int64
+	MD5   string // lower-case hex
+	CRC64 uint64
+}
+
+// ossStatusError is a non-2xx response to an upload
+type ossStatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *ossStatusError) Error() string {
+	return fmt.Sprintf("upload failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// ossChecksumError means OSS stored different content than was sent
+type ossChecksumError struct {
+	Kind     string
+	Expected string
+	Actual   string
+}
+
+func (e *ossChecksumError) Error() string {
+	return fmt.Sprintf("%s mismatch after upload: local %s, OSS %s", e.Kind, e.Expected, e.Actual)
+}
+
+// uploadChecksum computes MD5 and CRC64 (ECMA, as used by OSS) of streamed data
+type uploadChecksum struct {
+	md5   hash.Hash
+	crc64 hash.Hash64
+	size  int64
+}
+
+func newUploadChecksum() *uploadChecksum {
+	return &uploadChecksum{md5: md5.New(), crc64: crc64.New(crc64Table)}
+}
+
+func (c *uploadChecksum) Write(p []byte) (int, error) {
+	c.md5.Write(p)
+	c.crc64.Write(p)
+	c.size += int64(len(p))
+	return len(p), nil
+}
+
+func (c *uploadChecksum) result() *ossUploadResult {
+	return &ossUploadResult{Size: c.size, MD5: hex.EncodeToString(c.md5.Sum(nil)), CRC64: c.crc64.Sum64()}
+}
+
+// uploadFileToOSS streams a local file to a presigned OSS URL, retrying transient
+// failures with backoff and verifying the stored object's checksums. Bytes sent are
+// counted in progress and throttled by limiter; either may be nil.
+func uploadFileToOSS(ctx context.Context, localPath, ossUrl string, progress *fileProgress, limiter *bandwidthLimiter) (*ossUploadResult, error) {
+	res, err := client.DoWithRetry(ctx, ossUploadRetryConfig(), func(ctx context.Context, attempt int) (*ossUploadResult, error) {
+		if attempt > 0 {
+			log.Debugf("[DEBUG] Retrying upload of %s (attempt %d)", localPath, attempt+1)
+		}
+		return putFileToOSS(ctx, localPath, ossUrl, progress, limiter)
+	}, ossUploadRetryDecision)
+	if err == nil {
+		progress.done()
+	}
+	return res, err
+}
+
+// uploadSingleFileToOSS is uploadFileToOSS showing the progress of the upload as name
+func uploadSingleFileToOSS(ctx context.Context, localPath, ossUrl, name string, limiter *bandwidthLimiter) (*ossUploadResult, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	progress := newTransferProgress(progressOut(), "Uploading", 1, info.Size())
+	res, err := uploadFileToOSS(ctx, localPath, ossUrl, progress.file(name, info.Size()), limiter)
+	if err != nil {
+		progress.abort()
+		return nil, err
+	}
+	progress.finish()
+	return res, nil
+}
+
+// putFileToOSS makes a single streaming PUT of localPath. A failed PUT is retried from
+// the first byte: OSS multipart upload, with per-part retry and resume, would need
+// a credential for the initiate/upload-part/complete requests, and
+// GetDockerFileStoreCredential only issues a presigned URL for one PUT.
+func putFileToOSS(ctx context.Context, localPath, ossUrl string, progress *fileProgress, limiter *bandwidthLimiter) (*ossUploadResult, error) {
+	log.Debugf("[DEBUG] Starting file upload: %s", localPath)
+	f, err := os.Open(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var sum *uploadChecksum
+	body := func() io.ReadCloser {
+		sum = newUploadChecksum()
+		progress.reset()
+		src := limiter.reader(ctx, f)
+		if progress == nil {
+			return io.NopCloser(io.TeeReader(src, sum))
+		}
+		return io.NopCloser(io.TeeReader(src, io.MultiWriter(sum, progress)))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, ossUrl, body())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload request: %w", err)
+	}
+	// OSS may return 307/308 redirect; without GetBody the redirect request sends an empty body and the object is stored empty.
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return body(), nil
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("User-Agent", "AgentBay-CLI/1.0")
+	req.ContentLength = info.Size()
+
+	resp, err := ossHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		statusErr := &ossStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+		statusErr.RetryAfter, _ = client.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return nil, statusErr
+	}
+
+	result := sum.result()
+	if result.Size != info.Size() {
+		return nil, fmt.Errorf("file changed during upload: sent %d of %d bytes", result.Size, info.Size())
+	}
+	if err := verifyOSSChecksums(resp.Header, result); err != nil {
+		return nil, err
+	}
+	log.Debugf("[DEBUG] Uploaded %s (%d bytes, md5 %s)", localPath, result.Size, result.MD5)
+	return result, nil
+}
+
+// verifyOSSChecksums compares the local checksums with the x-oss-hash-crc64ecma and
+// ETag response headers. The ETag of a simple upload is the object's MD5.
+func verifyOSSChecksums(header http.Header, result *ossUploadResult) error {
+	verified := false
+	if v := header.Get("x-oss-hash-crc64ecma"); v != "" {
+		remote, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid x-oss-hash-crc64ecma header %q", v)
+		}
+		if remote != result.CRC64 {
+			return &ossChecksumError{Kind: "CRC64", Expected: strconv.FormatUint(result.CRC64, 10), Actual: v}
+		}
+		verified = true
+	}
+	if etag := strings.Trim(header.Get("ETag"), `"`); len(etag) == md5.Size*2 {
+		if _, err := hex.DecodeString(etag); err == nil {
+			if !strings.EqualFold(etag, result.MD5) {
+				return &ossChecksumError{Kind: "MD5", Expected: result.MD5, Actual: strings.ToLower(etag)}
+			}
+			verified = true
+		}
+	}
+	if !verified {
+		log.Debugf("[DEBUG] Upload response has no checksum headers, skipping verification")
+	}
+	return nil
+}
+
+// ossUploadRetryDecision retries network failures, checksum mismatches and
+// retryable HTTP statuses. Local file errors and cancellation are final.
+func ossUploadRetryDecision(err error) client.RetryDecision {
+	var statusErr *ossStatusError
+	if errors.As(err, &statusErr) {
+		return client.RetryDecision{Retry: client.IsRetryableHTTPStatus(statusErr.StatusCode), Wait: statusErr.RetryAfter}
+	}
+	var checksumErr *ossChecksumError
+	if errors.As(err, &checksumErr) {
+		return client.RetryDecision{Retry: true}
+	}
+	var pathErr *fs.PathError
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &pathErr) {
+		return client.RetryDecision{}
+	}
+	return client.RetryDecision{Retry: true}
+}
+
+// uploadConfigFromFlags applies the --concurrency and --limit-rate flags over the
+// upload settings from the environment
+func uploadConfigFromFlags(cmd *cobra.Command) (config.UploadConfig, error) {
+	cfg := config.LoadUploadConfig()
+	if cmd.Flags().Changed("concurrency") {
+		n, _ := cmd.Flags().GetInt("concurrency")
+		if n < 1 || n > config.MaxUploadConcurrency {
+			return cfg, fmt.Errorf("--concurrency must be between 1 and %d, got %d", config.MaxUploadConcurrency, n)
+		}
+		cfg.Concurrency = n
+	}
+	if cmd.Flags().Changed("limit-rate") {
+		value, _ := cmd.Flags().GetString("limit-rate")
+		rate, err := config.ParseByteRate(value)
+		if err != nil {
+			return cfg, fmt.Errorf("--limit-rate: %w", err)
+		}
+		cfg.LimitRate = rate
+	}
+	return cfg, nil
+}
+
+// getDockerfileCredential requests the upload URL of the Dockerfile. If the server
+// rejects the task the request reuses, for example because it has dropped the task,
+// the request is made once more for a new task, unless the user asked to resume it.
+func getDockerfileCredential(ctx context.Context, apiClient agentbay.Client, credReq *client.GetDockerFileStoreCredentialRequest, resume bool) (*client.GetDockerFileStoreCredentialResponse, error) {
+	resp, err := apiClient.GetDockerFileStoreCredential(ctx, credReq)
+	if err == nil || credReq.TaskId == nil || resume || ctx.Err() != nil {
+		return resp, err
+	}
+	log.Debugf("[DEBUG] Upload task %s was rejected, requesting a new task: %v", *credReq.TaskId, err)
+	retryReq := *credReq
+	retryReq.TaskId = nil
+	return apiClient.GetDockerFileStoreCredential(ctx, &retryReq)
+}
+
+// requestUploadCredentials gets a presigned upload URL for each file of a build task,
+// keyed by absolute path, making up to concurrency requests at a time. The first
+// failure cancels the requests still pending.
+func requestUploadCredentials(ctx context.Context, apiClient agentbay.Client, taskId string, files []uploadFile, concurrency int) (map[string]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sourceAgentBay := "AgentBay"
+	sem := make(chan struct{}, concurrency)
+	var mu sync.Mutex
+	creds := make(map[string]string, len(files))
+	var firstErr error
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	for _, f := range files {
+		f := f
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			credReq := &client.GetDockerFileStoreCredentialRequest{
+				Source:       &sourceAgentBay,
+				FilePath:     &f.relPath,
+				IsDockerfile: dara.String("false"),
+				TaskId:       &taskId,
+			}
+			resp, err := apiClient.GetDockerFileStoreCredential(ctx, credReq)
+			if err != nil {
+				fail(fmt.Errorf("failed to get upload credentials for %s: %w", f.relPath, err))
+				return
+			}
+			if resp.Body == nil || resp.Body.Data == nil {
+				fail(fmt.Errorf("invalid response: missing upload credentials for %s", f.relPath))
+				return
+			}
+			ossUrl := resp.Body.Data.GetOssUrl()
+			if ossUrl == nil || *ossUrl == "" {
+				fail(fmt.Errorf("invalid response: missing OSS URL for %s", f.relPath))
+				return
+			}
+			mu.Lock()
+			creds[f.absPath] = *ossUrl
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
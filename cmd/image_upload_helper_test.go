@@ -0,0 +1,421 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"hash/crc64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// fastUploadRetries makes upload retries immediate for the duration of a test
+func fastUploadRetries(t *testing.T) {
+	orig := ossUploadRetryConfig
+	ossUploadRetryConfig = func() *client.RetryConfig {
+		return &client.RetryConfig{MaxRetries: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1}
+	}
+	t.Cleanup(func() { ossUploadRetryConfig = orig })
+}
+
+// ossStub stores PUT bodies and answers with OSS-style checksum headers
+func ossStub(t *testing.T, handle func(w http.ResponseWriter, r *http.Request, attempt int32) bool) (*httptest.Server, *[]byte) {
+	var stored []byte
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if handle != nil && handle(w, r, n) {
+			return
+		}
+		stored = body
+		sum := md5.Sum(body)
+		w.Header().Set("ETag", `"`+strings.ToUpper(hex.EncodeToString(sum[:]))+`"`)
+		w.Header().Set("x-oss-hash-crc64ecma", strconv.FormatUint(crc64.Checksum(body, crc64.MakeTable(crc64.ECMA)), 10))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &stored
+}
+
+func writeUploadFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "model.bin")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestUploadFileToOSS(t *testing.T) {
+	fastUploadRetries(t)
+	path := writeUploadFile(t, "hello world")
+	srv, stored := ossStub(t, nil)
+
+	res, err := uploadFileToOSS(context.Background(), path, srv.URL, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(*stored))
+	assert.Equal(t, int64(11), res.Size)
+	assert.Equal(t, "5eb63bbbe01eeed093cb22bb8f5acdc3", res.MD5)
+}
+
+func TestUploadFileToOSS_RetriesTransientFailures(t *testing.T) {
+	fastUploadRetries(t)
+	path := writeUploadFile(t, "payload")
+	srv, stored := ossStub(t, func(w http.ResponseWriter, r *http.Request, attempt int32) bool {
+		switch attempt {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		case 2:
+			// Claim a different checksum than the body that was sent
+			w.Header().Set("ETag", `"00000000000000000000000000000000"`)
+			w.WriteHeader(http.StatusOK)
+			return true
+		}
+		return false
+	})
+
+	_, err := uploadFileToOSS(context.Background(), path, srv.URL, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(*stored))
+}
+
+func TestUploadFileToOSS_PermanentFailure(t *testing.T) {
+	fastUploadRetries(t)
+	path := writeUploadFile(t, "payload")
+	var calls int32
+	srv, _ := ossStub(t, func(w http.ResponseWriter, r *http.Request, attempt int32) bool {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+		return true
+	})
+
+	_, err := uploadFileToOSS(context.Background(), path, srv.URL, nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "403 is not retried")
+}
+
+func TestUploadFileToOSS_FollowsRedirectWithBody(t *testing.T) {
+	fastUploadRetries(t)
+	path := writeUploadFile(t, "redirected body")
+	target, stored := ossStub(t, nil)
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	_, err := uploadFileToOSS(context.Background(), path, redirect.URL, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "redirected body", string(*stored))
+}
+
+func TestUploadJournal(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	path := writeUploadFile(t, "journal me")
+
+	j, err := loadUploadJournal("task-1")
+	require.NoError(t, err)
+	_, ok := j.completed("model.bin", path)
+	assert.False(t, ok)
+
+	sum := md5.Sum([]byte("journal me"))
+	require.NoError(t, j.record("model.bin", path, &ossUploadResult{Size: 10, MD5: hex.EncodeToString(sum[:])}))
+
+	reloaded, err := loadUploadJournal("task-1")
+	require.NoError(t, err)
+	size, ok := reloaded.completed("model.bin", path)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), size)
+
+	// Touching the file without changing it keeps it uploaded
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, later, later))
+	_, ok = reloaded.completed("model.bin", path)
+	assert.True(t, ok)
+
+	// Changed content must be uploaded again
+	require.NoError(t, os.WriteFile(path, []byte("journal m3"), 0644))
+	require.NoError(t, os.Chtimes(path, later.Add(time.Hour), later.Add(time.Hour)))
+	_, ok = reloaded.completed("model.bin", path)
+	assert.False(t, ok)
+
+	require.NoError(t, reloaded.remove())
+	empty, err := loadUploadJournal("task-1")
+	require.NoError(t, err)
+	assert.Empty(t, empty.Files)
+
+	_, err = loadUploadJournal("../escape")
+	require.Error(t, err)
+}
+
+// recordUpload writes the journal of a task holding files, as after their upload
+func recordUpload(t *testing.T, taskId string, files []uploadFile) *uploadJournal {
+	j, err := loadUploadJournal(taskId)
+	require.NoError(t, err)
+	for _, f := range files {
+		content, err := os.ReadFile(f.absPath)
+		require.NoError(t, err)
+		sum := newUploadChecksum()
+		sum.Write(content)
+		require.NoError(t, j.record(f.relPath, f.absPath, sum.result()))
+	}
+	return j
+}
+
+func TestUploadManifest(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	path := writeUploadFile(t, "reuse me")
+	contextDir := filepath.Dir(path)
+	files, err := newUploadFiles(contextDir, []string{path})
+	require.NoError(t, err)
+
+	assert.Empty(t, reusableUploadTask("default", files), "no previous upload")
+
+	// A task is only offered once its build succeeded
+	journal := recordUpload(t, "task-1", files)
+	assert.Empty(t, reusableUploadTask("default", files))
+	require.NoError(t, recordUploadTask(journal, "default", "/src/app/Dockerfile"))
+	assert.Equal(t, "task-1", reusableUploadTask("default", files))
+	assert.Empty(t, reusableUploadTask("work", files), "tasks are not shared between profiles")
+
+	t.Run("content is looked up across Dockerfiles", func(t *testing.T) {
+		otherDir := t.TempDir()
+		copied := filepath.Join(otherDir, "model.bin")
+		require.NoError(t, os.WriteFile(copied, []byte("reuse me"), 0644))
+		same, err := newUploadFiles(otherDir, []string{copied})
+		require.NoError(t, err)
+		assert.Equal(t, "task-1", reusableUploadTask("default", same))
+
+		require.NoError(t, os.WriteFile(copied, []byte("changed!"), 0644))
+		assert.Empty(t, reusableUploadTask("default", same), "same path and size, other content")
+	})
+
+	t.Run("objects are only reused at the same path", func(t *testing.T) {
+		moved, err := newUploadFiles(filepath.Dir(contextDir), []string{path})
+		require.NoError(t, err)
+		assert.Empty(t, reusableUploadTask("default", moved))
+	})
+
+	// A task holding a file that is no longer part of the context is not reused
+	assert.Empty(t, reusableUploadTask("default", nil))
+
+	t.Run("a later build replaces the task of its Dockerfile", func(t *testing.T) {
+		other := recordUpload(t, "task-other", files)
+		require.NoError(t, recordUploadTask(other, "default", "/src/other/Dockerfile"))
+
+		next := recordUpload(t, "task-2", files)
+		require.NoError(t, recordUploadTask(next, "default", "/src/app/Dockerfile"))
+		m, err := loadUploadManifest()
+		require.NoError(t, err)
+		assert.NotContains(t, m.Tasks, "task-1")
+		assert.Contains(t, m.Tasks, "task-other")
+		assert.Len(t, m.Objects, 1)
+		assert.Len(t, m.Objects[uploadContentKey(next.Files["model.bin"].MD5, next.Files["model.bin"].CRC64)], 2)
+
+		old, err := loadUploadJournal("task-1")
+		require.NoError(t, err)
+		assert.Empty(t, old.Files, "the journal of the replaced task is removed")
+	})
+}
+
+// rejectingCredentialClient rejects credential requests for an earlier task
+type rejectingCredentialClient struct {
+	mockImageListClient
+	requests []*client.GetDockerFileStoreCredentialRequest
+}
+
+func (m *rejectingCredentialClient) GetDockerFileStoreCredential(ctx context.Context, request *client.GetDockerFileStoreCredentialRequest) (*client.GetDockerFileStoreCredentialResponse, error) {
+	m.requests = append(m.requests, request)
+	if request.TaskId != nil {
+		return nil, errors.New("task not found")
+	}
+	return &client.GetDockerFileStoreCredentialResponse{Body: &client.GetDockerFileStoreCredentialResponseBody{
+		Data: &client.GetDockerFileStoreCredentialResponseBodyData{OssUrl: dara.String("https://oss.example.com/Dockerfile"), TaskId: dara.String("task-new")},
+	}}, nil
+}
+
+func TestGetDockerfileCredential(t *testing.T) {
+	newRequest := func() *client.GetDockerFileStoreCredentialRequest {
+		return &client.GetDockerFileStoreCredentialRequest{FilePath: dara.String("Dockerfile"), TaskId: dara.String("task-old")}
+	}
+
+	m := &rejectingCredentialClient{}
+	resp, err := getDockerfileCredential(context.Background(), m, newRequest(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "task-new", dara.StringValue(resp.Body.Data.TaskId))
+	require.Len(t, m.requests, 2)
+	assert.Nil(t, m.requests[1].TaskId, "a rejected reused task is given up for a new one")
+
+	m = &rejectingCredentialClient{}
+	_, err = getDockerfileCredential(context.Background(), m, newRequest(), true)
+	require.Error(t, err, "a task the user resumes is not given up")
+	assert.Len(t, m.requests, 1)
+}
+
+// reuseBuildClient hands back the task a credential request names, or task-new, and
+// fails the build of any task but task-new
+type reuseBuildClient struct {
+	mockImageListClient
+	ossURL       string
+	fileRequests map[string]int
+	mu           sync.Mutex
+}
+
+func (m *reuseBuildClient) GetDockerFileStoreCredential(ctx context.Context, request *client.GetDockerFileStoreCredentialRequest) (*client.GetDockerFileStoreCredentialResponse, error) {
+	taskId := dara.StringValue(request.TaskId)
+	if taskId == "" {
+		taskId = "task-new"
+	}
+	m.mu.Lock()
+	m.fileRequests[taskId]++
+	m.mu.Unlock()
+	return &client.GetDockerFileStoreCredentialResponse{Body: &client.GetDockerFileStoreCredentialResponseBody{
+		Data: &client.GetDockerFileStoreCredentialResponseBodyData{OssUrl: dara.String(m.ossURL), TaskId: dara.String(taskId)},
+	}}, nil
+}
+
+func (m *reuseBuildClient) CreateDockerImageTask(ctx context.Context, request *client.CreateDockerImageTaskRequest) (*client.CreateDockerImageTaskResponse, error) {
+	return &client.CreateDockerImageTaskResponse{Body: &client.CreateDockerImageTaskResponseBody{
+		Data: &client.CreateDockerImageTaskResponseBodyData{TaskId: request.TaskId},
+	}}, nil
+}
+
+func (m *reuseBuildClient) GetDockerImageTask(ctx context.Context, request *client.GetDockerImageTaskRequest) (*client.GetDockerImageTaskResponse, error) {
+	status := "FAILED"
+	if dara.StringValue(request.TaskId) == "task-new" {
+		status = "SUCCESS"
+	}
+	return &client.GetDockerImageTaskResponse{Body: &client.GetDockerImageTaskResponseBody{
+		Data: &client.GetDockerImageTaskResponseBodyData{Status: dara.String(status), TaskMsg: dara.String("COPY failed: file not found")},
+	}}, nil
+}
+
+func (m *reuseBuildClient) GetDockerImageTaskLog(ctx context.Context, request *client.GetDockerImageTaskLogRequest) (*client.GetDockerImageTaskLogResponse, error) {
+	return nil, errors.New("no log")
+}
+
+func TestUploadAndBuild_ReusedFilesBuildFailure(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	srv, _ := ossStub(t, nil)
+	path := writeUploadFile(t, "reuse me")
+	contextDir := filepath.Dir(path)
+	dockerfilePath := filepath.Join(contextDir, "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfilePath, []byte("COPY model.bin /app/\n"), 0644))
+	files, err := newUploadFiles(contextDir, []string{path})
+	require.NoError(t, err)
+	require.NoError(t, recordUploadTask(recordUpload(t, "task-old", files), config.SelectedProfileName(), dockerfilePath))
+
+	m := &reuseBuildClient{ossURL: srv.URL, fileRequests: map[string]int{}}
+	b := &imageBuild{
+		imageName:      "my-image",
+		sourceImageId:  "code_latest",
+		dockerfilePath: dockerfilePath,
+		uploadContent:  []byte("COPY model.bin /app/\n"),
+		uploadFiles:    files,
+		uploadCfg:      config.UploadConfig{Concurrency: 2},
+	}
+	b.dockerfileContent = b.uploadContent
+
+	err = uploadAndBuild(context.Background(), m, b, false)
+	require.ErrorIs(t, err, errReusedFilesBuildFailed, "the build skipped the file held by task-old")
+	assert.Equal(t, 1, m.fileRequests["task-old"], "only the Dockerfile was uploaded to task-old")
+	assert.Empty(t, reusableUploadTask(config.SelectedProfileName(), files), "task-old is no longer offered")
+
+	require.NoError(t, uploadAndBuild(context.Background(), m, b, true))
+	assert.Equal(t, 2, m.fileRequests["task-new"], "the Dockerfile and the file were uploaded to a new task")
+}
+
+func TestCheckDockerfileUploadName(t *testing.T) {
+	repoDir := t.TempDir()
+	dockerfilePath := filepath.Join(repoDir, "images", "agent.Dockerfile")
+	files := []uploadFile{
+		{absPath: filepath.Join(repoDir, "app.py"), relPath: "app.py"},
+		{absPath: filepath.Join(repoDir, "Dockerfile"), relPath: "Dockerfile"},
+	}
+	assert.ErrorContains(t, checkDockerfileUploadName(dockerfilePath, files), "replace the Dockerfile")
+
+	// Copying the Dockerfile being built into the image is fine
+	assert.NoError(t, checkDockerfileUploadName(filepath.Join(repoDir, "Dockerfile"), files))
+	assert.NoError(t, checkDockerfileUploadName(dockerfilePath, files[:1]))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KB", formatBytes(1536))
+	assert.Equal(t, "3.0 GB", formatBytes(3<<30))
+}
+
+// credentialClient hands out upload URLs, failing for one path. Other requests block
+// until they are cancelled, so the test only passes if the first failure cancels them.
+type credentialClient struct {
+	mockImageListClient
+	failPath string
+	mu       sync.Mutex
+	started  int
+}
+
+func (m *credentialClient) GetDockerFileStoreCredential(ctx context.Context, request *client.GetDockerFileStoreCredentialRequest) (*client.GetDockerFileStoreCredentialResponse, error) {
+	m.mu.Lock()
+	m.started++
+	m.mu.Unlock()
+	path := dara.StringValue(request.FilePath)
+	if path == m.failPath {
+		return nil, errors.New("quota exceeded")
+	}
+	if m.failPath != "" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &client.GetDockerFileStoreCredentialResponse{Body: &client.GetDockerFileStoreCredentialResponseBody{
+		Data: &client.GetDockerFileStoreCredentialResponseBodyData{OssUrl: dara.String("https://oss.example.com/" + path)},
+	}}, nil
+}
+
+func TestRequestUploadCredentials(t *testing.T) {
+	files := []uploadFile{
+		{absPath: "/ctx/a.txt", relPath: "a.txt"},
+		{absPath: "/ctx/b.txt", relPath: "b.txt"},
+		{absPath: "/ctx/c.txt", relPath: "c.txt"},
+	}
+
+	creds, err := requestUploadCredentials(context.Background(), &credentialClient{}, "task-1", files, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "https://oss.example.com/b.txt", creds["/ctx/b.txt"])
+	assert.Len(t, creds, 3)
+
+	m := &credentialClient{failPath: "a.txt"}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err = requestUploadCredentials(context.Background(), m, "task-1", files, 2)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("credential requests were not cancelled after the first failure")
+	}
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a.txt: quota exceeded")
+	assert.Less(t, m.started, 3, "no new requests after the first failure")
+}
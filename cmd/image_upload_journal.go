@@ -0,0 +1,229 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// uploadJournal records which build-context files have been uploaded for a build task,
+// so an interrupted 'image create' can be resumed with --resume without uploading
+// them again. Once the build succeeds, its files are added to the upload manifest.
+// It is stored under the config directory.
+type uploadJournal struct {
+	TaskID string                        `json:"taskId"`
+	Files  map[string]uploadJournalEntry `json:"files"` // keyed by upload path relative to the context
+
+	path string
+	mu   sync.Mutex
+}
+
+// uploadJournalEntry identifies the local file content that was uploaded
+type uploadJournalEntry struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	MD5     string    `json:"md5"`
+	CRC64   uint64    `json:"crc64"`
+}
+
+// uploadFile is a build-context file and its upload path relative to the context
+type uploadFile struct {
+	absPath string
+	relPath string
+	size    int64
+}
+
+// newUploadFiles pairs the files to upload with their paths relative to contextDir and
+// their sizes
+func newUploadFiles(contextDir string, absPaths []string) ([]uploadFile, error) {
+	files := make([]uploadFile, 0, len(absPaths))
+	for _, absPath := range absPaths {
+		relPath, err := RelativePathForUpload(contextDir, absPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relative path for %s: %w", absPath, err)
+		}
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", absPath, err)
+		}
+		files = append(files, uploadFile{absPath: absPath, relPath: relPath, size: info.Size()})
+	}
+	return files, nil
+}
+
+// dockerfileUploadName is the upload path of the Dockerfile, whatever its local name
+const dockerfileUploadName = "Dockerfile"
+
+// checkDockerfileUploadName fails when a build-context file other than the Dockerfile
+// itself would be uploaded as dockerfileUploadName and overwrite the Dockerfile
+func checkDockerfileUploadName(dockerfilePath string, files []uploadFile) error {
+	for _, f := range files {
+		if f.relPath == dockerfileUploadName && f.absPath != dockerfilePath {
+			return fmt.Errorf("ADD/COPY file %s would be uploaded as %s and replace the Dockerfile %s; rename it or use a different build context", f.absPath, dockerfileUploadName, dockerfilePath)
+		}
+	}
+	return nil
+}
+
+// uploadsDir returns the directory holding upload journals and the manifest
+func uploadsDir() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "uploads"), nil
+}
+
+// uploadJournalPath returns where the journal of a build task is stored
+func uploadJournalPath(taskId string) (string, error) {
+	if taskId == "" || strings.ContainsAny(taskId, `/\`) || taskId == "." || taskId == ".." {
+		return "", fmt.Errorf("invalid task ID: %q", taskId)
+	}
+	dir, err := uploadsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, taskId+".json"), nil
+}
+
+// loadUploadJournal reads the journal of a build task, returning an empty journal if
+// there is none
+func loadUploadJournal(taskId string) (*uploadJournal, error) {
+	path, err := uploadJournalPath(taskId)
+	if err != nil {
+		return nil, err
+	}
+	j := &uploadJournal{TaskID: taskId, Files: make(map[string]uploadJournalEntry), path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("failed to read upload journal: %w", err)
+	}
+	if err := json.Unmarshal(data, j); err != nil || j.TaskID != taskId {
+		// A damaged journal only costs a re-upload
+		return &uploadJournal{TaskID: taskId, Files: make(map[string]uploadJournalEntry), path: path}, nil
+	}
+	if j.Files == nil {
+		j.Files = make(map[string]uploadJournalEntry)
+	}
+	return j, nil
+}
+
+// completed reports whether relPath was uploaded with the current content of absPath,
+// and if so its size
+func (j *uploadJournal) completed(relPath, absPath string) (int64, bool) {
+	j.mu.Lock()
+	entry, ok := j.Files[relPath]
+	j.mu.Unlock()
+	if !ok {
+		return 0, false
+	}
+	info, err := os.Stat(absPath)
+	if err != nil || info.Size() != entry.Size {
+		return 0, false
+	}
+	if !info.ModTime().Equal(entry.ModTime) {
+		// Touched but possibly unchanged; the content decides
+		sum, err := fileMD5(absPath)
+		if err != nil || sum != entry.MD5 {
+			return 0, false
+		}
+	}
+	return entry.Size, true
+}
+
+// coveredBy reports whether every file in the journal is still part of files. A task
+// holding files that were since deleted or excluded must not be reused, as a COPY of
+// their directory would bring them back.
+func (j *uploadJournal) coveredBy(files []uploadFile) bool {
+	current := make(map[string]bool, len(files))
+	for _, f := range files {
+		current[f.relPath] = true
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for relPath := range j.Files {
+		if !current[relPath] {
+			return false
+		}
+	}
+	return true
+}
+
+// record marks relPath as uploaded and saves the journal
+func (j *uploadJournal) record(relPath, absPath string, res *ossUploadResult) error {
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.Files[relPath] = uploadJournalEntry{Size: res.Size, ModTime: info.ModTime(), MD5: res.MD5, CRC64: res.CRC64}
+	return j.save()
+}
+
+// save writes the journal atomically; the caller holds j.mu
+func (j *uploadJournal) save() error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// remove deletes the journal
+func (j *uploadJournal) remove() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// fileMD5 returns the lower-case hex MD5 of a file
+func fileMD5(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// formatBytes renders a byte count for progress messages, e.g. "1.5 MB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
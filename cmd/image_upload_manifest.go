@@ -0,0 +1,260 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// uploadManifestFile holds the upload manifest, in the uploads directory
+const uploadManifestFile = "manifest.json"
+
+// uploadManifest records, by file content, the objects held by build tasks whose build
+// succeeded, so a later 'image create' can skip files whose content a task already
+// holds. Objects are stored by the backend per task and path and cannot be referenced
+// by hash from another task, so an object is only reused by uploading into its task
+// again, with the file at the same path.
+//
+// Reuse relies on backend behaviour that the API does not document: a finished task
+// keeps its objects, GetDockerFileStoreCredential hands back the task it is given, and
+// CreateDockerImageTask builds the task again. Objects cannot be checked before the
+// build, as the presigned URLs only allow PUT, so a failed build that skipped files is
+// repeated with all files uploaded to a new task (see uploadAndBuild).
+type uploadManifest struct {
+	Objects map[string][]uploadObject `json:"objects"` // keyed by uploadContentKey
+	Tasks   map[string]uploadTask     `json:"tasks"`   // keyed by task ID
+
+	path string
+}
+
+// uploadObject is a file uploaded for a build task
+type uploadObject struct {
+	TaskID string `json:"taskId"`
+	Path   string `json:"path"` // upload path relative to the build context
+	Size   int64  `json:"size"`
+}
+
+// uploadTask is a build task whose build succeeded. Tasks are only reused within the
+// profile they were built with, since another account cannot use them.
+type uploadTask struct {
+	Profile    string    `json:"profile"`
+	Dockerfile string    `json:"dockerfile"` // absolute path
+	BuiltAt    time.Time `json:"builtAt"`
+}
+
+// uploadContentKey identifies file content by its MD5 (hex) and CRC64 (ECMA)
+func uploadContentKey(md5 string, crc64 uint64) string {
+	return md5 + "-" + strconv.FormatUint(crc64, 16)
+}
+
+// fileContentKey returns the uploadContentKey of a file
+func fileContentKey(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sum := newUploadChecksum()
+	if _, err := io.Copy(sum, f); err != nil {
+		return "", err
+	}
+	res := sum.result()
+	return uploadContentKey(res.MD5, res.CRC64), nil
+}
+
+// loadUploadManifest reads the upload manifest, returning an empty one if there is none
+func loadUploadManifest() (*uploadManifest, error) {
+	dir, err := uploadsDir()
+	if err != nil {
+		return nil, err
+	}
+	m := &uploadManifest{path: filepath.Join(dir, uploadManifestFile)}
+	data, err := os.ReadFile(m.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read upload manifest: %w", err)
+	}
+	if err == nil && json.Unmarshal(data, m) != nil {
+		// A damaged manifest only costs a re-upload
+		m.Objects, m.Tasks = nil, nil
+	}
+	if m.Objects == nil {
+		m.Objects = make(map[string][]uploadObject)
+	}
+	if m.Tasks == nil {
+		m.Tasks = make(map[string]uploadTask)
+	}
+	return m, nil
+}
+
+// save writes the manifest atomically
+func (m *uploadManifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0700); err != nil {
+		return err
+	}
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.path)
+}
+
+// reusableTask looks up the content of files among the tasks of profile and returns
+// the task holding the most bytes of it at the same paths, with the number of those
+// bytes, or "" if no task can be reused for files
+func (m *uploadManifest) reusableTask(profile string, files []uploadFile) (string, int64) {
+	type location struct {
+		path string
+		size int64
+	}
+	type candidate struct {
+		key    string
+		taskId string
+	}
+	candidates := make(map[location][]candidate)
+	for key, objects := range m.Objects {
+		for _, o := range objects {
+			if task, ok := m.Tasks[o.TaskID]; ok && task.Profile == profile {
+				loc := location{path: o.Path, size: o.Size}
+				candidates[loc] = append(candidates[loc], candidate{key: key, taskId: o.TaskID})
+			}
+		}
+	}
+
+	held := make(map[string]int64)
+	for _, f := range files {
+		cands := candidates[location{path: f.relPath, size: f.size}]
+		if len(cands) == 0 {
+			continue
+		}
+		// Only files that some task may hold are hashed
+		key, err := fileContentKey(f.absPath)
+		if err != nil {
+			log.Debugf("[DEBUG] Failed to hash %s: %v", f.absPath, err)
+			continue
+		}
+		for _, c := range cands {
+			if c.key == key {
+				held[c.taskId] += f.size
+			}
+		}
+	}
+
+	taskIds := make([]string, 0, len(held))
+	for taskId := range held {
+		taskIds = append(taskIds, taskId)
+	}
+	sort.Slice(taskIds, func(i, j int) bool {
+		if held[taskIds[i]] != held[taskIds[j]] {
+			return held[taskIds[i]] > held[taskIds[j]]
+		}
+		return m.Tasks[taskIds[i]].BuiltAt.After(m.Tasks[taskIds[j]].BuiltAt)
+	})
+	for _, taskId := range taskIds {
+		journal, err := loadUploadJournal(taskId)
+		if err != nil || len(journal.Files) == 0 {
+			continue
+		}
+		if !journal.coveredBy(files) {
+			log.Debugf("[DEBUG] Task %s holds files that are no longer part of the build context, not reusing it", taskId)
+			continue
+		}
+		return taskId, held[taskId]
+	}
+	return "", 0
+}
+
+// recordTask adds the files of a task whose build succeeded, as listed by its journal.
+// Earlier tasks of the same Dockerfile and profile are dropped with their journals.
+func (m *uploadManifest) recordTask(journal *uploadJournal, profile, dockerfile string) error {
+	taskId := journal.TaskID
+	m.removeTask(taskId)
+	for prev, task := range m.Tasks {
+		if task.Profile == profile && task.Dockerfile == dockerfile {
+			m.removeTask(prev)
+			if old, err := loadUploadJournal(prev); err == nil {
+				old.remove()
+			}
+		}
+	}
+
+	m.Tasks[taskId] = uploadTask{Profile: profile, Dockerfile: dockerfile, BuiltAt: time.Now().UTC()}
+	journal.mu.Lock()
+	for relPath, entry := range journal.Files {
+		key := uploadContentKey(entry.MD5, entry.CRC64)
+		m.Objects[key] = append(m.Objects[key], uploadObject{TaskID: taskId, Path: relPath, Size: entry.Size})
+	}
+	journal.mu.Unlock()
+	return m.save()
+}
+
+// removeTask drops a task and its objects from the manifest
+func (m *uploadManifest) removeTask(taskId string) {
+	delete(m.Tasks, taskId)
+	for key, objects := range m.Objects {
+		kept := objects[:0]
+		for _, o := range objects {
+			if o.TaskID != taskId {
+				kept = append(kept, o)
+			}
+		}
+		if len(kept) == 0 {
+			delete(m.Objects, key)
+		} else {
+			m.Objects[key] = kept
+		}
+	}
+}
+
+// reusableUploadTask returns the recorded task of profile that holds the most of the
+// content of files, or "" if there is none
+func reusableUploadTask(profile string, files []uploadFile) string {
+	m, err := loadUploadManifest()
+	if err != nil {
+		log.Debugf("[DEBUG] Failed to read upload manifest: %v", err)
+		return ""
+	}
+	taskId, held := m.reusableTask(profile, files)
+	if taskId != "" {
+		log.Debugf("[DEBUG] Task %s already holds %s of the build context", taskId, formatBytes(held))
+	}
+	return taskId
+}
+
+// recordUploadTask adds a task whose build succeeded to the upload manifest
+func recordUploadTask(journal *uploadJournal, profile, dockerfile string) error {
+	m, err := loadUploadManifest()
+	if err != nil {
+		return err
+	}
+	return m.recordTask(journal, profile, dockerfile)
+}
+
+// forgetUploadTask drops a task from the upload manifest, with its journal, so that it
+// is not offered again
+func forgetUploadTask(taskId string) {
+	m, err := loadUploadManifest()
+	if err == nil {
+		m.removeTask(taskId)
+		err = m.save()
+	}
+	if err != nil {
+		log.Debugf("[DEBUG] Failed to update upload manifest: %v", err)
+	}
+	if journal, err := loadUploadJournal(taskId); err == nil {
+		journal.remove()
+	}
+}
@@ -4,9 +4,13 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -21,9 +25,41 @@ import (
 // OAuth constants are now defined in constants.go
 
 var LoginCmd = &cobra.Command{
-	Use:     "login",
-	Short:   "Log in to AgentBay",
-	Long:    "Authenticate with AgentBay using OAuth in your browser",
+	Use:   "login",
+	Short: "Log in to AgentBay",
+	Long: `Authenticate with AgentBay using OAuth in your browser.
+
+By default a browser is opened and the result is received on a local callback port.
+Where there is no local browser, or connections to localhost are blocked, use
+--no-callback: the CLI prints the login URL, and you paste back the URL the browser
+is redirected to after logging in.
+
+Alternatively, --device prints a URL and a code to enter in a browser on any other
+device. Its endpoint is not confirmed in the OAuth documentation yet, so it is never
+used automatically.
+
+The login belongs to the active profile; see 'agentbay profile'.
+
+Tokens are kept in config.json by default. Use --credential-store to keep them in the
+system keyring (keyring) or in a file encrypted with the passphrase from
+AGENTBAY_CLI_CREDENTIAL_KEY (encrypted-file). The choice is saved, and existing tokens
+are moved to the new store.
+
+Examples:
+  # Log in with the local browser
+  agentbay login
+
+  # Log in from a remote host using a browser on another device
+  agentbay login --device
+
+  # Log in without the local callback server
+  agentbay login --no-callback
+
+  # Keep the tokens in the system keyring
+  agentbay login --credential-store keyring
+
+  # Log in to another profile (see 'agentbay profile')
+  agentbay login --profile intl`,
 	Args:    cobra.NoArgs,
 	GroupID: "core",
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -31,25 +67,73 @@ var LoginCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	LoginCmd.Flags().Bool("device", false, "Log in with a code entered in a browser on another device")
+	LoginCmd.Flags().Bool("no-callback", false, "Log in without the local callback server by pasting the redirect URL back into the terminal")
+	LoginCmd.MarkFlagsMutuallyExclusive("device", "no-callback")
+	LoginCmd.Flags().String("credential-store", "", "Where to keep the tokens: "+strings.Join(config.CredentialStores, ", ")+" (saved for later commands)")
+}
+
 func runLogin(cmd *cobra.Command) error {
 	fmt.Println("Starting AgentBay authentication...")
 
+	if cmd.Flags().Changed("credential-store") {
+		// Switch before loading the token, as the current store may be unusable
+		cfg, err := config.ReadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		store, _ := cmd.Flags().GetString("credential-store")
+		err = cfg.SetCredentialStore(store)
+		if errors.Is(err, config.ErrCredentialsNotMoved) {
+			fmt.Printf("Warning: %v\n", err)
+		} else if err != nil {
+			return fmt.Errorf("failed to switch credential store: %w", err)
+		}
+		fmt.Printf("Tokens are kept in the %s credential store.\n", cfg.CredentialStore)
+	}
+
 	// Check if already authenticated
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if err := cfg.CredentialStoreError(); err != nil && !cfg.IsAuthenticated() {
+		return fmt.Errorf("credential store unavailable: %w", err)
+	}
+
+	if name := cfg.ProfileName(); name != config.DefaultProfileName {
+		fmt.Printf("Profile: %s\n", name)
+	}
 
 	if cfg.IsAuthenticated() && !cfg.IsTokenExpired() {
 		fmt.Println("You are already logged in to AgentBay!")
 		return nil
 	}
 
+	noCallback, _ := cmd.Flags().GetBool("no-callback")
+	device, _ := cmd.Flags().GetBool("device")
+	if !device && !noCallback && !canOpenBrowser() {
+		// Device login is not picked automatically: its endpoint is not confirmed yet
+		fmt.Println("[TIP] No browser is available on this host. If the login cannot reach the callback server, use 'agentbay login --no-callback' to paste the redirect URL instead.")
+	}
+	if device {
+		return runDeviceLogin(cmd, cfg)
+	}
+
 	// Generate random state for OAuth security
 	state, err := auth.GenerateState()
 	if err != nil {
 		return fmt.Errorf("failed to generate OAuth state: %w", err)
 	}
+	// PKCE binds the authorization code to this process
+	codeVerifier, codeChallenge, err := auth.GeneratePKCE()
+	if err != nil {
+		return err
+	}
+	if noCallback {
+		return runManualLogin(cmd, cfg, state, codeVerifier, codeChallenge)
+	}
 
 	// Try to start callback server on available port
 	var selectedPort string
@@ -58,7 +142,7 @@ func runLogin(cmd *cobra.Command) error {
 	var errChan chan error
 
 	// Create context with timeout for callback server
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 5*time.Minute)
 	defer cancel()
 
 	// Try each port in order
@@ -90,11 +174,11 @@ func runLogin(cmd *cobra.Command) error {
 
 		// Build authorization URL with current port
 		redirectURI := GetRedirectURI(port)
-		authURL = auth.BuildAuthURL(GetClientID(), redirectURI, state)
+		authURL = auth.BuildAuthURL(GetClientID(), redirectURI, state, codeChallenge)
 
 		// Start callback server in background
 		go func(p string) {
-			code, err := auth.StartCallbackServer(ctx, p)
+			code, err := auth.StartCallbackServer(ctx, p, state)
 			if err != nil {
 				errChan <- err
 				return
@@ -166,40 +250,14 @@ func runLogin(cmd *cobra.Command) error {
 		fmt.Println("Exchanging authorization code for access token...")
 
 		redirectURI := GetRedirectURI(selectedPort)
-		tokenResponse, err := auth.ExchangeCodeForToken(GetClientID(), redirectURI, code)
+		tokenResponse, err := auth.ExchangeCodeForToken(GetClientID(), redirectURI, code, codeVerifier)
 		if err != nil {
 			fmt.Printf("Debug: Token exchange failed with error: %v\n", err)
 			return fmt.Errorf("failed to exchange code for token: %w", err)
 		}
 		fmt.Printf("Debug: Token exchange successful, access token length: %d\n", len(tokenResponse.AccessToken))
 
-		// Convert ExpiresIn from string to int
-		expiresIn, err := strconv.Atoi(tokenResponse.ExpiresIn)
-		if err != nil {
-			fmt.Printf("Warning: Invalid expires_in value '%s', using default 3600 seconds\n", tokenResponse.ExpiresIn)
-			expiresIn = 3600
-		}
-
-		// Save tokens to configuration
-		fmt.Println("Saving authentication tokens...")
-
-		err = cfg.SaveTokens(
-			tokenResponse.AccessToken,
-			tokenResponse.TokenType,
-			expiresIn,
-			tokenResponse.RefreshToken,
-			tokenResponse.IDToken,
-		)
-		if err != nil {
-			fmt.Printf("Warning: Failed to save tokens: %v\n", err)
-			fmt.Println("You are logged in, but tokens were not saved to config file.")
-			return nil
-		}
-
-		fmt.Println("Authentication tokens saved successfully!")
-		fmt.Println("You are now logged in to AgentBay!")
-
-		return nil
+		return saveLoginTokens(cfg, tokenResponse)
 	case err := <-errChan:
 		// Check if error is related to port occupancy
 		errStr := err.Error()
@@ -211,12 +269,111 @@ func runLogin(cmd *cobra.Command) error {
 			fmt.Fprintf(os.Stderr, "  - Windows: netstat -ano | findstr :%s\n", selectedPort)
 			return fmt.Errorf("port %s is occupied", selectedPort)
 		}
-		return fmt.Errorf("authentication failed: %v", err)
+		return fmt.Errorf("authentication failed: %w", err)
 	case <-ctx.Done():
 		return fmt.Errorf("authentication timeout: please try again")
 	}
 }
 
+// runDeviceLogin logs in with the OAuth device authorization grant (RFC 8628): the user
+// enters a code at a URL in a browser on any device while the CLI polls for the token
+func runDeviceLogin(cmd *cobra.Command, cfg *config.Config) error {
+	ctx := commandContext(cmd)
+
+	da, err := auth.RequestDeviceAuthorization(ctx, GetClientID())
+	if err != nil {
+		return fmt.Errorf("failed to start device login: %w", err)
+	}
+
+	fmt.Println("To log in, open this URL in a browser on any device:")
+	fmt.Printf("  %s\n", da.VerificationURI)
+	fmt.Printf("and enter the code: %s\n\n", da.UserCode)
+	if da.VerificationURIComplete != "" {
+		fmt.Printf("Or open this URL, which already contains the code:\n  %s\n\n", da.VerificationURIComplete)
+	}
+	fmt.Println("Waiting for authorization...")
+
+	tokenResponse, err := auth.PollDeviceToken(ctx, GetClientID(), da)
+	switch {
+	case errors.Is(err, auth.ErrDeviceCodeExpired):
+		return fmt.Errorf("authentication timeout: the code expired, please run 'agentbay login --device' again")
+	case err != nil:
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	fmt.Println("Authentication successful!")
+	return saveLoginTokens(cfg, tokenResponse)
+}
+
+// runManualLogin logs in without the callback server: the user opens the login URL and
+// pastes back the URL the browser was redirected to, which does not need to load
+func runManualLogin(cmd *cobra.Command, cfg *config.Config, state, codeVerifier, codeChallenge string) error {
+	redirectURI := GetRedirectURI(DefaultCallbackPort)
+	authURL := auth.BuildAuthURL(GetClientID(), redirectURI, state, codeChallenge)
+
+	fmt.Printf("Open this URL in your browser and log in:\n\n%s\n\n", authURL)
+	fmt.Printf("After logging in, the browser is redirected to %s, which will fail to load.\n", redirectURI)
+	fmt.Println("Copy the full URL from the address bar and paste it here:")
+	fmt.Print("> ")
+
+	input, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && (err != io.EOF || input == "") {
+		return fmt.Errorf("failed to read the redirect URL: %w", err)
+	}
+	code, err := auth.ParseCallbackInput(input, state)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Exchanging authorization code for access token...")
+	tokenResponse, err := auth.ExchangeCodeForToken(GetClientID(), redirectURI, code, codeVerifier)
+	if err != nil {
+		return fmt.Errorf("failed to exchange code for token: %w", err)
+	}
+	fmt.Println("Authentication successful!")
+	return saveLoginTokens(cfg, tokenResponse)
+}
+
+// saveLoginTokens stores the tokens of a completed login
+func saveLoginTokens(cfg *config.Config, tokenResponse *auth.TokenResponse) error {
+	// Convert ExpiresIn from string to int
+	expiresIn, err := strconv.Atoi(tokenResponse.ExpiresIn)
+	if err != nil {
+		fmt.Printf("Warning: Invalid expires_in value '%s', using default 3600 seconds\n", tokenResponse.ExpiresIn)
+		expiresIn = 3600
+	}
+
+	// Save tokens to configuration
+	fmt.Println("Saving authentication tokens...")
+
+	err = cfg.SaveTokens(
+		tokenResponse.AccessToken,
+		tokenResponse.TokenType,
+		expiresIn,
+		tokenResponse.RefreshToken,
+		tokenResponse.IDToken,
+	)
+	if err != nil {
+		fmt.Printf("Warning: Failed to save tokens: %v\n", err)
+		fmt.Println("You are logged in, but tokens were not saved to config file.")
+		return nil
+	}
+
+	fmt.Println("Authentication tokens saved successfully!")
+	fmt.Println("You are now logged in to AgentBay!")
+	return nil
+}
+
+// canOpenBrowser reports whether a browser can be opened on this host. On Linux and other
+// Unix systems a browser needs a graphical session, which SSH sessions and
+// containers usually lack.
+func canOpenBrowser() bool {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return true
+	}
+	return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
 // min returns the minimum of two integers
 func min(a, b int) int {
 	if a < b {
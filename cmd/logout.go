@@ -15,7 +15,7 @@ import (
 var LogoutCmd = &cobra.Command{
 	Use:     "logout",
 	Short:   "Log out from AgentBay",
-	Long:    "Log out from AgentBay by invalidating server session and clearing local authentication data",
+	Long:    "Log out from AgentBay by invalidating server session and clearing local authentication data of the active profile",
 	Args:    cobra.NoArgs,
 	GroupID: "core",
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -32,6 +32,10 @@ func runLogout(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	if name := cfg.ProfileName(); name != config.DefaultProfileName {
+		fmt.Printf("Profile: %s\n", name)
+	}
+
 	// Check if we have valid tokens for API logout
 	hasValidTokens := cfg.IsAuthenticated()
 
@@ -69,6 +73,9 @@ func runLogout(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("failed to clear local authentication data: %w", err)
 	}
+	if err := cfg.CredentialStoreError(); err != nil {
+		fmt.Printf("Warning: Tokens kept in the credential store were not removed: %v\n", err)
+	}
 
 	// Success message
 	if hasValidTokens {
@@ -0,0 +1,294 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat selects how command results are written to stdout
+type OutputFormat string
+
+const (
+	// OutputTable is the default human-readable output
+	OutputTable OutputFormat = "table"
+	// OutputJSON writes a single JSON document per command
+	OutputJSON OutputFormat = "json"
+	// OutputYAML writes a single YAML document per command
+	OutputYAML OutputFormat = "yaml"
+)
+
+// outputFormat is set once per invocation from the root --output flag
+var outputFormat = OutputTable
+
+// SetOutputFormat validates and applies the value of the global --output flag
+func SetOutputFormat(value string) error {
+	switch OutputFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", OutputTable, "text":
+		outputFormat = OutputTable
+	case OutputJSON:
+		outputFormat = OutputJSON
+	case OutputYAML, "yml":
+		outputFormat = OutputYAML
+	default:
+		return fmt.Errorf("invalid --output value %q (supported: table, json, yaml)", value)
+	}
+	return nil
+}
+
+// GetOutputFormat returns the output format selected for this invocation
+func GetOutputFormat() OutputFormat {
+	return outputFormat
+}
+
+// isStructuredOutput reports whether results are rendered as JSON or YAML
+func isStructuredOutput() bool {
+	return outputFormat == OutputJSON || outputFormat == OutputYAML
+}
+
+// progressOut returns the writer for progress chatter ("[STEP 1/4]", "Done." ...).
+// In table mode this is stdout as before; with --output json|yaml it is stderr so
+// stdout carries only the structured result.
+func progressOut() io.Writer {
+	if isStructuredOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// renderOutput writes result to stdout in the selected structured format, or calls
+// renderTable for the default human-readable output.
+func renderOutput(result interface{}, renderTable func()) error {
+	return renderOutputTo(os.Stdout, result, renderTable)
+}
+
+// renderOutputTo is renderOutput with an explicit destination (used by tests)
+func renderOutputTo(w io.Writer, result interface{}, renderTable func()) error {
+	switch outputFormat {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return nil
+	case OutputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode YAML output: %w", err)
+		}
+		return enc.Close()
+	default:
+		if renderTable != nil {
+			renderTable()
+		}
+		return nil
+	}
+}
+
+// The types below are the stable, documented result objects emitted with
+// --output json|yaml. Field names are part of the CLI contract; only add fields.
+
+// versionOutput is the result of 'agentbay version'
+type versionOutput struct {
+	Version     string `json:"version" yaml:"version"`
+	GitCommit   string `json:"gitCommit" yaml:"gitCommit"`
+	BuildDate   string `json:"buildDate" yaml:"buildDate"`
+	Profile     string `json:"profile" yaml:"profile"`
+	Environment string `json:"environment" yaml:"environment"`
+	Endpoint    string `json:"endpoint" yaml:"endpoint"`
+}
+
+// profileOutput describes one profile in 'agentbay profile list'
+type profileOutput struct {
+	Name        string `json:"name" yaml:"name"`
+	Active      bool   `json:"active" yaml:"active"`
+	Environment string `json:"environment" yaml:"environment"`
+	Endpoint    string `json:"endpoint" yaml:"endpoint"`
+	ClientID    string `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	Region      string `json:"region,omitempty" yaml:"region,omitempty"`
+}
+
+// profileListOutput is the result of 'agentbay profile list'
+type profileListOutput struct {
+	Profiles []profileOutput `json:"profiles" yaml:"profiles"`
+}
+
+// imageOutput describes one image in 'agentbay image list'
+type imageOutput struct {
+	ImageID        string `json:"imageId" yaml:"imageId"`
+	ImageName      string `json:"imageName" yaml:"imageName"`
+	ImageType      string `json:"imageType" yaml:"imageType"`
+	ResourceStatus string `json:"resourceStatus" yaml:"resourceStatus"`
+	Status         string `json:"status" yaml:"status"`
+	OsName         string `json:"osName,omitempty" yaml:"osName,omitempty"`
+	OsVersion      string `json:"osVersion,omitempty" yaml:"osVersion,omitempty"`
+	ApplyScene     string `json:"applyScene,omitempty" yaml:"applyScene,omitempty"`
+}
+
+// imageListOutput is the result of 'agentbay image list'
+type imageListOutput struct {
+	Images     []imageOutput `json:"images" yaml:"images"`
+	TotalCount int32         `json:"totalCount" yaml:"totalCount"`
+	PageStart  int32         `json:"pageStart,omitempty" yaml:"pageStart,omitempty"`
+	PageSize   int32         `json:"pageSize,omitempty" yaml:"pageSize,omitempty"`
+}
+
+// imageDetailOutput is the result of 'agentbay image show'
+type imageDetailOutput struct {
+	ImageID        string                    `json:"imageId" yaml:"imageId"`
+	ImageName      string                    `json:"imageName" yaml:"imageName"`
+	ImageType      string                    `json:"imageType" yaml:"imageType"`
+	BuildType      string                    `json:"buildType,omitempty" yaml:"buildType,omitempty"`
+	ResourceStatus string                    `json:"resourceStatus" yaml:"resourceStatus"`
+	Status         string                    `json:"status" yaml:"status"`
+	ApplyScene     string                    `json:"applyScene,omitempty" yaml:"applyScene,omitempty"`
+	Description    string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	OS             *imageOSOutput            `json:"os,omitempty" yaml:"os,omitempty"`
+	Build          *imageBuildOutput         `json:"build,omitempty" yaml:"build,omitempty"`
+	ResourceGroup  *imageResourceGroupOutput `json:"resourceGroup,omitempty" yaml:"resourceGroup,omitempty"`
+}
+
+// imageOSOutput is the platform and disk information in 'agentbay image show'
+type imageOSOutput struct {
+	OsName         string `json:"osName,omitempty" yaml:"osName,omitempty"`
+	OsVersion      string `json:"osVersion,omitempty" yaml:"osVersion,omitempty"`
+	PlatformName   string `json:"platformName,omitempty" yaml:"platformName,omitempty"`
+	SystemDiskSize int32  `json:"systemDiskSize,omitempty" yaml:"systemDiskSize,omitempty"`
+	DataDiskSize   int32  `json:"dataDiskSize,omitempty" yaml:"dataDiskSize,omitempty"`
+	UpdateTime     string `json:"updateTime,omitempty" yaml:"updateTime,omitempty"`
+}
+
+// imageBuildOutput is the build information in 'agentbay image show'
+type imageBuildOutput struct {
+	TaskID        string `json:"taskId,omitempty" yaml:"taskId,omitempty"`
+	VersionID     string `json:"versionId,omitempty" yaml:"versionId,omitempty"`
+	ApiKeyID      string `json:"apiKeyId,omitempty" yaml:"apiKeyId,omitempty"`
+	InstanceReady bool   `json:"instanceReady" yaml:"instanceReady"`
+}
+
+// imageResourceGroupOutput is the resource group of an activated image in 'agentbay image show'
+type imageResourceGroupOutput struct {
+	ResourceGroupID  string `json:"resourceGroupId,omitempty" yaml:"resourceGroupId,omitempty"`
+	Status           string `json:"status,omitempty" yaml:"status,omitempty"`
+	RegionID         string `json:"regionId,omitempty" yaml:"regionId,omitempty"`
+	VpcID            string `json:"vpcId,omitempty" yaml:"vpcId,omitempty"`
+	VSwitchID        string `json:"vSwitchId,omitempty" yaml:"vSwitchId,omitempty"`
+	PolicyID         string `json:"policyId,omitempty" yaml:"policyId,omitempty"`
+	SessionBandwidth int32  `json:"sessionBandwidth,omitempty" yaml:"sessionBandwidth,omitempty"`
+}
+
+// imageCreateOutput is the result of 'agentbay image create'
+type imageCreateOutput struct {
+	ImageName     string `json:"imageName" yaml:"imageName"`
+	SourceImageID string `json:"sourceImageId" yaml:"sourceImageId"`
+	TaskID        string `json:"taskId" yaml:"taskId"`
+	ImageID       string `json:"imageId,omitempty" yaml:"imageId,omitempty"`
+	Status        string `json:"status" yaml:"status"`
+	ReusedFiles   int    `json:"reusedFiles,omitempty" yaml:"reusedFiles,omitempty"`
+	BytesSaved    int64  `json:"bytesSaved,omitempty" yaml:"bytesSaved,omitempty"`
+}
+
+// imageCreatePlanOutput is the result of 'agentbay image create --dry-run'
+type imageCreatePlanOutput struct {
+	ImageName     string           `json:"imageName" yaml:"imageName"`
+	SourceImageID string           `json:"sourceImageId" yaml:"sourceImageId"`
+	Dockerfile    string           `json:"dockerfile" yaml:"dockerfile"`
+	ContextDir    string           `json:"contextDir" yaml:"contextDir"`
+	Files         []uploadPlanFile `json:"files" yaml:"files"`
+	TotalFiles    int              `json:"totalFiles" yaml:"totalFiles"`
+	TotalBytes    int64            `json:"totalBytes" yaml:"totalBytes"`
+	ExcludedFiles []string         `json:"excludedFiles,omitempty" yaml:"excludedFiles,omitempty"`
+	Warnings      []string         `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// uploadPlanFile is one file 'agentbay image create' would upload, by upload path
+type uploadPlanFile struct {
+	Path string `json:"path" yaml:"path"`
+	Size int64  `json:"size" yaml:"size"`
+}
+
+// buildStatusOutput is the result of 'agentbay image build-status' and 'agentbay image wait'
+type buildStatusOutput struct {
+	TaskID  string `json:"taskId" yaml:"taskId"`
+	Status  string `json:"status" yaml:"status"`
+	ImageID string `json:"imageId,omitempty" yaml:"imageId,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	requestID string // for debugging output only
+}
+
+// buildLogOutput is the result of 'agentbay image logs'
+type buildLogOutput struct {
+	TaskID string   `json:"taskId" yaml:"taskId"`
+	Lines  []string `json:"lines" yaml:"lines"`
+}
+
+// imageStateOutput is the result of 'agentbay image activate' and 'agentbay image deactivate'
+type imageStateOutput struct {
+	ImageID        string `json:"imageId" yaml:"imageId"`
+	ImageType      string `json:"imageType" yaml:"imageType"`
+	ResourceStatus string `json:"resourceStatus" yaml:"resourceStatus"`
+	Status         string `json:"status" yaml:"status"`
+	Changed        bool   `json:"changed" yaml:"changed"`
+	CPU            int    `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory         int    `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// lintDiagnostic is a problem found by 'agentbay image lint'. Line is 0 for problems
+// that concern the whole file.
+type lintDiagnostic struct {
+	File     string `json:"file" yaml:"file"`
+	Line     int    `json:"line" yaml:"line"`
+	Severity string `json:"severity" yaml:"severity"`
+	Rule     string `json:"rule" yaml:"rule"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+// imageLintOutput is the result of 'agentbay image lint'. TotalFiles and TotalBytes
+// count the ADD/COPY files that would be uploaded.
+type imageLintOutput struct {
+	Dockerfile  string           `json:"dockerfile" yaml:"dockerfile"`
+	ContextDir  string           `json:"contextDir" yaml:"contextDir"`
+	Diagnostics []lintDiagnostic `json:"diagnostics" yaml:"diagnostics"`
+	Errors      int              `json:"errors" yaml:"errors"`
+	Warnings    int              `json:"warnings" yaml:"warnings"`
+	TotalFiles  int              `json:"totalFiles" yaml:"totalFiles"`
+	TotalBytes  int64            `json:"totalBytes" yaml:"totalBytes"`
+}
+
+// imageTemplateListOutput is the result of 'agentbay image templates'
+type imageTemplateListOutput struct {
+	Templates  []imageOutput `json:"templates" yaml:"templates"`
+	TotalCount int           `json:"totalCount" yaml:"totalCount"`
+}
+
+// imageInitOutput is the result of 'agentbay image init'
+type imageInitOutput struct {
+	SourceImageID  string `json:"sourceImageId" yaml:"sourceImageId"`
+	DockerfilePath string `json:"dockerfilePath" yaml:"dockerfilePath"`
+	NonEditLineNum int32  `json:"nonEditLineNum" yaml:"nonEditLineNum"`
+	LockfilePath   string `json:"lockfilePath" yaml:"lockfilePath"`
+}
+
+// skillPushOutput is the result of 'agentbay skills push'
+type skillPushOutput struct {
+	SkillID     string `json:"skillId" yaml:"skillId"`
+	OssBucket   string `json:"ossBucket" yaml:"ossBucket"`
+	OssFilePath string `json:"ossFilePath" yaml:"ossFilePath"`
+}
+
+// skillShowOutput is the result of 'agentbay skills show'
+type skillShowOutput struct {
+	SkillID     string `json:"skillId" yaml:"skillId"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestSetOutputFormat(t *testing.T) {
+	defer func() { _ = SetOutputFormat("table") }()
+
+	tests := []struct {
+		value   string
+		want    OutputFormat
+		wantErr bool
+	}{
+		{value: "", want: OutputTable},
+		{value: "table", want: OutputTable},
+		{value: "JSON", want: OutputJSON},
+		{value: "yaml", want: OutputYAML},
+		{value: "yml", want: OutputYAML},
+		{value: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			err := SetOutputFormat(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid --output value")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, GetOutputFormat())
+		})
+	}
+}
+
+func TestRenderOutput(t *testing.T) {
+	defer func() { _ = SetOutputFormat("table") }()
+
+	result := versionOutput{Version: "1.2.3", GitCommit: "abc", BuildDate: "today", Environment: "production", Endpoint: "example.com"}
+
+	t.Run("table mode calls the table renderer", func(t *testing.T) {
+		require.NoError(t, SetOutputFormat("table"))
+		var buf bytes.Buffer
+		called := false
+		require.NoError(t, renderOutputTo(&buf, result, func() { called = true }))
+		assert.True(t, called)
+		assert.Empty(t, buf.String())
+		assert.Equal(t, os.Stdout, progressOut())
+	})
+
+	t.Run("json mode writes stable field names", func(t *testing.T) {
+		require.NoError(t, SetOutputFormat("json"))
+		var buf bytes.Buffer
+		require.NoError(t, renderOutputTo(&buf, result, func() { t.Fatal("table renderer must not run") }))
+		var decoded map[string]string
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, "1.2.3", decoded["version"])
+		assert.Equal(t, "abc", decoded["gitCommit"])
+		assert.Equal(t, os.Stderr, progressOut())
+	})
+
+	t.Run("yaml mode writes stable field names", func(t *testing.T) {
+		require.NoError(t, SetOutputFormat("yaml"))
+		var buf bytes.Buffer
+		require.NoError(t, renderOutputTo(&buf, result, nil))
+		var decoded map[string]string
+		require.NoError(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, "example.com", decoded["endpoint"])
+	})
+}
+
+func TestNewImageListOutput(t *testing.T) {
+	images := []*client.ListMcpImagesResponseBodyData{
+		createMockImage("imgc-1234567890", "my-image", "User", "RESOURCE_PUBLISHED"),
+		nil,
+	}
+	pageStart, pageSize := int32(2), int32(5)
+	out := newImageListOutput(images, 7, &client.ListMcpImagesResponseBody{PageStart: &pageStart, PageSize: &pageSize})
+
+	require.Len(t, out.Images, 1)
+	assert.Equal(t, int32(7), out.TotalCount)
+	assert.Equal(t, int32(2), out.PageStart)
+	assert.Equal(t, int32(5), out.PageSize)
+	assert.Equal(t, "imgc-1234567890", out.Images[0].ImageID)
+	assert.Equal(t, "RESOURCE_PUBLISHED", out.Images[0].ResourceStatus)
+	assert.Equal(t, "Activated", out.Images[0].Status)
+	assert.Equal(t, "Linux", out.Images[0].OsName)
+
+	empty := newImageListOutput(nil, 0, nil)
+	data, err := json.Marshal(empty)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"images":[]`, "empty list must encode as [] not null")
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+var ProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named profiles",
+	Long: `Manage named profiles for several accounts and environments.
+
+Each profile has its own environment, API endpoint, OAuth client ID, OAuth region and
+login. Select a profile for one command with --profile or AGENTBAY_PROFILE, or for
+all later commands with 'agentbay profile use'. The "default" profile is used when
+none is selected. Environment variables such as AGENTBAY_ENV and AGENTBAY_CLI_ENDPOINT
+take precedence over the settings of the profile.
+
+Examples:
+  # Add a profile for the international site and log in to it
+  agentbay profile add intl --environment international
+  agentbay login --profile intl
+
+  # Make it the current profile
+  agentbay profile use intl`,
+	GroupID: "core",
+}
+
+var profileListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List profiles",
+	Long:    "List the profiles and their settings. The active profile is marked with *.",
+	Args:    cobra.NoArgs,
+	RunE:    runProfileList,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the current profile",
+	Long:  "Set the profile used by later commands when --profile and AGENTBAY_PROFILE are not set.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileUse,
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a profile",
+	Long: `Add a profile. Settings that are not given use the defaults of the profile's
+environment. Log in to the new profile with 'agentbay login --profile <name>'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileAdd,
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a profile",
+	Long:    "Remove a profile and its stored login. The default profile and the profile in use cannot be removed.",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runProfileRemove,
+}
+
+func init() {
+	profileAddCmd.Flags().String("environment", "", "Environment, as accepted by AGENTBAY_ENV (default production)")
+	profileAddCmd.Flags().String("endpoint", "", "API endpoint (default: the endpoint of the environment)")
+	profileAddCmd.Flags().String("client-id", "", "OAuth client ID (default: the client ID of the environment)")
+	profileAddCmd.Flags().String("region", "", "OAuth region: domestic or international (default: from the environment)")
+	profileAddCmd.Flags().Bool("use", false, "Make the new profile the current profile")
+
+	ProfileCmd.AddCommand(profileListCmd)
+	ProfileCmd.AddCommand(profileUseCmd)
+	ProfileCmd.AddCommand(profileAddCmd)
+	ProfileCmd.AddCommand(profileRemoveCmd)
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	result := newProfileListOutput(cfg)
+	return renderOutput(result, func() { printProfiles(result) })
+}
+
+// newProfileListOutput describes the profiles with the effective environment and
+// endpoint of each
+func newProfileListOutput(cfg *config.Config) profileListOutput {
+	result := profileListOutput{Profiles: []profileOutput{}}
+	for _, name := range cfg.ProfileNames() {
+		p, _ := cfg.GetProfile(name)
+		env, _ := config.ParseEnvironment(p.Environment)
+		endpoint := p.Endpoint
+		if endpoint == "" {
+			endpoint = config.EnvironmentConfigFor(env).Endpoint
+		}
+		result.Profiles = append(result.Profiles, profileOutput{
+			Name:        name,
+			Active:      name == cfg.ProfileName(),
+			Environment: string(env),
+			Endpoint:    endpoint,
+			ClientID:    p.ClientID,
+			Region:      p.Region,
+		})
+	}
+	return result
+}
+
+// printProfiles prints the profiles as a table
+func printProfiles(result profileListOutput) {
+	fmt.Printf("  %s %s %s\n", padString("NAME", 20), padString("ENVIRONMENT", 20), "ENDPOINT")
+	fmt.Printf("  %s %s %s\n", padString("----", 20), padString("-----------", 20), "--------")
+	for _, p := range result.Profiles {
+		marker := " "
+		if p.Active {
+			marker = "*"
+		}
+		fmt.Printf("%s %s %s %s\n", marker, padString(truncateString(p.Name, 20), 20), padString(p.Environment, 20), p.Endpoint)
+	}
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := cfg.UseProfile(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to profile %s\n", args[0])
+	if env := os.Getenv("AGENTBAY_PROFILE"); env != "" && env != args[0] {
+		fmt.Fprintf(os.Stderr, "[WARN] AGENTBAY_PROFILE=%s is set and takes precedence in this shell\n", env)
+	}
+	return nil
+}
+
+func runProfileAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	var p config.Profile
+	p.Environment, _ = cmd.Flags().GetString("environment")
+	p.Endpoint, _ = cmd.Flags().GetString("endpoint")
+	p.ClientID, _ = cmd.Flags().GetString("client-id")
+	p.Region, _ = cmd.Flags().GetString("region")
+	if err := cfg.AddProfile(args[0], p); err != nil {
+		return err
+	}
+	fmt.Printf("Added profile %s\n", args[0])
+
+	if use, _ := cmd.Flags().GetBool("use"); use {
+		if err := cfg.UseProfile(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Switched to profile %s\n", args[0])
+	}
+	fmt.Printf("[TIP] Log in with: agentbay login --profile %s\n", args[0])
+	return nil
+}
+
+func runProfileRemove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := cfg.RemoveProfile(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed profile %s\n", args[0])
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+func TestNewProfileListOutput(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	t.Setenv("AGENTBAY_CLI_CREDENTIAL_STORE", "")
+	t.Setenv("AGENTBAY_PROFILE", "staging")
+
+	_, err := config.GetConfig()
+	assert.ErrorIs(t, err, config.ErrProfileNotFound, "a missing profile cannot be selected")
+
+	t.Setenv("AGENTBAY_PROFILE", "")
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.AddProfile("staging", config.Profile{Environment: "pre", Endpoint: "staging.example.com"}))
+	require.NoError(t, cfg.AddProfile("intl", config.Profile{Environment: "intl", Region: "international"}))
+
+	t.Setenv("AGENTBAY_PROFILE", "staging")
+	cfg, err = config.GetConfig()
+	require.NoError(t, err)
+
+	result := newProfileListOutput(cfg)
+	assert.Equal(t, []profileOutput{
+		{Name: "default", Environment: "production", Endpoint: config.EnvironmentConfigFor(config.EnvProduction).Endpoint},
+		{Name: "intl", Environment: "international", Endpoint: config.EnvironmentConfigFor(config.EnvInternationalProduction).Endpoint, Region: "international"},
+		{Name: "staging", Active: true, Environment: "prerelease", Endpoint: "staging.example.com"},
+	}, result.Profiles)
+}
@@ -0,0 +1,376 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// progressRedrawInterval is how often live progress is redrawn on a terminal
+	progressRedrawInterval = 250 * time.Millisecond
+	// transferLogInterval is how often upload progress is logged when not on a terminal
+	transferLogInterval = 10 * time.Second
+	// statusLogInterval is how often an unchanged status is logged when not on a terminal
+	statusLogInterval = 30 * time.Second
+	// maxProgressFileLines caps the per-file lines shown under the upload total
+	maxProgressFileLines = 5
+)
+
+// isTerminal reports whether w is an interactive terminal that can redraw lines in place
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth returns the width live lines are cut to so they never wrap
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 20 {
+		return n
+	}
+	return 80
+}
+
+// progressDisplay draws a block of progress lines. On a terminal the block is redrawn
+// in place; otherwise it is logged as plain lines every logInterval.
+type progressDisplay struct {
+	w           io.Writer
+	tty         bool
+	logInterval time.Duration
+	render      func() []string
+
+	mu      sync.Mutex
+	drawn   int // lines of the live block currently on screen
+	lastLog time.Time
+	stopped bool
+	stopCh  chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newProgressDisplay(w io.Writer, logInterval time.Duration, render func() []string) *progressDisplay {
+	return &progressDisplay{
+		w:           w,
+		tty:         isTerminal(w),
+		logInterval: logInterval,
+		render:      render,
+		lastLog:     time.Now(),
+		stopCh:      make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// start draws the block and keeps it up to date until stop is called
+func (d *progressDisplay) start() {
+	interval := d.logInterval
+	if d.tty {
+		interval = progressRedrawInterval
+		d.mu.Lock()
+		d.draw()
+		d.mu.Unlock()
+	}
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.stopCh:
+				return
+			case <-ticker.C:
+				d.mu.Lock()
+				if d.tty {
+					d.draw()
+				} else if time.Since(d.lastLog) >= d.logInterval {
+					d.logLines(d.render())
+				}
+				d.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// log prints the block as plain lines right away; a no-op on a terminal
+func (d *progressDisplay) log() {
+	if d.tty {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.logLines(d.render())
+}
+
+// printf prints a message above the live block
+func (d *progressDisplay) printf(format string, args ...interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.clear()
+	fmt.Fprintf(d.w, format, args...)
+	if d.tty && !d.stopped {
+		d.draw()
+	}
+}
+
+// stop ends live updates and leaves final on screen in place of the block. Only the
+// first call has an effect.
+func (d *progressDisplay) stop(final []string) {
+	d.once.Do(func() {
+		close(d.stopCh)
+		<-d.done
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.clear()
+		d.stopped = true
+		d.logLines(final)
+	})
+}
+
+// draw replaces the live block with the current lines; the caller holds d.mu
+func (d *progressDisplay) draw() {
+	lines := d.render()
+	width := terminalWidth() - 1
+	d.clear()
+	for i, line := range lines {
+		if r := []rune(line); len(r) > width {
+			line = string(r[:width])
+		}
+		if i > 0 {
+			io.WriteString(d.w, "\n")
+		}
+		io.WriteString(d.w, line)
+	}
+	d.drawn = len(lines)
+}
+
+// clear erases the live block, leaving the cursor at the start of its first line
+func (d *progressDisplay) clear() {
+	if !d.tty || d.drawn == 0 {
+		return
+	}
+	io.WriteString(d.w, "\r\033[K")
+	for i := 1; i < d.drawn; i++ {
+		io.WriteString(d.w, "\033[1A\033[K")
+	}
+	d.drawn = 0
+}
+
+// logLines writes lines below whatever was printed last; the caller holds d.mu
+func (d *progressDisplay) logLines(lines []string) {
+	for _, line := range lines {
+		fmt.Fprintln(d.w, line)
+	}
+	d.lastLog = time.Now()
+}
+
+// transferProgress tracks the bytes sent for a set of files
+type transferProgress struct {
+	display    *progressDisplay
+	label      string
+	totalFiles int
+	totalBytes int64
+	start      time.Time
+
+	sent      atomic.Int64
+	mu        sync.Mutex
+	doneFiles int
+	active    []*fileProgress
+}
+
+// fileProgress counts the bytes sent for one file. A nil *fileProgress ignores all
+// calls, so uploads can be made without progress reporting.
+type fileProgress struct {
+	parent *transferProgress
+	name   string
+	size   int64
+	sent   atomic.Int64
+}
+
+// newTransferProgress starts reporting progress of sending totalFiles files of
+// totalBytes bytes in total to w. label is e.g. "Uploading".
+func newTransferProgress(w io.Writer, label string, totalFiles int, totalBytes int64) *transferProgress {
+	p := &transferProgress{label: label, totalFiles: totalFiles, totalBytes: totalBytes, start: time.Now()}
+	p.display = newProgressDisplay(w, transferLogInterval, p.lines)
+	p.display.start()
+	return p
+}
+
+// file registers a file about to be sent
+func (p *transferProgress) file(name string, size int64) *fileProgress {
+	f := &fileProgress{parent: p, name: name, size: size}
+	p.mu.Lock()
+	p.active = append(p.active, f)
+	p.mu.Unlock()
+	return f
+}
+
+// printf prints a message above the progress
+func (p *transferProgress) printf(format string, args ...interface{}) {
+	p.display.printf(format, args...)
+}
+
+// finish stops reporting and prints a summary
+func (p *transferProgress) finish() {
+	p.display.stop([]string{p.summary()})
+}
+
+// abort stops reporting without a summary, e.g. before printing an error
+func (p *transferProgress) abort() {
+	p.display.stop(nil)
+}
+
+func (f *fileProgress) Write(b []byte) (int, error) {
+	if f != nil {
+		f.sent.Add(int64(len(b)))
+		f.parent.sent.Add(int64(len(b)))
+	}
+	return len(b), nil
+}
+
+// reset discards the bytes counted so far, when a file is sent again after a failure
+func (f *fileProgress) reset() {
+	if f == nil {
+		return
+	}
+	f.parent.sent.Add(-f.sent.Swap(0))
+}
+
+// done marks the file as completely sent
+func (f *fileProgress) done() {
+	if f == nil {
+		return
+	}
+	f.parent.sent.Add(f.size - f.sent.Swap(f.size))
+	p := f.parent
+	p.mu.Lock()
+	p.doneFiles++
+	for i, a := range p.active {
+		if a == f {
+			p.active = append(p.active[:i], p.active[i+1:]...)
+			break
+		}
+	}
+	p.mu.Unlock()
+}
+
+// lines renders the total, followed on a terminal by the files being sent
+func (p *transferProgress) lines() []string {
+	sent := p.sent.Load()
+	elapsed := time.Since(p.start)
+	rate := transferRate(sent, elapsed)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	line := fmt.Sprintf("%s: %d/%d files, %s / %s", p.label, p.doneFiles, p.totalFiles, formatBytes(sent), formatBytes(p.totalBytes))
+	if p.totalBytes > 0 {
+		line += fmt.Sprintf(" (%d%%)", sent*100/p.totalBytes)
+	}
+	line += fmt.Sprintf(", %s/s, ETA %s", formatBytes(int64(rate)), formatETA(p.totalBytes-sent, rate))
+	lines := []string{line}
+	if !p.display.tty {
+		return lines
+	}
+	for i, f := range p.active {
+		if i == maxProgressFileLines {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(p.active)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s / %s", f.name, formatBytes(f.sent.Load()), formatBytes(f.size)))
+	}
+	return lines
+}
+
+// summary describes the finished transfer
+func (p *transferProgress) summary() string {
+	sent := p.sent.Load()
+	elapsed := time.Since(p.start)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return fmt.Sprintf("Done. %d/%d files, %s in %v (%s/s)", p.doneFiles, p.totalFiles,
+		formatBytes(sent), elapsed.Round(time.Second), formatBytes(int64(transferRate(sent, elapsed))))
+}
+
+// transferRate returns the average bytes per second
+func transferRate(sent int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(sent) / elapsed.Seconds()
+}
+
+// formatETA renders the time left to send remaining bytes at rate bytes per second
+func formatETA(remaining int64, rate float64) string {
+	if remaining <= 0 {
+		return "0s"
+	}
+	if rate <= 0 {
+		return "--"
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second).String()
+}
+
+// statusProgress shows the latest status of a long-running operation with the time
+// elapsed. On a terminal the line is redrawn in place; otherwise a line is logged
+// when the status changes and every statusLogInterval while it does not.
+type statusProgress struct {
+	display *progressDisplay
+	prefix  string
+	start   time.Time
+
+	mu     sync.Mutex
+	status string
+	detail string
+}
+
+// newStatusProgress starts showing "<prefix><status> (elapsed: ...)" on w
+func newStatusProgress(w io.Writer, prefix string) *statusProgress {
+	s := &statusProgress{prefix: prefix, start: time.Now()}
+	s.display = newProgressDisplay(w, statusLogInterval, s.lines)
+	s.display.start()
+	return s
+}
+
+// update sets the current status. detail is appended after the elapsed time and does
+// not count as a change.
+func (s *statusProgress) update(status, detail string) {
+	s.mu.Lock()
+	changed := status != s.status
+	s.status, s.detail = status, detail
+	s.mu.Unlock()
+	if changed {
+		s.display.log()
+	}
+}
+
+// printf prints a message above the status line
+func (s *statusProgress) printf(format string, args ...interface{}) {
+	s.display.printf(format, args...)
+}
+
+// stop ends live updates, leaving the last status on screen
+func (s *statusProgress) stop() {
+	var final []string
+	if s.display.tty {
+		final = s.lines()
+	}
+	s.display.stop(final)
+}
+
+func (s *statusProgress) lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s%s (elapsed: %v%s)", s.prefix, s.status, time.Since(s.start).Round(time.Second), s.detail)}
+}
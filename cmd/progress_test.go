@@ -0,0 +1,103 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func forceTerminal(t *testing.T, tty bool) {
+	t.Helper()
+	orig := isTerminal
+	isTerminal = func(io.Writer) bool { return tty }
+	t.Cleanup(func() { isTerminal = orig })
+}
+
+func TestTransferProgress_Counters(t *testing.T) {
+	forceTerminal(t, true)
+	var out bytes.Buffer
+	p := newTransferProgress(&out, "Uploading", 2, 300)
+
+	a := p.file("a.bin", 100)
+	b := p.file("dir/b.bin", 200)
+	a.Write(make([]byte, 60))
+	a.reset() // retried from the start
+	a.Write(make([]byte, 100))
+	a.done()
+	b.Write(make([]byte, 50))
+
+	lines := p.lines()
+	require.Len(t, lines, 2, "total and the file still being sent")
+	assert.True(t, strings.HasPrefix(lines[0], "Uploading: 1/2 files, 150 B / 300 B (50%), "), lines[0])
+	assert.Equal(t, "  dir/b.bin: 50 B / 200 B", lines[1])
+
+	b.done()
+	p.finish()
+	assert.Contains(t, out.String(), "\r\033[K", "redrawn in place")
+	assert.True(t, strings.HasSuffix(out.String(), "\n"))
+	assert.Contains(t, out.String(), "Done. 2/2 files, 300 B in ")
+
+	var nilFile *fileProgress
+	n, err := nilFile.Write([]byte("ignored"))
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+	nilFile.reset()
+	nilFile.done()
+}
+
+func TestTransferProgress_NotTerminal(t *testing.T) {
+	forceTerminal(t, false)
+	var out bytes.Buffer
+	p := newTransferProgress(&out, "Uploading", 1, 10)
+	f := p.file("a.bin", 10)
+	f.Write(make([]byte, 4))
+	assert.Len(t, p.lines(), 1, "no per-file lines in logs")
+
+	p.printf("[INFO] note\n")
+	f.done()
+	p.finish()
+	assert.NotContains(t, out.String(), "\033[")
+	assert.True(t, strings.HasPrefix(out.String(), "[INFO] note\nDone. 1/1 files, 10 B in "), out.String())
+}
+
+func TestStatusProgress(t *testing.T) {
+	t.Run("not a terminal logs changes", func(t *testing.T) {
+		forceTerminal(t, false)
+		var out bytes.Buffer
+		s := newStatusProgress(&out, "[STATUS] Build status: ")
+		s.update("PENDING", "")
+		s.update("PENDING", "")
+		s.update("RUNNING", "")
+		s.stop()
+		assert.Equal(t, "[STATUS] Build status: PENDING (elapsed: 0s)\n[STATUS] Build status: RUNNING (elapsed: 0s)\n", out.String())
+	})
+
+	t.Run("terminal keeps one line", func(t *testing.T) {
+		forceTerminal(t, true)
+		var out bytes.Buffer
+		s := newStatusProgress(&out, "  Status: ")
+		s.update("Activating", ", attempt: 1/60")
+		s.printf("[WARN] slow\n")
+		s.stop()
+		s.stop()
+		got := out.String()
+		assert.Contains(t, got, "[WARN] slow\n")
+		assert.Equal(t, 1, strings.Count(got, "  Status: Activating (elapsed: 0s, attempt: 1/60)\n"), got)
+	})
+}
+
+func TestFormatETA(t *testing.T) {
+	assert.Equal(t, "0s", formatETA(0, 10))
+	assert.Equal(t, "--", formatETA(100, 0))
+	assert.Equal(t, "1m40s", formatETA(1000, 10))
+	assert.Equal(t, "2s", formatETA(3, 2))
+	assert.InDelta(t, 100, transferRate(1000, 10*time.Second), 0.001)
+}
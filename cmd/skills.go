@@ -6,7 +6,6 @@ package cmd
 import (
 	"archive/zip"
 	"bytes"
-	"context"
 	"fmt"
 	"io"
 	"net/url"
@@ -136,9 +135,10 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 	apiClient := agentbay.NewClientFromConfig(cfg)
-	ctx := context.Background()
+	ctx := commandContext(cmd)
+	limiter := newBandwidthLimiter(config.LoadUploadConfig().LimitRate)
 
-	fmt.Printf("[STEP 1/3] Getting upload credential...\n")
+	fmt.Fprintf(progressOut(), "[STEP 1/3] Getting upload credential...\n")
 	credReq := &client.GetMarketSkillCredentialRequest{FileName: &skillZipName}
 	credResp, err := apiClient.GetMarketSkillCredential(ctx, credReq)
 	if err != nil {
@@ -173,19 +173,19 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 
 	if zipPath != "" {
 		// Direct zip file: upload as-is
-		fmt.Printf("[STEP 2/3] Uploading skill zip...\n")
+		fmt.Fprintf(progressOut(), "[STEP 2/3] Uploading skill zip...\n")
 		if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
 			if fi, err := os.Stat(zipPath); err == nil {
 				fmt.Fprintf(os.Stderr, "[DEBUG] Upload size: %d bytes, file: %s\n", fi.Size(), zipPath)
 			}
 		}
-		if err := uploadFileToOSS(zipPath, uploadURLStr); err != nil {
+		if _, err := uploadSingleFileToOSS(ctx, zipPath, uploadURLStr, skillZipName, limiter); err != nil {
 			fmt.Fprintf(os.Stderr, "[ERROR] Failed to upload: %v\n", err)
 			return fmt.Errorf("upload: %w", err)
 		}
 	} else {
 		// Directory: pack then upload
-		fmt.Printf("[STEP 2/3] Packing and uploading skill...\n")
+		fmt.Fprintf(progressOut(), "[STEP 2/3] Packing and uploading skill...\n")
 		zipBuf, err := zipSkillDir(pathInput)
 		if err != nil {
 			return fmt.Errorf("pack skill: %w", err)
@@ -206,13 +206,13 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 		if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
 			fmt.Fprintf(os.Stderr, "[DEBUG] Upload size: %d bytes, temp file: %s\n", zipBuf.Len(), tmpPath)
 		}
-		if err := uploadFileToOSS(tmpPath, uploadURLStr); err != nil {
+		if _, err := uploadSingleFileToOSS(ctx, tmpPath, uploadURLStr, skillZipName, limiter); err != nil {
 			fmt.Fprintf(os.Stderr, "[ERROR] Failed to upload: %v\n", err)
 			return fmt.Errorf("upload: %w", err)
 		}
 	}
 
-	fmt.Printf("[STEP 3/3] Creating skill...\n")
+	fmt.Fprintf(progressOut(), "[STEP 3/3] Creating skill...\n")
 	createReq := &client.CreateMarketSkillRequest{
 		OssBucket:   &createBucket,
 		OssFilePath: &createOssFilePath,
@@ -233,10 +233,14 @@ func runSkillsPush(cmd *cobra.Command, args []string) error {
 	if skillId == "" {
 		skillId = "<unknown>"
 	}
-	fmt.Println()
-	fmt.Printf("[SUCCESS] ✅ Skill created successfully!\n")
-	fmt.Printf("[RESULT] Skill ID: %s\n", skillId)
-	return nil
+	fmt.Fprintln(progressOut())
+	fmt.Fprintf(progressOut(), "[SUCCESS] ✅ Skill created successfully!\n")
+	fmt.Fprintf(progressOut(), "[RESULT] Skill ID: %s\n", skillId)
+	return renderOutput(skillPushOutput{
+		SkillID:     skillId,
+		OssBucket:   createBucket,
+		OssFilePath: createOssFilePath,
+	}, nil)
 }
 
 // skillDirToZipFileName returns the zip filename to use for upload, derived from the skill directory path.
@@ -344,7 +348,7 @@ func runSkillsShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 	apiClient := agentbay.NewClientFromConfig(cfg)
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	req := &client.DescribeMarketSkillDetailRequest{SkillId: &skillId}
 	resp, err := apiClient.DescribeMarketSkillDetail(ctx, req)
@@ -366,14 +370,20 @@ func runSkillsShow(cmd *cobra.Command, args []string) error {
 	if displaySkillId == "" {
 		displaySkillId = skillId
 	}
-	fmt.Printf("%-*s %s\n", skillDetailLabelW, "SkillId:", displaySkillId)
-	fmt.Printf("%-*s %s\n", skillDetailLabelW, "Name:", strPtr(d.GetName()))
 	desc := strPtr(d.GetDescription())
-	if desc != "" {
-		fmt.Printf("%-*s\n", skillDetailLabelW, "Description:")
-		fmt.Println(wrapText(desc, 72, "  "))
-	}
-	return nil
+	result := skillShowOutput{
+		SkillID:     displaySkillId,
+		Name:        strPtr(d.GetName()),
+		Description: desc,
+	}
+	return renderOutput(result, func() {
+		fmt.Printf("%-*s %s\n", skillDetailLabelW, "SkillId:", result.SkillID)
+		fmt.Printf("%-*s %s\n", skillDetailLabelW, "Name:", result.Name)
+		if desc != "" {
+			fmt.Printf("%-*s\n", skillDetailLabelW, "Description:")
+			fmt.Println(wrapText(desc, 72, "  "))
+		}
+	})
 }
 
 func strPtr(s *string) string {
@@ -22,16 +22,25 @@ var VersionCmd = &cobra.Command{
 	Long:    "Display version, git commit, and build date information",
 	GroupID: "core",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Printf("AgentBay CLI version %s\n", Version)
-		fmt.Printf("Git commit: %s\n", GitCommit)
-		fmt.Printf("Build date: %s\n", BuildDate)
-
-		// Show environment information
+		// Collect version and environment information
 		env := config.GetEnvironment()
-		envConfig := config.GetEnvironmentConfig()
-		fmt.Printf("Environment: %s\n", env)
-		fmt.Printf("Endpoint: %s\n", envConfig.Endpoint)
+		apiConfig := config.LoadAPIConfig(nil)
+		result := versionOutput{
+			Version:     Version,
+			GitCommit:   GitCommit,
+			BuildDate:   BuildDate,
+			Profile:     config.SelectedProfileName(),
+			Environment: string(env),
+			Endpoint:    apiConfig.Endpoint,
+		}
 
-		return nil
+		return renderOutput(result, func() {
+			fmt.Printf("AgentBay CLI version %s\n", result.Version)
+			fmt.Printf("Git commit: %s\n", result.GitCommit)
+			fmt.Printf("Build date: %s\n", result.BuildDate)
+			fmt.Printf("Profile: %s\n", result.Profile)
+			fmt.Printf("Environment: %s\n", result.Environment)
+			fmt.Printf("Endpoint: %s\n", result.Endpoint)
+		})
 	},
 }
@@ -6,11 +6,9 @@ package agentbay
 import (
 	"bytes"
 	"context"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
@@ -23,9 +21,6 @@ import (
 	"github.com/agentbay/agentbay-cli/internal/config"
 )
 
-// xmlResponseCache stores the last XML response for fallback parsing
-var xmlResponseCache string
-
 // formatXMLForDisplay formats XML string for better readability in logs
 func formatXMLForDisplay(xmlStr string) string {
 	// Simple XML formatting - add newlines after major tags
@@ -83,19 +78,18 @@ func (dt *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		return resp, err
 	}
 
-	// Cache XML responses for fallback parsing (always needed)
-	if resp.Body != nil {
-		body, err := io.ReadAll(resp.Body)
-		if err == nil {
-			// Cache XML responses for fallback parsing
-			if bytes.Contains(body, []byte("<?xml")) && (bytes.Contains(body, []byte("GetDockerFileStoreCredentialResponse")) || bytes.Contains(body, []byte("CreateDockerImageTaskResponse")) || bytes.Contains(body, []byte("GetDockerImageTaskResponse")) || bytes.Contains(body, []byte("ListMcpImagesResponse")) || bytes.Contains(body, []byte("GetMcpImageInfoResponse")) || bytes.Contains(body, []byte("CreateResourceGroupResponse")) || bytes.Contains(body, []byte("DeleteResourceGroupResponse")) || bytes.Contains(body, []byte("GetDockerfileTemplateResponse"))) {
-				xmlResponseCache = string(body)
-				log.Debugf("[DEBUG] Cached XML response for fallback parsing")
+	// Capture the response for the API call that issued this request (retry
+	// classification and decoding non-JSON bodies); concurrent calls each have their own recorder
+	if rec := responseRecorderFromContext(req.Context()); rec != nil {
+		var body []byte
+		if resp.Body != nil {
+			if b, readErr := io.ReadAll(resp.Body); readErr == nil {
+				body = b
+				// Restore the body for normal processing
+				resp.Body = io.NopCloser(bytes.NewReader(body))
 			}
-
-			// Restore the body for normal processing
-			resp.Body = io.NopCloser(bytes.NewReader(body))
 		}
+		rec.record(resp, body)
 	}
 
 	return resp, err
@@ -117,6 +111,7 @@ type Client interface {
 	GetDockerFileStoreCredential(ctx context.Context, request *client.GetDockerFileStoreCredentialRequest) (*client.GetDockerFileStoreCredentialResponse, error)
 	CreateDockerImageTask(ctx context.Context, request *client.CreateDockerImageTaskRequest) (*client.CreateDockerImageTaskResponse, error)
 	GetDockerImageTask(ctx context.Context, request *client.GetDockerImageTaskRequest) (*client.GetDockerImageTaskResponse, error)
+	GetDockerImageTaskLog(ctx context.Context, request *client.GetDockerImageTaskLogRequest) (*client.GetDockerImageTaskLogResponse, error)
 	ListMcpImages(ctx context.Context, request *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error)
 	GetMcpImageInfo(ctx context.Context, request *client.GetMcpImageInfoRequest) (*client.GetMcpImageInfoResponse, error)
 	CreateResourceGroup(ctx context.Context, request *client.CreateResourceGroupRequest) (*client.CreateResourceGroupResponse, error)
@@ -205,8 +200,8 @@ func (cw *clientWrapper) getClient() (*client.Client, error) {
 		UserAgent:      dara.String("AgentBay-CLI/1.0"),
 	}
 
-	// Set custom HTTP client for XML response caching (always needed for fallback parsing)
-	log.Debugf("[DEBUG] getClient: Setting up HTTP transport for XML response caching")
+	// Set custom HTTP client for per-request response capture (needed for decoding non-JSON bodies and retries)
+	log.Debugf("[DEBUG] getClient: Setting up HTTP transport for response capture")
 
 	// Create a custom transport that wraps the default transport
 	baseTransport := http.DefaultTransport
@@ -254,424 +249,15 @@ func (cw *clientWrapper) getRuntimeOptions() *dara.RuntimeOptions {
 	return runtimeOptions
 }
 
-// XMLGetDockerFileStoreCredentialResponse represents the XML response structure
-type XMLGetDockerFileStoreCredentialResponse struct {
-	XMLName        xml.Name `xml:"GetDockerFileStoreCredentialResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Data           struct {
-		TaskId string `xml:"TaskId"`
-		OssUrl string `xml:"OssUrl"`
-	} `xml:"Data"`
-	Code    string `xml:"Code"`
-	Success bool   `xml:"Success"`
-}
-
-// parseXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseXMLResponse(xmlData []byte) (*client.GetDockerFileStoreCredentialResponse, error) {
-	log.Debugf("[DEBUG] Parsing XML data: %s", string(xmlData))
-
-	var xmlResp XMLGetDockerFileStoreCredentialResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - TaskId: %s", xmlResp.Data.TaskId)
-	log.Debugf("[DEBUG] - OssUrl: %s", xmlResp.Data.OssUrl)
-
-	// Convert to SDK response format
-	response := &client.GetDockerFileStoreCredentialResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.GetDockerFileStoreCredentialResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Data: &client.GetDockerFileStoreCredentialResponseBodyData{
-				TaskId: dara.String(xmlResp.Data.TaskId),
-				OssUrl: dara.String(xmlResp.Data.OssUrl),
-			},
-		},
-	}
-
-	log.Debugf("[DEBUG] Created SDK response with OssUrl: %s", xmlResp.Data.OssUrl)
-	return response, nil
-}
-
-// XMLCreateDockerImageTaskResponse represents the XML response structure for CreateDockerImageTask
-type XMLCreateDockerImageTaskResponse struct {
-	XMLName        xml.Name `xml:"CreateDockerImageTaskResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Data           struct {
-		TaskId string `xml:"TaskId"`
-	} `xml:"Data"`
-	Code    string `xml:"Code"`
-	Success bool   `xml:"Success"`
-}
-
-// parseCreateDockerImageTaskXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseCreateDockerImageTaskXMLResponse(xmlData []byte) (*client.CreateDockerImageTaskResponse, error) {
-	log.Debugf("[DEBUG] Parsing CreateDockerImageTask XML data: %s", string(xmlData))
-
-	var xmlResp XMLCreateDockerImageTaskResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] CreateDockerImageTask XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse CreateDockerImageTask XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed CreateDockerImageTask XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - TaskId: %s", xmlResp.Data.TaskId)
-
-	// Convert to SDK response format
-	response := &client.CreateDockerImageTaskResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.CreateDockerImageTaskResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Data: &client.CreateDockerImageTaskResponseBodyData{
-				TaskId: dara.String(xmlResp.Data.TaskId),
-			},
-		},
-	}
-
-	log.Debugf("[DEBUG] Created CreateDockerImageTask SDK response with TaskId: %s", xmlResp.Data.TaskId)
-	return response, nil
-}
-
-// XMLGetDockerImageTaskResponse represents the XML response structure for GetDockerImageTask
-type XMLGetDockerImageTaskResponse struct {
-	XMLName        xml.Name `xml:"GetDockerImageTaskResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Data           struct {
-		Status  string `xml:"Status"`
-		ImageId string `xml:"ImageId"`
-		TaskMsg string `xml:"TaskMsg"`
-	} `xml:"Data"`
-	Code    string `xml:"Code"`
-	Success bool   `xml:"Success"`
-}
-
-// XMLListMcpImagesResponse represents the XML response structure for ListMcpImages
-type XMLListMcpImagesResponse struct {
-	XMLName        xml.Name `xml:"ListMcpImagesResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Data           struct {
-		Images []struct {
-			ImageId                 string `xml:"ImageId"`
-			ImageName               string `xml:"ImageName"`
-			ImageBuildType          string `xml:"ImageBuildType"`
-			ImageIntro              string `xml:"ImageIntro"`
-			ImageApplyScene         string `xml:"ImageApplyScene"`
-			ImageResourceStatus     string `xml:"ImageResourceStatus"`
-			ImageResourceGroupInfo  struct {
-				ResourceGroupId string `xml:"ResourceGroupId"`
-			} `xml:"ImageResourceGroupInfo"`
-			ImageInfo struct {
-				OsName         string `xml:"OsName"`
-				OsVersion      string `xml:"OsVersion"`
-				PlatformName   string `xml:"PlatformName"`
-				Status         string `xml:"Status"`
-				DataDiskSize   int32  `xml:"DataDiskSize"`
-				SystemDiskSize int32  `xml:"SystemDiskSize"`
-				FotaVersion    string `xml:"FotaVersion"`
-				UpdateTime     string `xml:"UpdateTime"`
-			} `xml:"ImageInfo"`
-			ToolInfo []struct {
-				McpServerId   string `xml:"McpServerId"`
-				McpServerName string `xml:"McpServerName"`
-				ToolList      []struct {
-					Tool        string `xml:"Tool"`
-					Description string `xml:"Description"`
-				} `xml:"ToolList"`
-			} `xml:"ToolInfo"`
-		} `xml:"data"`
-	} `xml:"Data"`
-	Code       string `xml:"Code"`
-	Success    bool   `xml:"Success"`
-	TotalCount int32  `xml:"TotalCount"`
-	PageSize   int32  `xml:"PageSize"`
-	PageStart  int32  `xml:"PageStart"`
-	NextToken  string `xml:"NextToken"`
-}
-
-// XMLCreateResourceGroupResponse represents the XML response structure for CreateResourceGroup
-type XMLCreateResourceGroupResponse struct {
-	XMLName        xml.Name `xml:"CreateResourceGroupResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Code           string   `xml:"Code"`
-	Success        bool     `xml:"Success"`
-	Message        string   `xml:"Message"`
-}
-
-// XMLDeleteResourceGroupResponse represents the XML response structure for DeleteResourceGroup
-type XMLDeleteResourceGroupResponse struct {
-	XMLName        xml.Name `xml:"DeleteResourceGroupResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Code           string   `xml:"Code"`
-	Success        bool     `xml:"Success"`
-	Message        string   `xml:"Message"`
-}
-
-// XMLGetMcpImageInfoResponse represents the XML response structure for GetMcpImageInfo
-type XMLGetMcpImageInfoResponse struct {
-	XMLName        xml.Name `xml:"GetMcpImageInfoResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Code           string   `xml:"Code"`
-	Success        bool     `xml:"Success"`
-	Message        string   `xml:"Message"`
-	Data           struct {
-		ImageId             string `xml:"ImageId"`
-		ImageName           string `xml:"ImageName"`
-		ImageBuildType      string `xml:"ImageBuildType"`
-		ImageApplyScene     string `xml:"ImageApplyScene"`
-		ImageResourceStatus string `xml:"ImageResourceStatus"`
-		ImageInfo           struct {
-			OsName         string `xml:"OsName"`
-			OsVersion      string `xml:"OsVersion"`
-			PlatformName   string `xml:"PlatformName"`
-			Status         string `xml:"Status"`
-			DataDiskSize   int32  `xml:"DataDiskSize"`
-			SystemDiskSize int32  `xml:"SystemDiskSize"`
-			UpdateTime     string `xml:"UpdateTime"`
-			ImageType      string `xml:"ImageType"`
-		} `xml:"ImageInfo"`
-		ImageBuildInfo struct {
-			TaskId                  string `xml:"TaskId"`
-			VersionId               string `xml:"VersionId"`
-			ApiKeyId                string `xml:"ApiKeyId"`
-			InstanceReady           bool   `xml:"InstanceReady"`
-			AndroidMobileGroupId    string `xml:"AndroidMobileGroupId"`
-			AndroidMobileInstanceId string `xml:"AndroidMobileInstanceId"`
-		} `xml:"ImageBuildInfo"`
-	} `xml:"Data"`
-}
-
-// parseGetDockerImageTaskXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseGetDockerImageTaskXMLResponse(xmlData []byte) (*client.GetDockerImageTaskResponse, error) {
-	log.Debugf("[DEBUG] Parsing GetDockerImageTask XML data: %s", string(xmlData))
-
-	var xmlResp XMLGetDockerImageTaskResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] GetDockerImageTask XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse GetDockerImageTask XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed GetDockerImageTask XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - Status: %s", xmlResp.Data.Status)
-	log.Debugf("[DEBUG] - ImageId: %s", xmlResp.Data.ImageId)
-	log.Debugf("[DEBUG] - TaskMsg: %s", xmlResp.Data.TaskMsg)
-
-	// Convert to SDK response format
-	response := &client.GetDockerImageTaskResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.GetDockerImageTaskResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Data: &client.GetDockerImageTaskResponseBodyData{
-				Status:  dara.String(xmlResp.Data.Status),
-				ImageId: dara.String(xmlResp.Data.ImageId),
-				TaskMsg: dara.String(xmlResp.Data.TaskMsg),
-			},
-		},
-	}
-
-	log.Debugf("[DEBUG] Created GetDockerImageTask SDK response with Status: %s", xmlResp.Data.Status)
-	return response, nil
-}
-
-// parseListMcpImagesXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseListMcpImagesXMLResponse(xmlData []byte) (*client.ListMcpImagesResponse, error) {
-	log.Debugf("[DEBUG] Parsing ListMcpImages XML data (size: %d bytes)", len(xmlData))
-
-	var xmlResp XMLListMcpImagesResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] ListMcpImages XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse ListMcpImages XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed ListMcpImages XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - TotalCount: %d", xmlResp.TotalCount)
-	log.Debugf("[DEBUG] - Data length: %d", len(xmlResp.Data.Images))
-
-	// Convert XML data to SDK format
-	var sdkData []*client.ListMcpImagesResponseBodyData
-	for _, xmlImage := range xmlResp.Data.Images {
-		// Convert ImageInfo
-		var imageInfo *client.ListMcpImagesResponseBodyDataImageInfo
-		if xmlImage.ImageInfo.OsName != "" || xmlImage.ImageInfo.OsVersion != "" {
-			imageInfo = &client.ListMcpImagesResponseBodyDataImageInfo{
-				OsName:         dara.String(xmlImage.ImageInfo.OsName),
-				OsVersion:      dara.String(xmlImage.ImageInfo.OsVersion),
-				PlatformName:   dara.String(xmlImage.ImageInfo.PlatformName),
-				Status:         dara.String(xmlImage.ImageInfo.Status),
-				DataDiskSize:   dara.Int32(xmlImage.ImageInfo.DataDiskSize),
-				SystemDiskSize: dara.Int32(xmlImage.ImageInfo.SystemDiskSize),
-				FotaVersion:    dara.String(xmlImage.ImageInfo.FotaVersion),
-				UpdateTime:     dara.String(xmlImage.ImageInfo.UpdateTime),
-			}
-		}
-
-		// Convert ToolInfo
-		var toolInfo []*client.ListMcpImagesResponseBodyDataToolInfo
-		for _, xmlTool := range xmlImage.ToolInfo {
-			var toolList []*client.ListMcpImagesResponseBodyDataToolInfoToolList
-			for _, xmlToolItem := range xmlTool.ToolList {
-				toolList = append(toolList, &client.ListMcpImagesResponseBodyDataToolInfoToolList{
-					Tool:        dara.String(xmlToolItem.Tool),
-					Description: dara.String(xmlToolItem.Description),
-				})
-			}
-
-			toolInfo = append(toolInfo, &client.ListMcpImagesResponseBodyDataToolInfo{
-				McpServerId:   dara.String(xmlTool.McpServerId),
-				McpServerName: dara.String(xmlTool.McpServerName),
-				ToolList:      toolList,
-			})
-		}
-
-		var imageResourceGroupInfo *client.ListMcpImagesResponseBodyDataImageResourceGroupInfo
-		if xmlImage.ImageResourceGroupInfo.ResourceGroupId != "" {
-			imageResourceGroupInfo = &client.ListMcpImagesResponseBodyDataImageResourceGroupInfo{
-				ResourceGroupId: dara.String(xmlImage.ImageResourceGroupInfo.ResourceGroupId),
-			}
-		}
-
-		sdkImage := &client.ListMcpImagesResponseBodyData{
-			ImageId:                 dara.String(xmlImage.ImageId),
-			ImageName:               dara.String(xmlImage.ImageName),
-			ImageBuildType:          dara.String(xmlImage.ImageBuildType),
-			ImageIntro:              dara.String(xmlImage.ImageIntro),
-			ImageApplyScene:         dara.String(xmlImage.ImageApplyScene),
-			ImageResourceStatus:     dara.String(xmlImage.ImageResourceStatus),
-			ImageResourceGroupInfo:  imageResourceGroupInfo,
-			ImageInfo:               imageInfo,
-			ToolInfo:                toolInfo,
-		}
-		sdkData = append(sdkData, sdkImage)
-	}
-
-	// Convert to SDK response format
-	response := &client.ListMcpImagesResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.ListMcpImagesResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Data:           sdkData,
-			TotalCount:     dara.Int32(xmlResp.TotalCount),
-			PageSize:       dara.Int32(xmlResp.PageSize),
-			PageStart:      dara.Int32(xmlResp.PageStart),
-			NextToken:      dara.String(xmlResp.NextToken),
-		},
-	}
-
-	log.Debugf("[DEBUG] Created ListMcpImages SDK response with %d images", len(sdkData))
-	return response, nil
-}
-
-// parseCreateResourceGroupXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseCreateResourceGroupXMLResponse(xmlData []byte) (*client.CreateResourceGroupResponse, error) {
-	log.Debugf("[DEBUG] Parsing CreateResourceGroup XML data: %s", string(xmlData))
-
-	var xmlResp XMLCreateResourceGroupResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] CreateResourceGroup XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse CreateResourceGroup XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed CreateResourceGroup XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - Message: %s", xmlResp.Message)
-
-	// Convert to SDK response format
-	response := &client.CreateResourceGroupResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.CreateResourceGroupResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Message:        dara.String(xmlResp.Message),
-		},
-	}
-
-	log.Debugf("[DEBUG] Created CreateResourceGroup SDK response")
-	return response, nil
-}
-
-// parseDeleteResourceGroupXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseDeleteResourceGroupXMLResponse(xmlData []byte) (*client.DeleteResourceGroupResponse, error) {
-	log.Debugf("[DEBUG] Parsing DeleteResourceGroup XML data: %s", string(xmlData))
-
-	var xmlResp XMLDeleteResourceGroupResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] DeleteResourceGroup XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse DeleteResourceGroup XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed DeleteResourceGroup XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - Message: %s", xmlResp.Message)
-
-	// Convert to SDK response format
-	response := &client.DeleteResourceGroupResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.DeleteResourceGroupResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Message:        dara.String(xmlResp.Message),
-		},
-	}
-
-	log.Debugf("[DEBUG] Created DeleteResourceGroup SDK response")
-	return response, nil
-}
-
 // GetDockerFileStoreCredential wraps the SDK client method
 func (cw *clientWrapper) GetDockerFileStoreCredential(ctx context.Context, request *client.GetDockerFileStoreCredentialRequest) (*client.GetDockerFileStoreCredentialResponse, error) {
+	return callWithRetry(ctx, cw, "GetDockerFileStoreCredential", true, func(ctx context.Context) (*client.GetDockerFileStoreCredentialResponse, error) {
+		return cw.getDockerFileStoreCredential(ctx, request)
+	})
+}
+
+// getDockerFileStoreCredential performs a single GetDockerFileStoreCredential attempt
+func (cw *clientWrapper) getDockerFileStoreCredential(ctx context.Context, request *client.GetDockerFileStoreCredentialRequest) (*client.GetDockerFileStoreCredentialResponse, error) {
 	log.Debugf("[DEBUG] ClientWrapper: Getting SDK client...")
 	sdkClient, err := cw.getClient()
 	if err != nil {
@@ -688,92 +274,76 @@ func (cw *clientWrapper) GetDockerFileStoreCredential(ctx context.Context, reque
 		log.Debugf("[DEBUG] Making GetDockerFileStoreCredential request...")
 	}
 
-	resp, err := sdkClient.GetDockerFileStoreCredentialWithOptions(request, runtimeOptions)
-
-	// Log detailed response information in verbose mode
-	if log.GetLevel() >= log.DebugLevel {
-		if err != nil {
-			// Check if this is a known XML parsing error
-			errStr := err.Error()
-			if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-				log.Debugf("[DEBUG] HTTP Response: XML format detected, will use custom parser")
-			} else {
-				log.Debugf("[DEBUG] HTTP Response Error: %v", err)
-				// Try to extract more details from the error
-				log.Debugf("[DEBUG] Error type: %T", err)
-				log.Debugf("[DEBUG] Error string: %s", err.Error())
-			}
-		} else {
-			log.Debugf("[DEBUG] GetDockerFileStoreCredential request completed successfully")
-		}
-	}
-
+	resp, err := sdkClient.GetDockerFileStoreCredentialWithContext(ctx, request, runtimeOptions)
 	if err != nil {
-		// Check if this is an XML parsing error
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
-		log.Debugf("[DEBUG] ClientWrapper: SDK API call failed: %v", err)
-		return nil, err
+		log.Debugf("[DEBUG] ClientWrapper: GetDockerFileStoreCredential SDK call failed: %v", err)
+		return decodeRecordedResponse[client.GetDockerFileStoreCredentialResponse](ctx, "GetDockerFileStoreCredential", err)
 	}
-	log.Debugf("[DEBUG] ClientWrapper: SDK API call completed successfully")
 
+	log.Debugf("[DEBUG] ClientWrapper: GetDockerFileStoreCredential completed successfully")
 	return resp, nil
 }
 
 // GetMarketSkillCredential wraps the SDK client method
 func (cw *clientWrapper) GetMarketSkillCredential(ctx context.Context, request *client.GetMarketSkillCredentialRequest) (*client.GetMarketSkillCredentialResponse, error) {
+	return callWithRetry(ctx, cw, "GetMarketSkillCredential", true, func(ctx context.Context) (*client.GetMarketSkillCredentialResponse, error) {
+		return cw.getMarketSkillCredential(ctx, request)
+	})
+}
+
+// getMarketSkillCredential performs a single GetMarketSkillCredential attempt
+func (cw *clientWrapper) getMarketSkillCredential(ctx context.Context, request *client.GetMarketSkillCredentialRequest) (*client.GetMarketSkillCredentialResponse, error) {
 	sdkClient, err := cw.getClient()
 	if err != nil {
 		return nil, err
 	}
 	runtimeOptions := cw.getRuntimeOptions()
-	return sdkClient.GetMarketSkillCredentialWithOptions(request, runtimeOptions)
+	return sdkClient.GetMarketSkillCredentialWithContext(ctx, request, runtimeOptions)
 }
 
-// CreateMarketSkill wraps the SDK client method
+// CreateMarketSkill wraps the SDK client method. It is not idempotent and is never retried.
 func (cw *clientWrapper) CreateMarketSkill(ctx context.Context, request *client.CreateMarketSkillRequest) (*client.CreateMarketSkillResponse, error) {
+	return callWithRetry(ctx, cw, "CreateMarketSkill", false, func(ctx context.Context) (*client.CreateMarketSkillResponse, error) {
+		return cw.createMarketSkill(ctx, request)
+	})
+}
+
+// createMarketSkill performs a single CreateMarketSkill attempt
+func (cw *clientWrapper) createMarketSkill(ctx context.Context, request *client.CreateMarketSkillRequest) (*client.CreateMarketSkillResponse, error) {
 	sdkClient, err := cw.getClient()
 	if err != nil {
 		return nil, err
 	}
 	runtimeOptions := cw.getRuntimeOptions()
-	return sdkClient.CreateMarketSkillWithOptions(request, runtimeOptions)
+	return sdkClient.CreateMarketSkillWithContext(ctx, request, runtimeOptions)
 }
 
 // DescribeMarketSkillDetail wraps the SDK client method
 func (cw *clientWrapper) DescribeMarketSkillDetail(ctx context.Context, request *client.DescribeMarketSkillDetailRequest) (*client.DescribeMarketSkillDetailResponse, error) {
+	return callWithRetry(ctx, cw, "DescribeMarketSkillDetail", true, func(ctx context.Context) (*client.DescribeMarketSkillDetailResponse, error) {
+		return cw.describeMarketSkillDetail(ctx, request)
+	})
+}
+
+// describeMarketSkillDetail performs a single DescribeMarketSkillDetail attempt
+func (cw *clientWrapper) describeMarketSkillDetail(ctx context.Context, request *client.DescribeMarketSkillDetailRequest) (*client.DescribeMarketSkillDetailResponse, error) {
 	sdkClient, err := cw.getClient()
 	if err != nil {
 		return nil, err
 	}
 	runtimeOptions := cw.getRuntimeOptions()
-	return sdkClient.DescribeMarketSkillDetailWithOptions(request, runtimeOptions)
+	return sdkClient.DescribeMarketSkillDetailWithContext(ctx, request, runtimeOptions)
 }
 
-// CreateDockerImageTask wraps the SDK client method
+// CreateDockerImageTask wraps the SDK client method. It is not idempotent and is never retried.
 func (cw *clientWrapper) CreateDockerImageTask(ctx context.Context, request *client.CreateDockerImageTaskRequest) (*client.CreateDockerImageTaskResponse, error) {
+	return callWithRetry(ctx, cw, "CreateDockerImageTask", false, func(ctx context.Context) (*client.CreateDockerImageTaskResponse, error) {
+		return cw.createDockerImageTask(ctx, request)
+	})
+}
+
+// createDockerImageTask performs a single CreateDockerImageTask attempt
+func (cw *clientWrapper) createDockerImageTask(ctx context.Context, request *client.CreateDockerImageTaskRequest) (*client.CreateDockerImageTaskResponse, error) {
 	sdkClient, err := cw.getClient()
 	if err != nil {
 		return nil, err
@@ -787,60 +357,25 @@ func (cw *clientWrapper) CreateDockerImageTask(ctx context.Context, request *cli
 		log.Debugf("[DEBUG] Making CreateDockerImageTask request...")
 	}
 
-	resp, err := sdkClient.CreateDockerImageTaskWithOptions(request, runtimeOptions)
-
-	// Log detailed response information in verbose mode
-	if log.GetLevel() >= log.DebugLevel {
-		if err != nil {
-			// Check if this is a known XML parsing error
-			errStr := err.Error()
-			if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-				log.Debugf("[DEBUG] CreateDockerImageTask HTTP Response: XML format detected, will use custom parser")
-			} else {
-				log.Debugf("[DEBUG] CreateDockerImageTask HTTP Response Error: %v", err)
-				// Try to extract more details from the error
-				log.Debugf("[DEBUG] Error type: %T", err)
-				log.Debugf("[DEBUG] Error string: %s", err.Error())
-			}
-		} else {
-			log.Debugf("[DEBUG] CreateDockerImageTask request completed successfully")
-		}
-	}
-
+	resp, err := sdkClient.CreateDockerImageTaskWithContext(ctx, request, runtimeOptions)
 	if err != nil {
-		// Check if this is an XML parsing error
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseCreateDockerImageTaskXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
-		log.Debugf("[DEBUG] ClientWrapper: SDK API call failed: %v", err)
-		return nil, err
+		log.Debugf("[DEBUG] ClientWrapper: CreateDockerImageTask SDK call failed: %v", err)
+		return decodeRecordedResponse[client.CreateDockerImageTaskResponse](ctx, "CreateDockerImageTask", err)
 	}
-	log.Debugf("[DEBUG] ClientWrapper: SDK API call completed successfully")
+
+	log.Debugf("[DEBUG] ClientWrapper: CreateDockerImageTask completed successfully")
 	return resp, nil
 }
 
 // GetDockerImageTask wraps the SDK client method
 func (cw *clientWrapper) GetDockerImageTask(ctx context.Context, request *client.GetDockerImageTaskRequest) (*client.GetDockerImageTaskResponse, error) {
+	return callWithRetry(ctx, cw, "GetDockerImageTask", true, func(ctx context.Context) (*client.GetDockerImageTaskResponse, error) {
+		return cw.getDockerImageTask(ctx, request)
+	})
+}
+
+// getDockerImageTask performs a single GetDockerImageTask attempt
+func (cw *clientWrapper) getDockerImageTask(ctx context.Context, request *client.GetDockerImageTaskRequest) (*client.GetDockerImageTaskResponse, error) {
 	sdkClient, err := cw.getClient()
 	if err != nil {
 		return nil, err
@@ -854,60 +389,57 @@ func (cw *clientWrapper) GetDockerImageTask(ctx context.Context, request *client
 		log.Debugf("[DEBUG] Making GetDockerImageTask request...")
 	}
 
-	resp, err := sdkClient.GetDockerImageTaskWithOptions(request, runtimeOptions)
+	resp, err := sdkClient.GetDockerImageTaskWithContext(ctx, request, runtimeOptions)
+	if err != nil {
+		log.Debugf("[DEBUG] ClientWrapper: GetDockerImageTask SDK call failed: %v", err)
+		return decodeRecordedResponse[client.GetDockerImageTaskResponse](ctx, "GetDockerImageTask", err)
+	}
 
-	// Log detailed response information in verbose mode
+	log.Debugf("[DEBUG] ClientWrapper: GetDockerImageTask completed successfully")
+	return resp, nil
+}
+
+// GetDockerImageTaskLog wraps the SDK client method
+func (cw *clientWrapper) GetDockerImageTaskLog(ctx context.Context, request *client.GetDockerImageTaskLogRequest) (*client.GetDockerImageTaskLogResponse, error) {
+	return callWithRetry(ctx, cw, "GetDockerImageTaskLog", true, func(ctx context.Context) (*client.GetDockerImageTaskLogResponse, error) {
+		return cw.getDockerImageTaskLog(ctx, request)
+	})
+}
+
+// getDockerImageTaskLog performs a single GetDockerImageTaskLog attempt
+func (cw *clientWrapper) getDockerImageTaskLog(ctx context.Context, request *client.GetDockerImageTaskLogRequest) (*client.GetDockerImageTaskLogResponse, error) {
+	sdkClient, err := cw.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get runtime options with debug enabled if verbose
+	runtimeOptions := cw.getRuntimeOptions()
+
+	// Log basic request information in verbose mode
 	if log.GetLevel() >= log.DebugLevel {
-		if err != nil {
-			// Check if this is a known XML parsing error
-			errStr := err.Error()
-			if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-				log.Debugf("[DEBUG] GetDockerImageTask HTTP Response: XML format detected, will use custom parser")
-			} else {
-				log.Debugf("[DEBUG] GetDockerImageTask HTTP Response Error: %v", err)
-				// Try to extract more details from the error
-				log.Debugf("[DEBUG] Error type: %T", err)
-				log.Debugf("[DEBUG] Error string: %s", err.Error())
-			}
-		} else {
-			log.Debugf("[DEBUG] GetDockerImageTask request completed successfully")
-		}
+		log.Debugf("[DEBUG] Making GetDockerImageTaskLog request...")
 	}
 
+	resp, err := sdkClient.GetDockerImageTaskLogWithContext(ctx, request, runtimeOptions)
 	if err != nil {
-		// Check if this is an XML parsing error
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseGetDockerImageTaskXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
-		log.Debugf("[DEBUG] ClientWrapper: SDK API call failed: %v", err)
-		return nil, err
+		log.Debugf("[DEBUG] ClientWrapper: GetDockerImageTaskLog SDK call failed: %v", err)
+		return decodeRecordedResponse[client.GetDockerImageTaskLogResponse](ctx, "GetDockerImageTaskLog", err)
 	}
-	log.Debugf("[DEBUG] ClientWrapper: SDK API call completed successfully")
+
+	log.Debugf("[DEBUG] ClientWrapper: GetDockerImageTaskLog completed successfully")
 	return resp, nil
 }
 
 // ListMcpImages wraps the SDK client method
 func (cw *clientWrapper) ListMcpImages(ctx context.Context, request *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
+	return callWithRetry(ctx, cw, "ListMcpImages", true, func(ctx context.Context) (*client.ListMcpImagesResponse, error) {
+		return cw.listMcpImages(ctx, request)
+	})
+}
+
+// listMcpImages performs a single ListMcpImages attempt
+func (cw *clientWrapper) listMcpImages(ctx context.Context, request *client.ListMcpImagesRequest) (*client.ListMcpImagesResponse, error) {
 	sdkClient, err := cw.getClient()
 	if err != nil {
 		return nil, err
@@ -921,59 +453,25 @@ func (cw *clientWrapper) ListMcpImages(ctx context.Context, request *client.List
 		log.Debugf("[DEBUG] Making ListMcpImages request...")
 	}
 
-	resp, err := sdkClient.ListMcpImagesWithOptions(request, runtimeOptions)
-
-	// Log detailed response information in verbose mode
-	if log.GetLevel() >= log.DebugLevel {
-		if err != nil {
-			// Check if this is a known XML parsing error
-			errStr := err.Error()
-			if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) || bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) {
-				log.Debugf("[DEBUG] ListMcpImages HTTP Response: XML format detected, will use custom parser")
-			} else {
-				log.Debugf("[DEBUG] ListMcpImages HTTP Response Error: %v", err)
-				// Try to extract more details from the error
-				log.Debugf("[DEBUG] Error type: %T", err)
-				log.Debugf("[DEBUG] Error string: %s", err.Error())
-			}
-		} else {
-			log.Debugf("[DEBUG] ListMcpImages request completed successfully")
-		}
-	}
-
+	resp, err := sdkClient.ListMcpImagesWithContext(ctx, request, runtimeOptions)
 	if err != nil {
-		// Check if this is an XML parsing error
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) || bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseListMcpImagesXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
-		log.Debugf("[DEBUG] ClientWrapper: SDK API call failed: %v", err)
-		return nil, err
+		log.Debugf("[DEBUG] ClientWrapper: ListMcpImages SDK call failed: %v", err)
+		return decodeRecordedResponse[client.ListMcpImagesResponse](ctx, "ListMcpImages", err)
 	}
-	log.Debugf("[DEBUG] ClientWrapper: SDK API call completed successfully")
+
+	log.Debugf("[DEBUG] ClientWrapper: ListMcpImages completed successfully")
 	return resp, nil
 }
 
+// CreateResourceGroup wraps the SDK client method. It is not idempotent and is never retried.
 func (cw *clientWrapper) CreateResourceGroup(ctx context.Context, request *client.CreateResourceGroupRequest) (*client.CreateResourceGroupResponse, error) {
+	return callWithRetry(ctx, cw, "CreateResourceGroup", false, func(ctx context.Context) (*client.CreateResourceGroupResponse, error) {
+		return cw.createResourceGroup(ctx, request)
+	})
+}
+
+// createResourceGroup performs a single CreateResourceGroup attempt
+func (cw *clientWrapper) createResourceGroup(ctx context.Context, request *client.CreateResourceGroupRequest) (*client.CreateResourceGroupResponse, error) {
 	log.Debugf("[DEBUG] ClientWrapper: CreateResourceGroup called")
 
 	// Log request details in verbose mode
@@ -1013,58 +511,24 @@ func (cw *clientWrapper) CreateResourceGroup(ctx context.Context, request *clien
 
 	// Call the underlying SDK method
 	resp, err := sdkClient.CreateResourceGroupWithContext(ctx, request, runtimeOptions)
-
-	// Log detailed response information in verbose mode
-	if log.GetLevel() >= log.DebugLevel {
-		if err != nil {
-			// Check if this is a known XML parsing error
-			errStr := err.Error()
-			if bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) || bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-				log.Debugf("[DEBUG] CreateResourceGroup HTTP Response: XML format detected, will use custom parser")
-			} else {
-				log.Debugf("[DEBUG] CreateResourceGroup HTTP Response Error: %v", err)
-				log.Debugf("[DEBUG] Error type: %T", err)
-				log.Debugf("[DEBUG] Error string: %s", err.Error())
-			}
-		} else {
-			log.Debugf("[DEBUG] CreateResourceGroup request completed successfully")
-		}
-	}
-
 	if err != nil {
-		// Check if this is an XML parsing error
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) || bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseCreateResourceGroupXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
 		log.Debugf("[DEBUG] ClientWrapper: CreateResourceGroup SDK call failed: %v", err)
-		return nil, err
+		return decodeRecordedResponse[client.CreateResourceGroupResponse](ctx, "CreateResourceGroup", err)
 	}
 
 	log.Debugf("[DEBUG] ClientWrapper: CreateResourceGroup completed successfully")
 	return resp, nil
 }
 
+// DeleteResourceGroup wraps the SDK client method
 func (cw *clientWrapper) DeleteResourceGroup(ctx context.Context, request *client.DeleteResourceGroupRequest) (*client.DeleteResourceGroupResponse, error) {
+	return callWithRetry(ctx, cw, "DeleteResourceGroup", true, func(ctx context.Context) (*client.DeleteResourceGroupResponse, error) {
+		return cw.deleteResourceGroup(ctx, request)
+	})
+}
+
+// deleteResourceGroup performs a single DeleteResourceGroup attempt
+func (cw *clientWrapper) deleteResourceGroup(ctx context.Context, request *client.DeleteResourceGroupRequest) (*client.DeleteResourceGroupResponse, error) {
 	log.Debugf("[DEBUG] ClientWrapper: DeleteResourceGroup called")
 	if log.GetLevel() >= log.DebugLevel {
 		log.Debugf("[DEBUG] ClientWrapper: Request ImageId = %v", request.GetImageId())
@@ -1090,143 +554,24 @@ func (cw *clientWrapper) DeleteResourceGroup(ctx context.Context, request *clien
 
 	// Call the underlying SDK method
 	resp, err := sdkClient.DeleteResourceGroupWithContext(ctx, request, runtimeOptions)
-
-	// Log detailed response information in verbose mode
-	if log.GetLevel() >= log.DebugLevel {
-		if err != nil {
-			// Check if this is a known XML parsing error
-			errStr := err.Error()
-			if bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) || bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-				log.Debugf("[DEBUG] DeleteResourceGroup HTTP Response: XML format detected, will use custom parser")
-			} else {
-				log.Debugf("[DEBUG] DeleteResourceGroup HTTP Response Error: %v", err)
-				log.Debugf("[DEBUG] Error type: %T", err)
-				log.Debugf("[DEBUG] Error string: %s", err.Error())
-			}
-		} else {
-			log.Debugf("[DEBUG] DeleteResourceGroup request completed successfully")
-		}
-	}
-
 	if err != nil {
-		// Check if this is an XML parsing error
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) || bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseDeleteResourceGroupXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
 		log.Debugf("[DEBUG] ClientWrapper: DeleteResourceGroup SDK call failed: %v", err)
-		return nil, err
+		return decodeRecordedResponse[client.DeleteResourceGroupResponse](ctx, "DeleteResourceGroup", err)
 	}
 
 	log.Debugf("[DEBUG] ClientWrapper: DeleteResourceGroup completed successfully")
 	return resp, nil
 }
 
-// parseGetMcpImageInfoXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseGetMcpImageInfoXMLResponse(xmlData []byte) (*client.GetMcpImageInfoResponse, error) {
-	log.Debugf("[DEBUG] Parsing GetMcpImageInfo XML data: %s", string(xmlData))
-
-	var xmlResp XMLGetMcpImageInfoResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] GetMcpImageInfo XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse GetMcpImageInfo XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed GetMcpImageInfo XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - Message: %s", xmlResp.Message)
-	log.Debugf("[DEBUG] - ImageId: %s", xmlResp.Data.ImageId)
-	log.Debugf("[DEBUG] - ImageBuildType: %s", xmlResp.Data.ImageBuildType)
-	log.Debugf("[DEBUG] - ImageResourceStatus: %s", xmlResp.Data.ImageResourceStatus)
-
-	// Convert ImageInfo
-	var imageInfo *client.GetMcpImageInfoResponseBodyDataImageInfo
-	if xmlResp.Data.ImageInfo.OsName != "" || xmlResp.Data.ImageInfo.Status != "" {
-		imageInfo = &client.GetMcpImageInfoResponseBodyDataImageInfo{
-			OsName:         dara.String(xmlResp.Data.ImageInfo.OsName),
-			OsVersion:      dara.String(xmlResp.Data.ImageInfo.OsVersion),
-			PlatformName:   dara.String(xmlResp.Data.ImageInfo.PlatformName),
-			Status:         dara.String(xmlResp.Data.ImageInfo.Status),
-			DataDiskSize:   dara.Int32(xmlResp.Data.ImageInfo.DataDiskSize),
-			SystemDiskSize: dara.Int32(xmlResp.Data.ImageInfo.SystemDiskSize),
-			UpdateTime:     dara.String(xmlResp.Data.ImageInfo.UpdateTime),
-		}
-	}
-
-	// Convert ImageBuildInfo
-	var imageBuildInfo *client.GetMcpImageInfoResponseBodyDataImageBuildInfo
-	if xmlResp.Data.ImageBuildInfo.TaskId != "" || xmlResp.Data.ImageBuildInfo.VersionId != "" {
-		imageBuildInfo = &client.GetMcpImageInfoResponseBodyDataImageBuildInfo{
-			TaskId:                  dara.String(xmlResp.Data.ImageBuildInfo.TaskId),
-			VersionId:               dara.String(xmlResp.Data.ImageBuildInfo.VersionId),
-			ApiKeyId:                dara.String(xmlResp.Data.ImageBuildInfo.ApiKeyId),
-			InstanceReady:           dara.Bool(xmlResp.Data.ImageBuildInfo.InstanceReady),
-			AndroidMobileGroupId:    dara.String(xmlResp.Data.ImageBuildInfo.AndroidMobileGroupId),
-			AndroidMobileInstanceId: dara.String(xmlResp.Data.ImageBuildInfo.AndroidMobileInstanceId),
-		}
-	}
-
-	// Convert to SDK response format
-	response := &client.GetMcpImageInfoResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.GetMcpImageInfoResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Message:        dara.String(xmlResp.Message),
-			Data: &client.GetMcpImageInfoResponseBodyData{
-				ImageId:         dara.String(xmlResp.Data.ImageId),
-				ImageName:       dara.String(xmlResp.Data.ImageName),
-				ImageBuildType:  dara.String(xmlResp.Data.ImageBuildType),
-				ImageApplyScene: dara.String(xmlResp.Data.ImageApplyScene),
-				ImageInfo:       imageInfo,
-				ImageBuildInfo:  imageBuildInfo,
-			},
-		},
-	}
-
-	// Store ImageResourceStatus and ImageType in response headers as SDK model doesn't have these fields
-	// This allows us to retrieve them from the response when needed
-	if xmlResp.Data.ImageResourceStatus != "" {
-		response.Headers["X-Image-Resource-Status"] = dara.String(xmlResp.Data.ImageResourceStatus)
-		log.Debugf("[DEBUG] Stored ImageResourceStatus in header: %s", xmlResp.Data.ImageResourceStatus)
-	}
-
-	if xmlResp.Data.ImageInfo.ImageType != "" {
-		response.Headers["X-Image-Type"] = dara.String(xmlResp.Data.ImageInfo.ImageType)
-		log.Debugf("[DEBUG] Stored ImageType in header: %s", xmlResp.Data.ImageInfo.ImageType)
-	}
-
-	log.Debugf("[DEBUG] Created GetMcpImageInfo SDK response")
-	return response, nil
-}
-
 // GetMcpImageInfo wraps the SDK client method
 func (cw *clientWrapper) GetMcpImageInfo(ctx context.Context, request *client.GetMcpImageInfoRequest) (*client.GetMcpImageInfoResponse, error) {
+	return callWithRetry(ctx, cw, "GetMcpImageInfo", true, func(ctx context.Context) (*client.GetMcpImageInfoResponse, error) {
+		return cw.getMcpImageInfo(ctx, request)
+	})
+}
+
+// getMcpImageInfo performs a single GetMcpImageInfo attempt
+func (cw *clientWrapper) getMcpImageInfo(ctx context.Context, request *client.GetMcpImageInfoRequest) (*client.GetMcpImageInfoResponse, error) {
 	log.Debugf("[DEBUG] ClientWrapper: GetMcpImageInfo called")
 	if log.GetLevel() >= log.DebugLevel {
 		log.Debugf("[DEBUG] ClientWrapper: Request ImageId = %v", request.GetImageId())
@@ -1252,138 +597,24 @@ func (cw *clientWrapper) GetMcpImageInfo(ctx context.Context, request *client.Ge
 
 	// Call the underlying SDK method
 	resp, err := sdkClient.GetMcpImageInfoWithContext(ctx, request, runtimeOptions)
-
-	// Log detailed response information in verbose mode
-	if log.GetLevel() >= log.DebugLevel {
-		if err != nil {
-			// Check if this is a known XML parsing error
-			errStr := err.Error()
-			if bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) || bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-				log.Debugf("[DEBUG] GetMcpImageInfo HTTP Response: XML format detected, will use custom parser")
-			} else {
-				log.Debugf("[DEBUG] GetMcpImageInfo HTTP Response Error: %v", err)
-				log.Debugf("[DEBUG] Error type: %T", err)
-				log.Debugf("[DEBUG] Error string: %s", err.Error())
-			}
-		} else {
-			log.Debugf("[DEBUG] GetMcpImageInfo request completed successfully")
-		}
-	}
-
 	if err != nil {
-		// Check if this is an XML parsing error
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) || bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseGetMcpImageInfoXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
 		log.Debugf("[DEBUG] ClientWrapper: GetMcpImageInfo SDK call failed: %v", err)
-		return nil, err
+		return decodeRecordedResponse[client.GetMcpImageInfoResponse](ctx, "GetMcpImageInfo", err)
 	}
 
 	log.Debugf("[DEBUG] ClientWrapper: GetMcpImageInfo completed successfully")
 	return resp, nil
 }
 
-// XMLGetDockerfileTemplateResponse represents the XML response structure for GetDockerfileTemplate
-type XMLGetDockerfileTemplateResponse struct {
-	XMLName        xml.Name `xml:"GetDockerfileTemplateResponse"`
-	RequestId      string   `xml:"RequestId"`
-	HttpStatusCode int      `xml:"HttpStatusCode"`
-	Data           struct {
-		OssDownloadUrl    string `xml:"OssDownloadUrl"`
-		NonEditLineNum    string `xml:"NonEditLineNum"`
-		DockerfileContent string `xml:"DockerfileContent"`
-	} `xml:"Data"`
-	Code    string `xml:"Code"`
-	Success bool   `xml:"Success"`
-	Message string `xml:"Message"`
-}
-
-// parseGetDockerfileTemplateXMLResponse parses XML response and converts it to SDK response format
-func (cw *clientWrapper) parseGetDockerfileTemplateXMLResponse(xmlData []byte) (*client.GetDockerfileTemplateResponse, error) {
-	log.Debugf("[DEBUG] Parsing GetDockerfileTemplate XML data: %s", string(xmlData))
-
-	var xmlResp XMLGetDockerfileTemplateResponse
-	if err := xml.Unmarshal(xmlData, &xmlResp); err != nil {
-		log.Debugf("[DEBUG] GetDockerfileTemplate XML unmarshal failed: %v", err)
-		return nil, fmt.Errorf("failed to parse GetDockerfileTemplate XML response: %w", err)
-	}
-
-	log.Debugf("[DEBUG] Parsed GetDockerfileTemplate XML data:")
-	log.Debugf("[DEBUG] - RequestId: %s", xmlResp.RequestId)
-	log.Debugf("[DEBUG] - HttpStatusCode: %d", xmlResp.HttpStatusCode)
-	log.Debugf("[DEBUG] - Code: %s", xmlResp.Code)
-	log.Debugf("[DEBUG] - Success: %t", xmlResp.Success)
-	log.Debugf("[DEBUG] - Message: %s", xmlResp.Message)
-	log.Debugf("[DEBUG] - OssDownloadUrl: %s", xmlResp.Data.OssDownloadUrl)
-	log.Debugf("[DEBUG] - NonEditLineNum: %s", xmlResp.Data.NonEditLineNum)
-	log.Debugf("[DEBUG] - DockerfileContent length: %d", len(xmlResp.Data.DockerfileContent))
-
-	// Parse NonEditLineNum from string to int32
-	var nonEditLineNum *int32
-	if xmlResp.Data.NonEditLineNum != "" {
-		if num, err := strconv.ParseInt(xmlResp.Data.NonEditLineNum, 10, 32); err == nil {
-			num32 := int32(num)
-			nonEditLineNum = &num32
-			log.Debugf("[DEBUG] Parsed NonEditLineNum: %d", *nonEditLineNum)
-		} else {
-			log.Debugf("[DEBUG] Failed to parse NonEditLineNum '%s': %v", xmlResp.Data.NonEditLineNum, err)
-		}
-	}
-
-	// Convert to SDK response format
-	responseData := &client.GetDockerfileTemplateResponseBodyData{
-		OssDownloadUrl: dara.String(xmlResp.Data.OssDownloadUrl),
-	}
-
-	// Set NonEditLineNum if available
-	if nonEditLineNum != nil {
-		responseData.NonEditLineNum = nonEditLineNum
-	}
-
-	// Set DockerfileContent if available
-	if xmlResp.Data.DockerfileContent != "" {
-		responseData.DockerfileContent = dara.String(xmlResp.Data.DockerfileContent)
-	}
-
-	response := &client.GetDockerfileTemplateResponse{
-		Headers:    make(map[string]*string),
-		StatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-		Body: &client.GetDockerfileTemplateResponseBody{
-			RequestId:      dara.String(xmlResp.RequestId),
-			HttpStatusCode: dara.Int32(int32(xmlResp.HttpStatusCode)),
-			Code:           dara.String(xmlResp.Code),
-			Success:        dara.Bool(xmlResp.Success),
-			Message:        dara.String(xmlResp.Message),
-			Data:           responseData,
-		},
-	}
-
-	log.Debugf("[DEBUG] Created GetDockerfileTemplate SDK response with OssDownloadUrl: %s", xmlResp.Data.OssDownloadUrl)
-	return response, nil
-}
-
 // GetDockerfileTemplate wraps the SDK client method
 func (cw *clientWrapper) GetDockerfileTemplate(ctx context.Context, request *client.GetDockerfileTemplateRequest) (*client.GetDockerfileTemplateResponse, error) {
+	return callWithRetry(ctx, cw, "GetDockerfileTemplate", true, func(ctx context.Context) (*client.GetDockerfileTemplateResponse, error) {
+		return cw.getDockerfileTemplate(ctx, request)
+	})
+}
+
+// getDockerfileTemplate performs a single GetDockerfileTemplate attempt
+func (cw *clientWrapper) getDockerfileTemplate(ctx context.Context, request *client.GetDockerfileTemplateRequest) (*client.GetDockerfileTemplateResponse, error) {
 	sdkClient, err := cw.getClient()
 	if err != nil {
 		return nil, err
@@ -1397,82 +628,11 @@ func (cw *clientWrapper) GetDockerfileTemplate(ctx context.Context, request *cli
 		log.Debugf("[DEBUG] Making GetDockerfileTemplate request...")
 	}
 
-	// Validate request
-	if err := request.Validate(); err != nil {
-		return nil, err
-	}
-
-	// Prepare query parameters
-	query := map[string]interface{}{}
-	if !dara.IsNil(request.Source) {
-		query["Source"] = request.Source
-	}
-	if !dara.IsNil(request.SourceImageId) {
-		query["SourceImageId"] = request.SourceImageId
-	}
-	// Template is reserved for future extension
-	if !dara.IsNil(request.Template) {
-		query["Template"] = request.Template
-	}
-
-	// Prepare API request
-	apiRequest := &openapiutil.OpenApiRequest{
-		Query: openapiutil.Query(query),
-		Headers: map[string]*string{
-			"Accept": dara.String("application/json"),
-		},
-	}
-
-	// Prepare API parameters
-	params := &openapiutil.Params{
-		Action:      dara.String("GetDockerfileTemplate"),
-		Version:     dara.String("2025-05-01"),
-		Protocol:    dara.String("HTTPS"),
-		Pathname:    dara.String("/"),
-		Method:      dara.String("GET"),
-		AuthType:    dara.String("AK"),
-		Style:       dara.String("RPC"),
-		ReqBodyType: dara.String("formData"),
-		BodyType:    dara.String("json"),
-	}
-
 	// Call API
-	result := &client.GetDockerfileTemplateResponse{}
-	body, err := sdkClient.CallApi(params, apiRequest, runtimeOptions)
+	result, err := sdkClient.GetDockerfileTemplateWithContext(ctx, request, runtimeOptions)
 	if err != nil {
-		log.Debugf("[DEBUG] GetDockerfileTemplate API call failed: %v", err)
-
-		// Check if this is an XML parsing error (in case API returns XML instead of JSON)
-		errStr := err.Error()
-		if bytes.Contains([]byte(errStr), []byte("readObjectStart: expect { or n, but found")) || bytes.Contains([]byte(errStr), []byte("invalid character '<' looking for beginning of value")) {
-			log.Debugf("[DEBUG] SDK returned XML response, using custom XML parser...")
-
-			// Use cached XML response if available
-			if xmlResponseCache != "" {
-				log.Debugf("[DEBUG] Parsing cached XML response...")
-
-				// Parse the cached XML directly
-				customResponse, parseErr := cw.parseGetDockerfileTemplateXMLResponse([]byte(xmlResponseCache))
-				if parseErr != nil {
-					log.Debugf("[DEBUG] Custom XML parsing failed: %v", parseErr)
-					return nil, fmt.Errorf("XML parsing failed: %w", parseErr)
-				}
-
-				log.Debugf("[DEBUG] XML response parsed successfully")
-				return customResponse, nil
-			} else {
-				log.Debugf("[DEBUG] No cached XML response available")
-				return nil, fmt.Errorf("XML parsing failed and no cached response available: %w", err)
-			}
-		}
-
-		return nil, err
-	}
-
-	// Convert response body to result
-	if err := dara.Convert(body, &result); err != nil {
-		log.Debugf("[DEBUG] Failed to convert response: %v", err)
-		return nil, fmt.Errorf("failed to convert response: %w", err)
+		log.Debugf("[DEBUG] ClientWrapper: GetDockerfileTemplate SDK call failed: %v", err)
+		return decodeRecordedResponse[client.GetDockerfileTemplateResponse](ctx, "GetDockerfileTemplate", err)
 	}
 
 	log.Debugf("[DEBUG] ClientWrapper: GetDockerfileTemplate completed successfully")
@@ -0,0 +1,72 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// responseRecorder captures the last HTTP response seen by one API attempt. The
+// SDK hides Retry-After, drops the status of non-JSON error bodies and cannot
+// decode XML bodies, so debugTransport records them here. Each attempt gets its
+// own recorder through the request context, so concurrent calls never share one.
+type responseRecorder struct {
+	mu         sync.Mutex
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+type responseRecorderKey struct{}
+
+// withResponseRecorder returns a context whose API calls report responses to rec
+func withResponseRecorder(ctx context.Context, rec *responseRecorder) context.Context {
+	return context.WithValue(ctx, responseRecorderKey{}, rec)
+}
+
+// responseRecorderFromContext returns the recorder attached to ctx, or nil
+func responseRecorderFromContext(ctx context.Context) *responseRecorder {
+	if ctx == nil {
+		return nil
+	}
+	rec, _ := ctx.Value(responseRecorderKey{}).(*responseRecorder)
+	return rec
+}
+
+// record stores the response status, headers and body; safe to call on a nil recorder
+func (r *responseRecorder) record(resp *http.Response, body []byte) {
+	if r == nil || resp == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statusCode = resp.StatusCode
+	r.header = resp.Header.Clone()
+	r.body = body
+}
+
+// last returns the recorded status code and Retry-After header value
+func (r *responseRecorder) last() (int, string) {
+	if r == nil {
+		return 0, ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.header == nil {
+		return r.statusCode, ""
+	}
+	return r.statusCode, r.header.Get("Retry-After")
+}
+
+// response returns the recorded status code, headers and body
+func (r *responseRecorder) response() (int, http.Header, []byte) {
+	if r == nil {
+		return 0, nil, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.statusCode, r.header, r.body
+}
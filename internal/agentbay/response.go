@@ -0,0 +1,84 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/alibabacloud-go/tea/dara"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// responseError is returned for an error response the SDK could not read (it only
+// decodes JSON). It exposes the HTTP status like the SDK's own errors so retry
+// classification treats both the same.
+type responseError struct {
+	StatusCode int
+	RequestId  string
+	Code       string
+	Message    string
+}
+
+func (e *responseError) Error() string {
+	msg := fmt.Sprintf("request failed with HTTP %d", e.StatusCode)
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Message != "" {
+		msg += " - " + e.Message
+	}
+	if e.RequestId != "" {
+		msg += " (RequestId: " + e.RequestId + ")"
+	}
+	return msg
+}
+
+// GetStatusCode returns the HTTP status code of the response
+func (e *responseError) GetStatusCode() *int {
+	return &e.StatusCode
+}
+
+// decodeRecordedResponse recovers from an SDK call that failed because the service
+// answered in a format the SDK cannot read: the SDK only reads JSON, while the service
+// may answer in XML. Every wrapper method calls it when its SDK call fails. It decodes
+// the response recorded for this attempt into T using client.DecodeResponse; if the
+// recorded response is JSON, or nothing was recorded, the SDK error is returned unchanged.
+func decodeRecordedResponse[T any](ctx context.Context, action string, sdkErr error) (*T, error) {
+	status, header, body := responseRecorderFromContext(ctx).response()
+	if status == 0 || len(body) == 0 {
+		return nil, sdkErr
+	}
+	if client.ResponseFormat(header.Get("Content-Type"), body) == client.FormatJSON {
+		return nil, sdkErr
+	}
+	log.Debugf("[DEBUG] %s: decoding %s response (HTTP %d, Content-Type %q)", action, client.FormatXML, status, header.Get("Content-Type"))
+
+	if status >= http.StatusBadRequest {
+		var envelope struct {
+			RequestId *string
+			Code      *string
+			Message   *string
+		}
+		if err := client.DecodeResponseBody(header.Get("Content-Type"), body, &envelope); err != nil {
+			log.Debugf("[DEBUG] %s: failed to decode error response: %v", action, err)
+		}
+		return nil, &responseError{
+			StatusCode: status,
+			RequestId:  dara.StringValue(envelope.RequestId),
+			Code:       dara.StringValue(envelope.Code),
+			Message:    dara.StringValue(envelope.Message),
+		}
+	}
+
+	out := new(T)
+	if err := client.DecodeResponse(status, header, body, out); err != nil {
+		log.Debugf("[DEBUG] %s: %v", action, err)
+		return nil, fmt.Errorf("%s: %w", action, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// retryConfig builds the retry policy from the API configuration, falling back to
+// client.DefaultRetryConfig for unset delays.
+func (cw *clientWrapper) retryConfig() *client.RetryConfig {
+	cfg := client.DefaultRetryConfig()
+	if cw.apiConfig == nil {
+		return cfg
+	}
+	cfg.MaxRetries = cw.apiConfig.MaxRetries
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cw.apiConfig.RetryInitialDelayMs > 0 {
+		cfg.InitialDelay = time.Duration(cw.apiConfig.RetryInitialDelayMs) * time.Millisecond
+	}
+	if cw.apiConfig.RetryMaxDelayMs > 0 {
+		cfg.MaxDelay = time.Duration(cw.apiConfig.RetryMaxDelayMs) * time.Millisecond
+	}
+	return cfg
+}
+
+// callWithRetry runs call with the wrapper's retry policy. Non-idempotent calls are
+// attempted exactly once.
+func callWithRetry[T any](ctx context.Context, cw *clientWrapper, action string, idempotent bool, call func(ctx context.Context) (T, error)) (T, error) {
+	cfg := cw.retryConfig()
+	if !idempotent {
+		cfg.MaxRetries = 0
+	}
+
+	var rec *responseRecorder
+	return client.DoWithRetry(ctx, cfg, func(ctx context.Context, attempt int) (T, error) {
+		if attempt > 0 {
+			log.Debugf("[DEBUG] %s: retry %d/%d", action, attempt, cfg.MaxRetries)
+		}
+		rec = &responseRecorder{}
+		return call(withResponseRecorder(ctx, rec))
+	}, func(err error) client.RetryDecision {
+		decision := retryDecision(err, rec)
+		if decision.Retry {
+			log.Debugf("[DEBUG] %s failed with retryable error: %v", action, err)
+		}
+		return decision
+	})
+}
+
+// retryDecision classifies a failed attempt. HTTP status (from the SDK error or the
+// recorded response) wins over error-text heuristics; Retry-After and throttling
+// hints become the minimum wait.
+func retryDecision(err error, rec *responseRecorder) client.RetryDecision {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return client.RetryDecision{}
+	}
+
+	status := client.StatusCodeFromError(err)
+	wait := client.RetryAfterFromError(err)
+	if recStatus, retryAfter := rec.last(); recStatus != 0 {
+		if status == 0 {
+			status = recStatus
+		}
+		if d, ok := client.ParseRetryAfter(retryAfter, time.Now()); ok && d > wait {
+			wait = d
+		}
+	}
+
+	if status >= http.StatusBadRequest {
+		return client.RetryDecision{Retry: client.IsRetryableHTTPStatus(status), Wait: wait}
+	}
+	if status != 0 {
+		// A 2xx/3xx response that failed to decode will not improve on retry
+		return client.RetryDecision{}
+	}
+	return client.RetryDecision{Retry: client.IsRetryableError(err), Wait: wait}
+}
@@ -6,9 +6,13 @@ package auth
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net"
 	"net/http"
@@ -16,11 +20,13 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"testing"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/agentbay/agentbay-cli/internal/client"
+	"github.com/agentbay/agentbay-cli/internal/config"
 )
 
 // OAuth region: "domestic" (aliyun.com) or "international" (alibabacloud.com).
@@ -44,35 +50,77 @@ const (
 	revokeEndpointInternational = "https://oauth.alibabacloud.com/v1/revoke"
 )
 
-// isInternationalEnv returns true when AGENTBAY_ENV indicates international (prod or pre).
-func isInternationalEnv() bool {
-	env := strings.ToLower(strings.TrimSpace(os.Getenv("AGENTBAY_ENV")))
-	switch env {
-	case "international", "prod-international", "intl", "international-prod",
-		"international-pre", "pre-international", "intl-pre", "staging-international":
-		return true
-	}
-	return false
-}
+// Device authorization endpoints (RFC 8628). These follow the paths of the token
+// endpoints but are not in the published OAuth documentation, so device login is only
+// used when asked for with 'login --device'.
+const (
+	deviceEndpointDomestic      = "https://oauth.aliyun.com/v1/device/code"
+	deviceEndpointInternational = "https://oauth.alibabacloud.com/v1/device/code"
+)
+
+// oauthURLEnv points test binaries at a local fake OAuth server, which then serves all
+// endpoints with the usual paths. It is ignored outside of tests, so that a release
+// build never sends codes or tokens to another host.
+const oauthURLEnv = "AGENTBAY_OAUTH_URL"
 
 // getOAuthEndpoints returns auth, token, and revoke URLs.
 // Uses AGENTBAY_OAUTH_REGION if set; otherwise when AGENTBAY_ENV is international production,
 // uses international endpoints. Else domestic (aliyun.com).
 func getOAuthEndpoints() (auth, token, revoke string) {
-	region := strings.ToLower(strings.TrimSpace(os.Getenv("AGENTBAY_OAUTH_REGION")))
-	if region == "" && isInternationalEnv() {
-		region = oauthRegionInternational
+	if base := oauthURLOverride(); base != "" {
+		return base + "/oauth2/v1/auth", base + "/v1/token", base + "/v1/revoke"
 	}
-	if region == oauthRegionInternational {
+	if getOAuthRegion() == oauthRegionInternational {
 		log.Debugf("[DEBUG] Using international OAuth endpoints (signin.alibabacloud.com)")
 		return authEndpointInternational, tokenEndpointInternational, revokeEndpointInternational
 	}
 	return authEndpointDomestic, tokenEndpointDomestic, revokeEndpointDomestic
 }
 
+// getDeviceAuthorizationEndpoint returns the device authorization URL for the same
+// region as getOAuthEndpoints
+func getDeviceAuthorizationEndpoint() string {
+	if base := oauthURLOverride(); base != "" {
+		return base + "/v1/device/code"
+	}
+	if getOAuthRegion() == oauthRegionInternational {
+		return deviceEndpointInternational
+	}
+	return deviceEndpointDomestic
+}
+
+// getOAuthRegion returns AGENTBAY_OAUTH_REGION or the region of the active profile,
+// defaulting to international when the environment is international and to domestic
+// otherwise
+func getOAuthRegion() string {
+	region := strings.ToLower(strings.TrimSpace(os.Getenv("AGENTBAY_OAUTH_REGION")))
+	if region == "" {
+		region = config.ActiveProfile().Region
+	}
+	if region == "" && config.GetEnvironment().IsInternational() {
+		region = oauthRegionInternational
+	}
+	return region
+}
+
+// oauthURLOverride returns the OAuth server set by AGENTBAY_OAUTH_URL without a trailing
+// slash, or "" when not running as a test
+func oauthURLOverride() string {
+	if !testing.Testing() {
+		return ""
+	}
+	base := strings.TrimRight(strings.TrimSpace(os.Getenv(oauthURLEnv)), "/")
+	if base != "" {
+		log.Debugf("[DEBUG] Using OAuth server from %s: %s", oauthURLEnv, base)
+	}
+	return base
+}
+
 // OAuth client configuration
 const (
 	DefaultClientID = "4019057658592127596"
+	// oauthScope is the scope requested for CLI tokens
+	oauthScope = "/acs/xiaoying"
 )
 
 // TokenResponse represents the OAuth token response
@@ -91,27 +139,37 @@ type RefreshResponse struct {
 	ExpiresIn   int    `json:"expires_in"`
 }
 
-// BuildAuthURL constructs the OAuth authorization URL
-func BuildAuthURL(clientID, redirectURI, state string) string {
+// BuildAuthURL constructs the OAuth authorization URL. codeChallenge is the S256 PKCE
+// challenge from GeneratePKCE; it is omitted when empty.
+func BuildAuthURL(clientID, redirectURI, state, codeChallenge string) string {
 	authURL, _, _ := getOAuthEndpoints()
 	params := url.Values{}
 	params.Set("client_id", clientID)
 	params.Set("redirect_uri", redirectURI)
 	params.Set("response_type", "code")
 	params.Set("state", state)
-	params.Set("scope", "/acs/xiaoying")
+	params.Set("scope", oauthScope)
+	if codeChallenge != "" {
+		params.Set("code_challenge", codeChallenge)
+		params.Set("code_challenge_method", "S256")
+	}
 
 	return authURL + "?" + params.Encode()
 }
 
-// ExchangeCodeForToken exchanges authorization code for access token
-func ExchangeCodeForToken(clientID, redirectURI, code string) (*TokenResponse, error) {
+// ExchangeCodeForToken exchanges authorization code for access token. codeVerifier is
+// the PKCE verifier whose challenge was sent with the authorization request; it is
+// omitted when empty.
+func ExchangeCodeForToken(clientID, redirectURI, code, codeVerifier string) (*TokenResponse, error) {
 	_, tokenURL, _ := getOAuthEndpoints()
 	data := url.Values{}
 	data.Set("code", code)
 	data.Set("client_id", clientID)
 	data.Set("redirect_uri", redirectURI)
 	data.Set("grant_type", "authorization_code")
+	if codeVerifier != "" {
+		data.Set("code_verifier", codeVerifier)
+	}
 
 	resp, err := http.PostForm(tokenURL, data)
 	if err != nil {
@@ -120,7 +178,7 @@ func ExchangeCodeForToken(clientID, redirectURI, code string) (*TokenResponse, e
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token exchange failed with status: %d", resp.StatusCode)
+		return nil, fmt.Errorf("token exchange failed with status: %d%s", resp.StatusCode, describeOAuthError(resp.Body))
 	}
 
 	var tokenResponse TokenResponse
@@ -236,9 +294,12 @@ func checkPortAvailabilityWithRetry(port string, retryConfig *client.RetryConfig
 	return false, fmt.Errorf("port %s is still occupied after %d attempts", port, retryConfig.MaxRetries+1)
 }
 
-// StartCallbackServer starts a local HTTP server to handle OAuth callbacks
+// StartCallbackServer starts a local HTTP server to handle OAuth callbacks and returns
+// the authorization code. A callback whose state differs from state fails with
+// ErrStateMismatch, and one carrying an error (e.g. the user denied consent) fails
+// with that error; the browser is shown an error page in both cases.
 // It binds the port first to ensure atomic port acquisition
-func StartCallbackServer(ctx context.Context, port string) (string, error) {
+func StartCallbackServer(ctx context.Context, port, state string) (string, error) {
 	// Bind port first (atomic operation - binding means we own the port)
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
@@ -260,19 +321,18 @@ func StartCallbackServer(ctx context.Context, port string) (string, error) {
 		defer wg.Done()
 
 		// Get authorization code
-		code = r.URL.Query().Get("code")
-		if code == "" {
-			serverErr = fmt.Errorf("no code in callback")
-			http.Error(w, "No code", http.StatusBadRequest)
-			return
-		}
-
-		// Return success page
+		code, serverErr = codeFromCallback(r.URL.Query(), state)
 		w.Header().Set("Content-Type", "text/html")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(GetSuccessHTML()))
+		if serverErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(GetErrorHTML(serverErr.Error())))
+		} else {
+			// Return success page
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(GetSuccessHTML()))
+		}
 
-		// Delay server close to ensure browser receives the success page
+		// Delay server close to ensure browser receives the page
 		go func() {
 			time.Sleep(500 * time.Millisecond)
 			server.Close()
@@ -296,10 +356,8 @@ func StartCallbackServer(ctx context.Context, port string) (string, error) {
 
 	select {
 	case <-done:
-		// Callback received
+		// Callback received; the handler closes the server once the page is sent
 		if serverErr != nil {
-			server.Close()
-			listener.Close()
 			return "", serverErr
 		}
 		return code, nil
@@ -310,6 +368,49 @@ func StartCallbackServer(ctx context.Context, port string) (string, error) {
 	}
 }
 
+// ErrStateMismatch is returned when the state of an OAuth callback differs from the
+// state sent with the authorization request, e.g. a redirect from another login attempt
+var ErrStateMismatch = errors.New("OAuth state mismatch: the response does not belong to this login attempt, please try again")
+
+// ParseCallbackInput returns the authorization code from text pasted by the user: the
+// full redirect URL the browser was sent to, or its query string. The input must carry
+// expectedState, so a bare code, which cannot be tied to this login attempt, is rejected.
+func ParseCallbackInput(input, expectedState string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("no redirect URL entered")
+	}
+	if !strings.Contains(input, "://") && !strings.Contains(input, "=") {
+		return "", fmt.Errorf("paste the full redirect URL, including its state parameter, not only the code")
+	}
+
+	rawQuery := input
+	if u, err := url.Parse(input); err == nil && strings.Contains(input, "://") {
+		rawQuery = u.RawQuery
+	}
+	query, err := url.ParseQuery(strings.TrimPrefix(rawQuery, "?"))
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	return codeFromCallback(query, expectedState)
+}
+
+// codeFromCallback returns the authorization code from the query of an OAuth redirect,
+// checking its error and state parameters first
+func codeFromCallback(query url.Values, expectedState string) (string, error) {
+	if oauthErr := query.Get("error"); oauthErr != "" {
+		return "", fmt.Errorf("authorization failed: %s", formatOAuthError(oauthErrorResponse{Error: oauthErr, ErrorDescription: query.Get("error_description")}))
+	}
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(expectedState)) != 1 {
+		return "", ErrStateMismatch
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("no code in callback")
+	}
+	return code, nil
+}
+
 // GenerateState generates a random state parameter for OAuth
 func GenerateState() (string, error) {
 	bytes := make([]byte, 32)
@@ -319,6 +420,17 @@ func GenerateState() (string, error) {
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
 
+// GeneratePKCE generates a PKCE code verifier and its S256 code challenge (RFC 7636)
+func GeneratePKCE() (verifier, challenge string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", fmt.Errorf("failed to generate PKCE code verifier: %w", err)
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(bytes)
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
 // GetSuccessHTML returns the HTML page shown after successful authentication
 func GetSuccessHTML() string {
 	return `<!DOCTYPE html>
@@ -363,6 +475,50 @@ func GetSuccessHTML() string {
 </html>`
 }
 
+// GetErrorHTML returns the HTML page shown when authentication fails
+func GetErrorHTML(message string) string {
+	return `<!DOCTYPE html>
+<html>
+<head>
+    <title>Authentication Failed</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif;
+            display: flex;
+            justify-content: center;
+            align-items: center;
+            height: 100vh;
+            margin: 0;
+            background-color: #f5f5f5;
+        }
+        .container {
+            text-align: center;
+            background: white;
+            padding: 2rem;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0,0,0,0.1);
+        }
+        .error {
+            color: #dc3545;
+            font-size: 1.5rem;
+            margin-bottom: 1rem;
+        }
+        .message {
+            color: #666;
+            margin-bottom: 1rem;
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="error">Authentication Failed</div>
+        <div class="message">` + html.EscapeString(message) + `</div>
+        <div class="message">Please return to the terminal and run 'agentbay login' again.</div>
+    </div>
+</body>
+</html>`
+}
+
 // IsPortOccupied checks if a port is already in use
 func IsPortOccupied(port string) bool {
 	ln, err := net.Listen("tcp", ":"+port)
@@ -0,0 +1,179 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// deviceCodeGrantType is the grant type of the device access token request (RFC 8628 section 3.4)
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	// defaultDevicePollInterval is used when the server does not send an interval
+	defaultDevicePollInterval = 5 * time.Second
+	// deviceSlowDownIncrement is added to the interval on each slow_down response
+	deviceSlowDownIncrement = 5 * time.Second
+)
+
+var (
+	// ErrDeviceAccessDenied is returned when the user declines the authorization request
+	ErrDeviceAccessDenied = errors.New("authorization request was denied")
+	// ErrDeviceCodeExpired is returned when the user code expires before it is entered
+	ErrDeviceCodeExpired = errors.New("device code expired before authorization completed")
+)
+
+// DeviceAuthorization is the device authorization response (RFC 8628 section 3.2)
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"` // seconds
+	Interval                int    `json:"interval"`   // seconds between token requests
+}
+
+// oauthErrorResponse is the error body of the token endpoint (RFC 6749 section 5.2)
+type oauthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// RequestDeviceAuthorization starts the device authorization grant and returns the
+// code for the user to enter at the verification URI
+func RequestDeviceAuthorization(ctx context.Context, clientID string) (*DeviceAuthorization, error) {
+	data := url.Values{}
+	data.Set("client_id", clientID)
+	data.Set("scope", oauthScope)
+
+	resp, err := postForm(ctx, getDeviceAuthorizationEndpoint(), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device authorization: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device authorization failed with status: %d%s", resp.StatusCode, describeOAuthError(resp.Body))
+	}
+
+	var da DeviceAuthorization
+	if err := json.NewDecoder(resp.Body).Decode(&da); err != nil {
+		return nil, fmt.Errorf("failed to decode device authorization response: %w", err)
+	}
+	if da.DeviceCode == "" || da.UserCode == "" || da.VerificationURI == "" {
+		return nil, fmt.Errorf("invalid device authorization response: missing device_code, user_code or verification_uri")
+	}
+	return &da, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user has completed the
+// authorization started by RequestDeviceAuthorization. authorization_pending keeps
+// polling and slow_down increases the interval, as required by RFC 8628 section 3.5.
+// It returns ErrDeviceAccessDenied or ErrDeviceCodeExpired when the authorization
+// cannot complete.
+func PollDeviceToken(ctx context.Context, clientID string, da *DeviceAuthorization) (*TokenResponse, error) {
+	interval := time.Duration(da.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	parent := ctx
+	if da.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(da.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	_, tokenURL, _ := getOAuthEndpoints()
+	data := url.Values{}
+	data.Set("grant_type", deviceCodeGrantType)
+	data.Set("device_code", da.DeviceCode)
+	data.Set("client_id", clientID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			if parent.Err() != nil {
+				return nil, parent.Err()
+			}
+			return nil, ErrDeviceCodeExpired
+		case <-time.After(interval):
+		}
+
+		resp, err := postForm(ctx, tokenURL, data)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue // reported by the select above
+			}
+			return nil, fmt.Errorf("failed to request token: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token response: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			var tokenResponse TokenResponse
+			if err := json.Unmarshal(body, &tokenResponse); err != nil {
+				return nil, fmt.Errorf("failed to decode token response: %w", err)
+			}
+			return &tokenResponse, nil
+		}
+
+		var oauthErr oauthErrorResponse
+		_ = json.Unmarshal(body, &oauthErr)
+		switch oauthErr.Error {
+		case "authorization_pending":
+			log.Debugf("[DEBUG] Device authorization pending, polling again in %v", interval)
+		case "slow_down":
+			interval += deviceSlowDownIncrement
+			log.Debugf("[DEBUG] Device token endpoint asked to slow down, polling every %v", interval)
+		case "access_denied":
+			return nil, ErrDeviceAccessDenied
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		case "":
+			return nil, fmt.Errorf("token request failed with status: %d", resp.StatusCode)
+		default:
+			return nil, fmt.Errorf("token request failed: %s", formatOAuthError(oauthErr))
+		}
+	}
+}
+
+// postForm posts form data with ctx, like http.PostForm
+func postForm(ctx context.Context, endpoint string, data url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return http.DefaultClient.Do(req)
+}
+
+// describeOAuthError returns ", error: ..." for an OAuth error body, or "" when the
+// body is not one
+func describeOAuthError(body io.Reader) string {
+	var oauthErr oauthErrorResponse
+	if json.NewDecoder(body).Decode(&oauthErr) != nil || oauthErr.Error == "" {
+		return ""
+	}
+	return ", error: " + formatOAuthError(oauthErr)
+}
+
+// formatOAuthError formats an OAuth error code with its description
+func formatOAuthError(e oauthErrorResponse) string {
+	if e.ErrorDescription == "" {
+		return e.Error
+	}
+	return fmt.Sprintf("%s (%s)", e.Error, e.ErrorDescription)
+}
@@ -720,6 +720,87 @@ func (client *Client) GetDockerImageTask(request *GetDockerImageTaskRequest) (_r
 	return _result, _err
 }
 
+// Summary:
+//
+// 获取docker镜像任务构建日志
+//
+// Written by hand: GetDockerImageTaskLog is not in the API spec, and its request and
+// response fields are unconfirmed.
+//
+// @param request - GetDockerImageTaskLogRequest
+//
+// @param runtime - runtime options for this request RuntimeOptions
+//
+// @return GetDockerImageTaskLogResponse
+func (client *Client) GetDockerImageTaskLogWithOptions(request *GetDockerImageTaskLogRequest, runtime *dara.RuntimeOptions) (_result *GetDockerImageTaskLogResponse, _err error) {
+	_err = request.Validate()
+	if _err != nil {
+		return _result, _err
+	}
+	query := map[string]interface{}{}
+	if !dara.IsNil(request.MaxResults) {
+		query["MaxResults"] = request.MaxResults
+	}
+
+	if !dara.IsNil(request.NextToken) {
+		query["NextToken"] = request.NextToken
+	}
+
+	if !dara.IsNil(request.Source) {
+		query["Source"] = request.Source
+	}
+
+	if !dara.IsNil(request.TaskId) {
+		query["TaskId"] = request.TaskId
+	}
+
+	req := &openapiutil.OpenApiRequest{
+		Query: openapiutil.Query(query),
+		Headers: map[string]*string{
+			"Accept": dara.String("application/xml"),
+		},
+	}
+	params := &openapiutil.Params{
+		Action:      dara.String("GetDockerImageTaskLog"),
+		Version:     dara.String("2025-05-01"),
+		Protocol:    dara.String("HTTPS"),
+		Pathname:    dara.String("/"),
+		Method:      dara.String("POST"),
+		AuthType:    dara.String("AK"),
+		Style:       dara.String("RPC"),
+		ReqBodyType: dara.String("formData"),
+		BodyType:    dara.String("xml"),
+	}
+	_result = &GetDockerImageTaskLogResponse{}
+	_body, _err := client.CallApi(params, req, runtime)
+	if _err != nil {
+		return _result, _err
+	}
+	_err = dara.Convert(_body, &_result)
+	return _result, _err
+}
+
+// Summary:
+//
+// 获取docker镜像任务构建日志
+//
+// Written by hand: GetDockerImageTaskLog is not in the API spec, and its request and
+// response fields are unconfirmed.
+//
+// @param request - GetDockerImageTaskLogRequest
+//
+// @return GetDockerImageTaskLogResponse
+func (client *Client) GetDockerImageTaskLog(request *GetDockerImageTaskLogRequest) (_result *GetDockerImageTaskLogResponse, _err error) {
+	runtime := &dara.RuntimeOptions{}
+	_result = &GetDockerImageTaskLogResponse{}
+	_body, _err := client.GetDockerImageTaskLogWithOptions(request, runtime)
+	if _err != nil {
+		return _result, _err
+	}
+	_result = _body
+	return _result, _err
+}
+
 // Summary:
 //
 // 查询支持mcp镜像列表
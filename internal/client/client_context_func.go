@@ -54,7 +54,7 @@ func (client *Client) GetDockerFileStoreCredentialWithContext(ctx context.Contex
 		BodyType:    dara.String("xml"),
 	}
 	_result = &GetDockerFileStoreCredentialResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		return _result, _err
 	}
@@ -64,7 +64,40 @@ func (client *Client) GetDockerFileStoreCredentialWithContext(ctx context.Contex
 
 // GetMarketSkillCredentialWithContext 获取 Skill 上传凭证（OSS）
 func (client *Client) GetMarketSkillCredentialWithContext(ctx context.Context, request *GetMarketSkillCredentialRequest, runtime *dara.RuntimeOptions) (_result *GetMarketSkillCredentialResponse, _err error) {
-	return client.GetMarketSkillCredentialWithOptions(request, runtime)
+	_err = request.Validate()
+	if _err != nil {
+		return _result, _err
+	}
+	query := map[string]interface{}{}
+	if !dara.IsNil(request.FileName) {
+		query["FileName"] = request.FileName
+	}
+	req := &openapiutil.OpenApiRequest{
+		Query:   openapiutil.Query(query),
+		Headers: map[string]*string{"Accept": dara.String("application/json")},
+	}
+	params := &openapiutil.Params{
+		Action:      dara.String("GetMarketSkillCredential"),
+		Version:     dara.String("2025-05-01"),
+		Protocol:    dara.String("HTTPS"),
+		Pathname:    dara.String("/"),
+		Method:      dara.String("GET"),
+		AuthType:    dara.String("AK"),
+		Style:       dara.String("RPC"),
+		ReqBodyType: dara.String("formData"),
+		BodyType:    dara.String("string"),
+	}
+	_result = &GetMarketSkillCredentialResponse{}
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
+	if _err != nil {
+		reqID := ""
+		if _body != nil {
+			reqID = extractRequestIDFromResponse(_body)
+		}
+		return _result, &ErrWithRequestID{Err: _err, RequestID: reqID}
+	}
+	_result, _err = parseGetMarketSkillCredentialResponse(_body)
+	return _result, _err
 }
 
 // CreateMarketSkillWithContext 通过 OSS 创建 Skill
@@ -100,9 +133,13 @@ func (client *Client) CreateMarketSkillWithContext(ctx context.Context, request
 		BodyType:    dara.String("string"),
 	}
 	_result = &CreateMarketSkillResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
-		return _result, _err
+		reqID := ""
+		if _body != nil {
+			reqID = extractRequestIDFromResponse(_body)
+		}
+		return _result, &ErrWithRequestID{Err: _err, RequestID: reqID}
 	}
 	_result, _err = parseCreateMarketSkillResponse(_body)
 	return _result, _err
@@ -138,7 +175,7 @@ func (client *Client) DescribeMarketSkillDetailWithContext(ctx context.Context,
 		BodyType:    dara.String("string"),
 	}
 	_result = &DescribeMarketSkillDetailResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		reqID := ""
 		if _body != nil {
@@ -199,7 +236,7 @@ func (client *Client) CreateDockerImageTaskWithContext(ctx context.Context, requ
 		BodyType:    dara.String("xml"),
 	}
 	_result = &CreateDockerImageTaskResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		return _result, _err
 	}
@@ -248,7 +285,67 @@ func (client *Client) GetDockerImageTaskWithContext(ctx context.Context, request
 		BodyType:    dara.String("xml"),
 	}
 	_result = &GetDockerImageTaskResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
+	if _err != nil {
+		return _result, _err
+	}
+	_err = dara.Convert(_body, &_result)
+	return _result, _err
+}
+
+// Summary:
+//
+// 获取docker镜像任务构建日志
+//
+// Written by hand: GetDockerImageTaskLog is not in the API spec, and its request and
+// response fields are unconfirmed.
+//
+// @param request - GetDockerImageTaskLogRequest
+//
+// @param runtime - runtime options for this request RuntimeOptions
+//
+// @return GetDockerImageTaskLogResponse
+func (client *Client) GetDockerImageTaskLogWithContext(ctx context.Context, request *GetDockerImageTaskLogRequest, runtime *dara.RuntimeOptions) (_result *GetDockerImageTaskLogResponse, _err error) {
+	_err = request.Validate()
+	if _err != nil {
+		return _result, _err
+	}
+	query := map[string]interface{}{}
+	if !dara.IsNil(request.MaxResults) {
+		query["MaxResults"] = request.MaxResults
+	}
+
+	if !dara.IsNil(request.NextToken) {
+		query["NextToken"] = request.NextToken
+	}
+
+	if !dara.IsNil(request.Source) {
+		query["Source"] = request.Source
+	}
+
+	if !dara.IsNil(request.TaskId) {
+		query["TaskId"] = request.TaskId
+	}
+
+	req := &openapiutil.OpenApiRequest{
+		Query: openapiutil.Query(query),
+		Headers: map[string]*string{
+			"Accept": dara.String("application/xml"),
+		},
+	}
+	params := &openapiutil.Params{
+		Action:      dara.String("GetDockerImageTaskLog"),
+		Version:     dara.String("2025-05-01"),
+		Protocol:    dara.String("HTTPS"),
+		Pathname:    dara.String("/"),
+		Method:      dara.String("POST"),
+		AuthType:    dara.String("AK"),
+		Style:       dara.String("RPC"),
+		ReqBodyType: dara.String("formData"),
+		BodyType:    dara.String("xml"),
+	}
+	_result = &GetDockerImageTaskLogResponse{}
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		return _result, _err
 	}
@@ -314,7 +411,7 @@ func (client *Client) ListMcpImagesWithContext(ctx context.Context, request *Lis
 		BodyType:    dara.String("json"),
 	}
 	_result = &ListMcpImagesResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		return _result, _err
 	}
@@ -356,7 +453,7 @@ func (client *Client) GetMcpImageInfoWithContext(ctx context.Context, request *G
 		BodyType:    dara.String("json"),
 	}
 	_result = &GetMcpImageInfoResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		return _result, _err
 	}
@@ -438,7 +535,7 @@ func (client *Client) CreateResourceGroupWithContext(ctx context.Context, reques
 		BodyType:    dara.String("json"),
 	}
 	_result = &CreateResourceGroupResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		return _result, _err
 	}
@@ -483,7 +580,62 @@ func (client *Client) DeleteResourceGroupWithContext(ctx context.Context, reques
 		BodyType:    dara.String("json"),
 	}
 	_result = &DeleteResourceGroupResponse{}
-	_body, _err := client.CallApi(params, req, runtime)
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
+	if _err != nil {
+		return _result, _err
+	}
+	_err = dara.Convert(_body, &_result)
+	return _result, _err
+}
+
+// Summary:
+//
+// 下载dockerfile模版
+//
+// @param request - GetDockerfileTemplateRequest
+//
+// @param runtime - runtime options for this request RuntimeOptions
+//
+// @return GetDockerfileTemplateResponse
+//
+// Note: the service expects GET with a JSON Accept header here, unlike GetDockerfileTemplateWithOptions.
+func (client *Client) GetDockerfileTemplateWithContext(ctx context.Context, request *GetDockerfileTemplateRequest, runtime *dara.RuntimeOptions) (_result *GetDockerfileTemplateResponse, _err error) {
+	_err = request.Validate()
+	if _err != nil {
+		return _result, _err
+	}
+	query := map[string]interface{}{}
+	if !dara.IsNil(request.Source) {
+		query["Source"] = request.Source
+	}
+
+	if !dara.IsNil(request.SourceImageId) {
+		query["SourceImageId"] = request.SourceImageId
+	}
+
+	if !dara.IsNil(request.Template) {
+		query["Template"] = request.Template
+	}
+
+	req := &openapiutil.OpenApiRequest{
+		Query: openapiutil.Query(query),
+		Headers: map[string]*string{
+			"Accept": dara.String("application/json"),
+		},
+	}
+	params := &openapiutil.Params{
+		Action:      dara.String("GetDockerfileTemplate"),
+		Version:     dara.String("2025-05-01"),
+		Protocol:    dara.String("HTTPS"),
+		Pathname:    dara.String("/"),
+		Method:      dara.String("GET"),
+		AuthType:    dara.String("AK"),
+		Style:       dara.String("RPC"),
+		ReqBodyType: dara.String("formData"),
+		BodyType:    dara.String("json"),
+	}
+	_result = &GetDockerfileTemplateResponse{}
+	_body, _err := client.CallApiWithCtx(ctx, params, req, runtime)
 	if _err != nil {
 		return _result, _err
 	}
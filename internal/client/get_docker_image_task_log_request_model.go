@@ -0,0 +1,78 @@
+// Written by hand in the style of the generated models: GetDockerImageTaskLog is not in
+// the API spec this package is generated from. The MaxResults, NextToken and Logs
+// fields, and NextToken being returned at the end of the log, are unconfirmed.
+
+package client
+
+import (
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+type iGetDockerImageTaskLogRequest interface {
+	dara.Model
+	String() string
+	GoString() string
+	SetMaxResults(v int32) *GetDockerImageTaskLogRequest
+	GetMaxResults() *int32
+	SetNextToken(v string) *GetDockerImageTaskLogRequest
+	GetNextToken() *string
+	SetSource(v string) *GetDockerImageTaskLogRequest
+	GetSource() *string
+	SetTaskId(v string) *GetDockerImageTaskLogRequest
+	GetTaskId() *string
+}
+
+type GetDockerImageTaskLogRequest struct {
+	MaxResults *int32  `json:"MaxResults,omitempty" xml:"MaxResults,omitempty"`
+	NextToken  *string `json:"NextToken,omitempty" xml:"NextToken,omitempty"`
+	Source     *string `json:"Source,omitempty" xml:"Source,omitempty"`
+	TaskId     *string `json:"TaskId,omitempty" xml:"TaskId,omitempty"`
+}
+
+func (s GetDockerImageTaskLogRequest) String() string {
+	return dara.Prettify(s)
+}
+
+func (s GetDockerImageTaskLogRequest) GoString() string {
+	return s.String()
+}
+
+func (s *GetDockerImageTaskLogRequest) GetMaxResults() *int32 {
+	return s.MaxResults
+}
+
+func (s *GetDockerImageTaskLogRequest) GetNextToken() *string {
+	return s.NextToken
+}
+
+func (s *GetDockerImageTaskLogRequest) GetSource() *string {
+	return s.Source
+}
+
+func (s *GetDockerImageTaskLogRequest) GetTaskId() *string {
+	return s.TaskId
+}
+
+func (s *GetDockerImageTaskLogRequest) SetMaxResults(v int32) *GetDockerImageTaskLogRequest {
+	s.MaxResults = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogRequest) SetNextToken(v string) *GetDockerImageTaskLogRequest {
+	s.NextToken = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogRequest) SetSource(v string) *GetDockerImageTaskLogRequest {
+	s.Source = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogRequest) SetTaskId(v string) *GetDockerImageTaskLogRequest {
+	s.TaskId = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogRequest) Validate() error {
+	return dara.Validate(s)
+}
@@ -0,0 +1,137 @@
+// Written by hand in the style of the generated models: GetDockerImageTaskLog is not in
+// the API spec this package is generated from. The MaxResults, NextToken and Logs
+// fields, and NextToken being returned at the end of the log, are unconfirmed.
+
+package client
+
+import (
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+type iGetDockerImageTaskLogResponseBody interface {
+	dara.Model
+	String() string
+	GoString() string
+	SetCode(v string) *GetDockerImageTaskLogResponseBody
+	GetCode() *string
+	SetData(v *GetDockerImageTaskLogResponseBodyData) *GetDockerImageTaskLogResponseBody
+	GetData() *GetDockerImageTaskLogResponseBodyData
+	SetHttpStatusCode(v int32) *GetDockerImageTaskLogResponseBody
+	GetHttpStatusCode() *int32
+	SetMessage(v string) *GetDockerImageTaskLogResponseBody
+	GetMessage() *string
+	SetRequestId(v string) *GetDockerImageTaskLogResponseBody
+	GetRequestId() *string
+	SetSuccess(v bool) *GetDockerImageTaskLogResponseBody
+	GetSuccess() *bool
+}
+
+type GetDockerImageTaskLogResponseBody struct {
+	Code           *string                                `json:"Code,omitempty" xml:"Code,omitempty"`
+	Data           *GetDockerImageTaskLogResponseBodyData `json:"Data,omitempty" xml:"Data,omitempty" type:"Struct"`
+	HttpStatusCode *int32                                 `json:"HttpStatusCode,omitempty" xml:"HttpStatusCode,omitempty"`
+	Message        *string                                `json:"Message,omitempty" xml:"Message,omitempty"`
+	RequestId      *string                                `json:"RequestId,omitempty" xml:"RequestId,omitempty"`
+	Success        *bool                                  `json:"Success,omitempty" xml:"Success,omitempty"`
+}
+
+func (s GetDockerImageTaskLogResponseBody) String() string {
+	return dara.Prettify(s)
+}
+
+func (s GetDockerImageTaskLogResponseBody) GoString() string {
+	return s.String()
+}
+
+func (s *GetDockerImageTaskLogResponseBody) GetCode() *string {
+	return s.Code
+}
+
+func (s *GetDockerImageTaskLogResponseBody) GetData() *GetDockerImageTaskLogResponseBodyData {
+	return s.Data
+}
+
+func (s *GetDockerImageTaskLogResponseBody) GetHttpStatusCode() *int32 {
+	return s.HttpStatusCode
+}
+
+func (s *GetDockerImageTaskLogResponseBody) GetMessage() *string {
+	return s.Message
+}
+
+func (s *GetDockerImageTaskLogResponseBody) GetRequestId() *string {
+	return s.RequestId
+}
+
+func (s *GetDockerImageTaskLogResponseBody) GetSuccess() *bool {
+	return s.Success
+}
+
+func (s *GetDockerImageTaskLogResponseBody) SetCode(v string) *GetDockerImageTaskLogResponseBody {
+	s.Code = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBody) SetData(v *GetDockerImageTaskLogResponseBodyData) *GetDockerImageTaskLogResponseBody {
+	s.Data = v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBody) SetHttpStatusCode(v int32) *GetDockerImageTaskLogResponseBody {
+	s.HttpStatusCode = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBody) SetMessage(v string) *GetDockerImageTaskLogResponseBody {
+	s.Message = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBody) SetRequestId(v string) *GetDockerImageTaskLogResponseBody {
+	s.RequestId = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBody) SetSuccess(v bool) *GetDockerImageTaskLogResponseBody {
+	s.Success = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBody) Validate() error {
+	return dara.Validate(s)
+}
+
+type GetDockerImageTaskLogResponseBodyData struct {
+	Logs      *string `json:"Logs,omitempty" xml:"Logs,omitempty"`
+	NextToken *string `json:"NextToken,omitempty" xml:"NextToken,omitempty"`
+}
+
+func (s GetDockerImageTaskLogResponseBodyData) String() string {
+	return dara.Prettify(s)
+}
+
+func (s GetDockerImageTaskLogResponseBodyData) GoString() string {
+	return s.String()
+}
+
+func (s *GetDockerImageTaskLogResponseBodyData) GetLogs() *string {
+	return s.Logs
+}
+
+func (s *GetDockerImageTaskLogResponseBodyData) GetNextToken() *string {
+	return s.NextToken
+}
+
+func (s *GetDockerImageTaskLogResponseBodyData) SetLogs(v string) *GetDockerImageTaskLogResponseBodyData {
+	s.Logs = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBodyData) SetNextToken(v string) *GetDockerImageTaskLogResponseBodyData {
+	s.NextToken = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponseBodyData) Validate() error {
+	return dara.Validate(s)
+}
@@ -0,0 +1,66 @@
+// Written by hand in the style of the generated models: GetDockerImageTaskLog is not in
+// the API spec this package is generated from. The MaxResults, NextToken and Logs
+// fields, and NextToken being returned at the end of the log, are unconfirmed.
+
+package client
+
+import (
+	"github.com/alibabacloud-go/tea/dara"
+)
+
+type iGetDockerImageTaskLogResponse interface {
+	dara.Model
+	String() string
+	GoString() string
+	SetHeaders(v map[string]*string) *GetDockerImageTaskLogResponse
+	GetHeaders() map[string]*string
+	SetStatusCode(v int32) *GetDockerImageTaskLogResponse
+	GetStatusCode() *int32
+	SetBody(v *GetDockerImageTaskLogResponseBody) *GetDockerImageTaskLogResponse
+	GetBody() *GetDockerImageTaskLogResponseBody
+}
+
+type GetDockerImageTaskLogResponse struct {
+	Headers    map[string]*string                 `json:"headers,omitempty" xml:"headers,omitempty"`
+	StatusCode *int32                             `json:"statusCode,omitempty" xml:"statusCode,omitempty"`
+	Body       *GetDockerImageTaskLogResponseBody `json:"body,omitempty" xml:"body,omitempty"`
+}
+
+func (s GetDockerImageTaskLogResponse) String() string {
+	return dara.Prettify(s)
+}
+
+func (s GetDockerImageTaskLogResponse) GoString() string {
+	return s.String()
+}
+
+func (s *GetDockerImageTaskLogResponse) GetHeaders() map[string]*string {
+	return s.Headers
+}
+
+func (s *GetDockerImageTaskLogResponse) GetStatusCode() *int32 {
+	return s.StatusCode
+}
+
+func (s *GetDockerImageTaskLogResponse) GetBody() *GetDockerImageTaskLogResponseBody {
+	return s.Body
+}
+
+func (s *GetDockerImageTaskLogResponse) SetHeaders(v map[string]*string) *GetDockerImageTaskLogResponse {
+	s.Headers = v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponse) SetStatusCode(v int32) *GetDockerImageTaskLogResponse {
+	s.StatusCode = &v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponse) SetBody(v *GetDockerImageTaskLogResponseBody) *GetDockerImageTaskLogResponse {
+	s.Body = v
+	return s
+}
+
+func (s *GetDockerImageTaskLogResponse) Validate() error {
+	return dara.Validate(s)
+}
@@ -0,0 +1,337 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Response body formats understood by DecodeResponseBody
+const (
+	FormatJSON = "json"
+	FormatXML  = "xml"
+)
+
+// ResponseFormat returns the format of a response body. The Content-Type header
+// decides; the first non-space byte of the body is only consulted when the header
+// is missing or does not name JSON or XML (e.g. text/plain).
+func ResponseFormat(contentType string, body []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			return FormatJSON
+		case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+			return FormatXML
+		}
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+		return FormatXML
+	}
+	return FormatJSON
+}
+
+// DecodeResponse fills a response model such as *ListMcpImagesResponse (a struct
+// with Headers, StatusCode and Body fields) from a raw HTTP response. Header names
+// are lower-cased like the SDK does.
+func DecodeResponse(statusCode int, header http.Header, body []byte, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode response: expected pointer to struct, got %T", out)
+	}
+	v = v.Elem()
+
+	if f := v.FieldByName("Headers"); f.IsValid() && f.Type() == reflect.TypeOf(map[string]*string{}) {
+		headers := make(map[string]*string, len(header))
+		for k := range header {
+			value := header.Get(k)
+			headers[strings.ToLower(k)] = &value
+		}
+		f.Set(reflect.ValueOf(headers))
+	}
+	if f := v.FieldByName("StatusCode"); f.IsValid() && f.Type() == reflect.TypeOf((*int32)(nil)) {
+		code := int32(statusCode)
+		f.Set(reflect.ValueOf(&code))
+	}
+
+	f := v.FieldByName("Body")
+	if !f.IsValid() || f.Kind() != reflect.Ptr {
+		return fmt.Errorf("decode response: %T has no Body field", out)
+	}
+	target := reflect.New(f.Type().Elem())
+	if err := DecodeResponseBody(header.Get("Content-Type"), body, target.Interface()); err != nil {
+		return err
+	}
+	f.Set(target)
+	return nil
+}
+
+// DecodeResponseBody decodes body into out, a pointer to a response body model.
+// JSON is handled by encoding/json. XML is mapped onto the model by reflection:
+// the document root is ignored, elements match fields by their xml/json tag name
+// (case-insensitively), text is converted to the field's scalar type, and slices
+// accept both repeated elements and a wrapper element around the items.
+func DecodeResponseBody(contentType string, body []byte, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("decode response body: expected non-nil pointer, got %T", out)
+	}
+
+	if ResponseFormat(contentType, body) == FormatJSON {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("failed to parse JSON response: %w", err)
+		}
+		return nil
+	}
+
+	root, err := parseXMLTree(body)
+	if err != nil {
+		return fmt.Errorf("failed to parse XML response: %w", err)
+	}
+	if err := assignXML(root, v.Elem(), root.name); err != nil {
+		return fmt.Errorf("failed to decode XML response: %w", err)
+	}
+	return nil
+}
+
+// xmlNode is one element of a parsed XML document
+type xmlNode struct {
+	name     string
+	text     string
+	children []*xmlNode
+}
+
+// empty reports whether the element has neither child elements nor text
+func (n *xmlNode) empty() bool {
+	return len(n.children) == 0 && strings.TrimSpace(n.text) == ""
+}
+
+// childrenNamed returns the child elements whose name matches name case-insensitively
+func (n *xmlNode) childrenNamed(name string) []*xmlNode {
+	var matches []*xmlNode
+	for _, c := range n.children {
+		if strings.EqualFold(c.name, name) {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// parseXMLTree parses data into a tree of elements and returns the root element
+func parseXMLTree(data []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("empty XML document")
+	}
+	return root, nil
+}
+
+// assignXML stores element n into v, converting it to v's type. path is used in errors.
+func assignXML(n *xmlNode, v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		// Absent values stay nil, except strings where <Message/> means ""
+		if n.empty() && v.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		target := reflect.New(v.Type().Elem())
+		if err := assignXML(n, target.Elem(), path); err != nil {
+			return err
+		}
+		v.Set(target)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := xmlFieldName(field)
+			if name == "" {
+				continue
+			}
+			matches := n.childrenNamed(name)
+			if len(matches) == 0 {
+				continue
+			}
+			fieldPath := path + "." + name
+			if field.Type.Kind() == reflect.Slice {
+				if err := assignXMLSlice(matches, v.Field(i), fieldPath); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := assignXML(matches[0], v.Field(i), fieldPath); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(n.children))
+		for _, c := range n.children {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := assignXML(c, elem, path+"."+c.name); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(c.name).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf(xmlToInterface(n)))
+		}
+	case reflect.String:
+		v.SetString(n.text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(n.text))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(strings.TrimSpace(n.text), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(strings.TrimSpace(n.text), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n.text), v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+// assignXMLSlice fills slice field v from the elements that matched its name. The
+// service encodes lists either as repeated elements (<ToolInfo>..</ToolInfo><ToolInfo>..</ToolInfo>)
+// or as one wrapper whose children are the items (<Data><data>..</data><data>..</data></Data>).
+func assignXMLSlice(matches []*xmlNode, v reflect.Value, path string) error {
+	items := matches
+	if len(matches) == 1 && isXMLListWrapper(matches[0], v.Type().Elem()) {
+		items = matches[0].children
+	}
+
+	s := reflect.MakeSlice(v.Type(), 0, len(items))
+	for i, item := range items {
+		if item.empty() {
+			continue
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := assignXML(item, elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+		s = reflect.Append(s, elem)
+	}
+	v.Set(s)
+	return nil
+}
+
+// isXMLListWrapper reports whether n wraps list items rather than being one item:
+// an item of a struct type has at least one child matching a field of that type.
+func isXMLListWrapper(n *xmlNode, elemType reflect.Type) bool {
+	if len(n.children) == 0 {
+		return false
+	}
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return true
+	}
+	for _, c := range n.children {
+		for i := 0; i < elemType.NumField(); i++ {
+			if strings.EqualFold(xmlFieldName(elemType.Field(i)), c.name) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// xmlFieldName returns the element name a struct field is decoded from: its xml tag,
+// then its json tag, then the field name. Unexported and "-" fields return "".
+func xmlFieldName(f reflect.StructField) string {
+	if f.PkgPath != "" {
+		return ""
+	}
+	for _, key := range []string{"xml", "json"} {
+		tag, ok := f.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// xmlToInterface converts n to a string, or to a map of child values (repeated
+// children become a []interface{})
+func xmlToInterface(n *xmlNode) interface{} {
+	if len(n.children) == 0 {
+		return n.text
+	}
+	m := make(map[string]interface{}, len(n.children))
+	for _, c := range n.children {
+		value := xmlToInterface(c)
+		switch existing := m[c.name].(type) {
+		case nil:
+			m[c.name] = value
+		case []interface{}:
+			m[c.name] = append(existing, value)
+		default:
+			m[c.name] = []interface{}{existing, value}
+		}
+	}
+	return m
+}
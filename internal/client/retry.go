@@ -4,8 +4,12 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"math/rand"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -104,3 +108,115 @@ func IsRetryableHTTPStatus(statusCode int) bool {
 	}
 	return false
 }
+
+// Backoff returns the delay before the given retry attempt (1-based): exponential
+// growth from InitialDelay by BackoffFactor, capped at MaxDelay, with "equal jitter"
+// so the result lies in [d/2, d].
+func (c *RetryConfig) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := float64(c.InitialDelay)
+	for i := 1; i < attempt; i++ {
+		delay *= c.BackoffFactor
+		if c.MaxDelay > 0 && delay >= float64(c.MaxDelay) {
+			break
+		}
+	}
+	if c.MaxDelay > 0 && delay > float64(c.MaxDelay) {
+		delay = float64(c.MaxDelay)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := time.Duration(delay / 2)
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// ParseRetryAfter parses an HTTP Retry-After header value, which is either a number
+// of seconds or an HTTP date. It returns false when the value is empty or invalid.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// StatusCodeFromError returns the HTTP status carried by an SDK error
+// (ClientError, ServerError, ThrottlingError), or 0 if there is none.
+func StatusCodeFromError(err error) int {
+	var withStatus interface{ GetStatusCode() *int }
+	if errors.As(err, &withStatus) {
+		if code := withStatus.GetStatusCode(); code != nil {
+			return *code
+		}
+	}
+	return 0
+}
+
+// RetryAfterFromError returns the server-suggested wait carried by a throttling
+// error (TimeLeft from the x-ratelimit headers, in milliseconds), or 0.
+func RetryAfterFromError(err error) time.Duration {
+	var throttled interface{ GetRetryAfter() *int64 }
+	if errors.As(err, &throttled) {
+		if ms := throttled.GetRetryAfter(); ms != nil && *ms > 0 {
+			return time.Duration(*ms) * time.Millisecond
+		}
+	}
+	return 0
+}
+
+// RetryDecision tells DoWithRetry whether a failed attempt should be retried and,
+// optionally, how long the server asked us to wait (0 means use the backoff).
+type RetryDecision struct {
+	Retry bool
+	Wait  time.Duration
+}
+
+// DoWithRetry calls op until it succeeds, decide says the error is final, the
+// retry budget is exhausted or ctx is done. Waits use the larger of the backoff
+// and the server-suggested delay, capped at MaxDelay unless the server asked for more.
+func DoWithRetry[T any](ctx context.Context, cfg *RetryConfig, op func(ctx context.Context, attempt int) (T, error), decide func(err error) RetryDecision) (T, error) {
+	if cfg == nil {
+		cfg = DefaultRetryConfig()
+	}
+	var result T
+	var err error
+	for attempt := 0; ; attempt++ {
+		result, err = op(ctx, attempt)
+		if err == nil {
+			return result, nil
+		}
+		if attempt >= cfg.MaxRetries || ctx.Err() != nil {
+			return result, err
+		}
+		decision := decide(err)
+		if !decision.Retry {
+			return result, err
+		}
+		wait := cfg.Backoff(attempt + 1)
+		if decision.Wait > wait {
+			wait = decision.Wait
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+	}
+}
@@ -14,13 +14,21 @@ import (
 type APIConfig struct {
 	Endpoint  string `json:"endpoint"`
 	TimeoutMs int    `json:"timeout_ms"`
+	// MaxRetries is how many times idempotent API calls are retried after a
+	// transient failure; 0 disables retries
+	MaxRetries int `json:"max_retries"`
+	// RetryInitialDelayMs and RetryMaxDelayMs bound the exponential backoff;
+	// 0 means use the client default
+	RetryInitialDelayMs int `json:"retry_initial_delay_ms"`
+	RetryMaxDelayMs     int `json:"retry_max_delay_ms"`
 }
 
 // DefaultAPIConfig returns the default API configuration
 func DefaultAPIConfig() APIConfig {
 	return APIConfig{
-		Endpoint:  GetDefaultEndpoint(),
-		TimeoutMs: 60000,
+		Endpoint:   GetDefaultEndpoint(),
+		TimeoutMs:  60000,
+		MaxRetries: 3,
 	}
 }
 
@@ -29,8 +37,11 @@ func LoadAPIConfig(cfg *APIConfig) APIConfig {
 	if cfg != nil {
 		// If config is explicitly provided, use it directly
 		return APIConfig{
-			Endpoint:  cfg.Endpoint,
-			TimeoutMs: cfg.TimeoutMs,
+			Endpoint:            cfg.Endpoint,
+			TimeoutMs:           cfg.TimeoutMs,
+			MaxRetries:          cfg.MaxRetries,
+			RetryInitialDelayMs: cfg.RetryInitialDelayMs,
+			RetryMaxDelayMs:     cfg.RetryMaxDelayMs,
 		}
 	}
 
@@ -40,6 +51,9 @@ func LoadAPIConfig(cfg *APIConfig) APIConfig {
 	if endpoint := os.Getenv("AGENTBAY_CLI_ENDPOINT"); endpoint != "" {
 		config.Endpoint = endpoint
 		log.Debugf("[DEBUG] Using endpoint from AGENTBAY_CLI_ENDPOINT: %s", endpoint)
+	} else if endpoint := activeProfile.Endpoint; endpoint != "" {
+		config.Endpoint = endpoint
+		log.Debugf("[DEBUG] Using endpoint from profile %s: %s", selectedProfile, endpoint)
 	} else {
 		log.Debugf("[DEBUG] Using default endpoint for %s environment: %s",
 			GetEnvironment(), config.Endpoint)
@@ -62,9 +76,29 @@ func LoadAPIConfig(cfg *APIConfig) APIConfig {
 		log.Debugf("[DEBUG] Using default timeout: %d ms", config.TimeoutMs)
 	}
 
+	loadIntEnv("AGENTBAY_CLI_MAX_RETRIES", &config.MaxRetries)
+	loadIntEnv("AGENTBAY_CLI_RETRY_INITIAL_DELAY_MS", &config.RetryInitialDelayMs)
+	loadIntEnv("AGENTBAY_CLI_RETRY_MAX_DELAY_MS", &config.RetryMaxDelayMs)
+
 	return config
 }
 
+// loadIntEnv overrides *target with the non-negative integer in the named
+// environment variable, warning and keeping the current value if it does not parse
+func loadIntEnv(name string, target *int) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Warnf("Warning: Failed to parse %s as a non-negative integer: %q, using default value %d", name, value, *target)
+		return
+	}
+	*target = n
+	log.Debugf("[DEBUG] Using %s from environment: %d", name, n)
+}
+
 // GetFullEndpoint returns the full endpoint URL with https:// prefix if needed
 func (c *APIConfig) GetFullEndpoint() string {
 	endpoint := c.Endpoint
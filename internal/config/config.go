@@ -6,14 +6,27 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
+
+	log "github.com/sirupsen/logrus"
 )
 
 // Config represents the CLI configuration
 type Config struct {
-	Token *Token `json:"token,omitempty"` // OAuth token authentication
+	CredentialStore string              `json:"credential_store,omitempty"` // where tokens are kept, see CredentialStores
+	CurrentProfile  string              `json:"current_profile,omitempty"`  // profile used when none is selected
+	Profiles        map[string]*Profile `json:"profiles,omitempty"`
+	// Token is the OAuth token of the active profile, loaded from the credential store.
+	// Configuration files written before profiles kept the only token here; it now
+	// belongs to the default profile.
+	Token *Token `json:"token,omitempty"`
+
+	profile       string // the active profile, which Token belongs to
+	tokensUnread  bool   // read without the tokens of the credential store, see ReadConfig
+	credentialErr error  // why the credential store could not be read, see CredentialStoreError
 }
 
 // Token represents OAuth authentication tokens
@@ -30,8 +43,62 @@ var (
 	ErrNoTokenFound = errors.New("no authentication token found. Run 'agentbay login' to authenticate")
 )
 
-// GetConfig loads the configuration from file or creates a new one
+// GetConfig loads the configuration from file or creates a new one, with the token of
+// the active profile. When the credential store cannot be used, a warning is printed and
+// the profile is not logged in, see CredentialStoreError.
 func GetConfig() (*Config, error) {
+	c, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	c.profile = c.resolveProfileName()
+	if !c.HasProfile(c.profile) {
+		return nil, fmt.Errorf("%w: %q (see 'agentbay profile list')", ErrProfileNotFound, c.profile)
+	}
+	if p := c.Profiles[c.profile]; p != nil {
+		c.Token = p.Token
+	}
+
+	if err := c.loadCredentials(); err != nil {
+		// Failing here would lock out every command, including the login and logout
+		// that fix the store
+		c.credentialErr = err
+		c.tokensUnread = true
+		if c.Token == nil {
+			log.Warnf("[WARN] Not logged in: %v. Run 'agentbay login --credential-store <store>' to use another store", err)
+		} else {
+			log.Warnf("[WARN] Using the token kept in config.json: %v", err)
+		}
+	}
+	return c, nil
+}
+
+// CredentialStoreError returns why the credential store could not be read when the
+// configuration was loaded, or nil
+func (c *Config) CredentialStoreError() error {
+	return c.credentialErr
+}
+
+// ReadConfig loads the configuration file for the active profile without reading the
+// credential store, for commands that manage profiles or credential stores and must
+// work when the store is unusable or the active profile does not exist. Saving it
+// leaves the stored tokens as they are, unless tokens are saved or cleared.
+func ReadConfig() (*Config, error) {
+	c, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+	c.profile = c.resolveProfileName()
+	if p := c.Profiles[c.profile]; p != nil {
+		c.Token = p.Token
+	}
+	c.tokensUnread = true
+	return c, nil
+}
+
+// readConfig loads the configuration file without the tokens kept in a credential store
+func readConfig() (*Config, error) {
 	configFilePath, err := getConfigPath()
 	if err != nil {
 		return nil, err
@@ -55,15 +122,50 @@ func GetConfig() (*Config, error) {
 
 		err = json.Unmarshal(configContent, &c)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to parse %s: %w", configFilePath, err)
 		}
 	}
 
+	// A token from before profiles belongs to the default profile
+	if c.Token != nil {
+		if c.Profiles == nil {
+			c.Profiles = map[string]*Profile{}
+		}
+		if c.Profiles[DefaultProfileName] == nil {
+			c.Profiles[DefaultProfileName] = &Profile{}
+		}
+		if c.Profiles[DefaultProfileName].Token == nil {
+			c.Profiles[DefaultProfileName].Token = c.Token
+		}
+		c.Token = nil
+	}
 	return &c, nil
 }
 
-// Save writes the configuration to file
+// Save writes the configuration to file and the token to the credential store
 func (c *Config) Save() error {
+	if c.tokensUnread {
+		// Write back the tokens the file held and leave the credential store alone
+		return c.writeFile(CredentialStorePlaintext)
+	}
+	store, err := c.credentialStore(c.ProfileName())
+	if err != nil {
+		return err
+	}
+	if c.Token == nil {
+		err = store.Delete()
+	} else {
+		err = store.Save(c.Token)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update the %s credential store: %w", store.Name(), err)
+	}
+	return c.writeFile(store.Name())
+}
+
+// writeFile writes the configuration to file. Tokens are left out unless the named
+// credential store keeps them there.
+func (c *Config) writeFile(storeName string) error {
 	configFilePath, err := getConfigPath()
 	if err != nil {
 		return err
@@ -74,7 +176,31 @@ func (c *Config) Save() error {
 		return err
 	}
 
-	configContent, err := json.MarshalIndent(c, "", "  ")
+	// Token belongs to the active profile
+	name := c.ProfileName()
+	if c.Profiles[name] != nil {
+		c.Profiles[name].Token = c.Token
+	} else if c.Token != nil {
+		if c.Profiles == nil {
+			c.Profiles = map[string]*Profile{}
+		}
+		c.Profiles[name] = &Profile{Token: c.Token}
+	}
+
+	onDisk := *c
+	onDisk.Token = nil
+	if storeName != CredentialStorePlaintext {
+		onDisk.Profiles = make(map[string]*Profile, len(c.Profiles))
+		for name, p := range c.Profiles {
+			var withoutToken Profile
+			if p != nil {
+				withoutToken = *p
+				withoutToken.Token = nil
+			}
+			onDisk.Profiles[name] = &withoutToken
+		}
+	}
+	configContent, err := json.MarshalIndent(&onDisk, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -113,6 +239,7 @@ func (c *Config) SaveTokens(accessToken, tokenType string, expiresIn int, refres
 		IDToken:      idToken,
 		ExpiresAt:    expiresAt,
 	}
+	c.tokensUnread = false
 
 	return c.Save()
 }
@@ -132,9 +259,14 @@ func (c *Config) RefreshTokens(accessToken, tokenType string, expiresIn int) err
 	return c.Save()
 }
 
-// ClearTokens removes authentication tokens from the configuration
+// ClearTokens removes authentication tokens from the configuration. When the credential
+// store could not be read, only the tokens kept in config.json are removed.
 func (c *Config) ClearTokens() error {
 	c.Token = nil
+	if c.credentialErr != nil {
+		return c.writeFile(CredentialStorePlaintext)
+	}
+	c.tokensUnread = false
 	return c.Save()
 }
 
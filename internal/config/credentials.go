@@ -0,0 +1,223 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Credential store names, as accepted by --credential-store and AGENTBAY_CLI_CREDENTIAL_STORE
+const (
+	// CredentialStorePlaintext keeps the token in config.json (the default)
+	CredentialStorePlaintext = "plaintext"
+	// CredentialStoreKeyring keeps the token in the system keyring
+	CredentialStoreKeyring = "keyring"
+	// CredentialStoreEncryptedFile keeps the token in a passphrase-encrypted file next to config.json
+	CredentialStoreEncryptedFile = "encrypted-file"
+)
+
+const (
+	// credentialStoreEnv selects the credential store, overriding the configured one
+	credentialStoreEnv = "AGENTBAY_CLI_CREDENTIAL_STORE"
+)
+
+// CredentialStores lists the supported credential store names
+var CredentialStores = []string{CredentialStorePlaintext, CredentialStoreKeyring, CredentialStoreEncryptedFile}
+
+// ErrCredentialsNotMoved is returned by SetCredentialStore for tokens it could not read
+// from the previous store
+var ErrCredentialsNotMoved = errors.New("some tokens could not be moved to the new credential store")
+
+// CredentialStore persists the OAuth token of one profile outside of config.json
+type CredentialStore interface {
+	// Name returns the store name, one of CredentialStores
+	Name() string
+	// Load returns the stored token, or nil when there is none
+	Load() (*Token, error)
+	// Save stores the token, replacing any previous one
+	Save(token *Token) error
+	// Delete removes the stored token. Deleting a missing token is not an error.
+	Delete() error
+}
+
+// NewCredentialStore returns the credential store with the given name for the token of
+// profile. An empty name selects the plaintext store.
+func NewCredentialStore(name, profile string) (CredentialStore, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", CredentialStorePlaintext:
+		return plaintextStore{}, nil
+	case CredentialStoreKeyring:
+		return &keyringStore{account: profile}, nil
+	case CredentialStoreEncryptedFile:
+		path, err := encryptedCredentialsPath()
+		if err != nil {
+			return nil, err
+		}
+		return &encryptedFileStore{path: path, account: profile}, nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %q (supported: %s)", name, strings.Join(CredentialStores, ", "))
+	}
+}
+
+// plaintextStore keeps the token in config.json itself, which Config.Save writes, so
+// it has nothing to load or store separately
+type plaintextStore struct{}
+
+func (plaintextStore) Name() string          { return CredentialStorePlaintext }
+func (plaintextStore) Load() (*Token, error) { return nil, nil }
+func (plaintextStore) Save(*Token) error     { return nil }
+func (plaintextStore) Delete() error         { return nil }
+
+// credentialStore returns the store for the token of profile, selected by
+// AGENTBAY_CLI_CREDENTIAL_STORE or, when that is unset, by the configuration
+func (c *Config) credentialStore(profile string) (CredentialStore, error) {
+	name := c.CredentialStore
+	if env := os.Getenv(credentialStoreEnv); env != "" {
+		name = env
+	}
+	return NewCredentialStore(name, profile)
+}
+
+// CredentialStoreName returns the name of the credential store in use
+func (c *Config) CredentialStoreName() (string, error) {
+	store, err := c.credentialStore(c.ProfileName())
+	if err != nil {
+		return "", err
+	}
+	return store.Name(), nil
+}
+
+// loadCredentials fills in the token of the active profile from the credential store.
+// Tokens still found in config.json are migrated into the store and removed from the
+// file.
+func (c *Config) loadCredentials() error {
+	store, err := c.credentialStore(c.ProfileName())
+	if err != nil {
+		return err
+	}
+	if store.Name() == CredentialStorePlaintext {
+		return nil
+	}
+
+	migrated := false
+	for _, name := range c.ProfileNames() {
+		p := c.Profiles[name]
+		if p == nil || p.Token == nil {
+			continue
+		}
+		profileStore, err := c.credentialStore(name)
+		if err != nil {
+			return err
+		}
+		if err := profileStore.Save(p.Token); err != nil {
+			return fmt.Errorf("failed to move the token of profile %q from config.json to the %s credential store: %w", name, store.Name(), err)
+		}
+		migrated = true
+	}
+	if migrated {
+		if err := c.writeFile(store.Name()); err != nil {
+			return err
+		}
+	}
+	if c.Token != nil {
+		return nil
+	}
+
+	token, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load the token from the %s credential store: %w", store.Name(), err)
+	}
+	c.Token = token
+	return nil
+}
+
+// SetCredentialStore switches to the named credential store, moving the tokens of all
+// profiles into it and deleting them from the previous store, and saves the
+// configuration. Tokens that cannot be read, because the previous store is unusable,
+// are left behind: the store is switched all the same, and an error wrapping
+// ErrCredentialsNotMoved names them.
+func (c *Config) SetCredentialStore(name string) error {
+	target, err := NewCredentialStore(name, "")
+	if err != nil {
+		return err
+	}
+
+	type storedProfile struct {
+		name  string
+		from  CredentialStore
+		token *Token
+	}
+	var stored []storedProfile
+	var unread []error
+	for _, profile := range c.ProfileNames() {
+		from, err := c.credentialStore(profile)
+		if err != nil {
+			unread = append(unread, fmt.Errorf("profile %q: %w", profile, err))
+			continue
+		}
+		token, err := c.storedToken(from, profile)
+		if err != nil {
+			unread = append(unread, fmt.Errorf("profile %q: failed to load the token from the %s credential store: %w", profile, from.Name(), err))
+			continue
+		}
+		stored = append(stored, storedProfile{name: profile, from: from, token: token})
+	}
+
+	c.CredentialStore = target.Name()
+	for _, sp := range stored {
+		if sp.name == c.ProfileName() {
+			// The token of the active profile has now been read
+			c.Token, c.tokensUnread, c.credentialErr = sp.token, false, nil
+		}
+		to, _ := NewCredentialStore(c.CredentialStore, sp.name)
+		if sp.token == nil {
+			continue
+		}
+		if err := to.Save(sp.token); err != nil {
+			return fmt.Errorf("failed to save the token of profile %q to the %s credential store: %w", sp.name, to.Name(), err)
+		}
+		if to.Name() == CredentialStorePlaintext && sp.name != c.ProfileName() {
+			if c.Profiles == nil {
+				c.Profiles = map[string]*Profile{}
+			}
+			if c.Profiles[sp.name] == nil {
+				c.Profiles[sp.name] = &Profile{}
+			}
+			c.Profiles[sp.name].Token = sp.token
+		}
+	}
+	if err := c.writeFile(c.CredentialStore); err != nil {
+		return err
+	}
+
+	for _, sp := range stored {
+		if sp.from.Name() == c.CredentialStore {
+			continue
+		}
+		if err := sp.from.Delete(); err != nil {
+			return fmt.Errorf("failed to delete the token of profile %q from the %s credential store: %w", sp.name, sp.from.Name(), err)
+		}
+	}
+	if len(unread) > 0 {
+		return fmt.Errorf("%w: %w", ErrCredentialsNotMoved, errors.Join(unread...))
+	}
+	return nil
+}
+
+// storedToken returns the token of profile kept in store
+func (c *Config) storedToken(store CredentialStore, profile string) (*Token, error) {
+	if profile == c.ProfileName() && !c.tokensUnread {
+		return c.Token, nil
+	}
+	if store.Name() == CredentialStorePlaintext {
+		if p := c.Profiles[profile]; p != nil {
+			return p.Token, nil
+		}
+		return nil, nil
+	}
+	return store.Load()
+}
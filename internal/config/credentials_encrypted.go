@@ -0,0 +1,261 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// credentialKeyEnv holds the passphrase of the encrypted-file credential store
+	credentialKeyEnv = "AGENTBAY_CLI_CREDENTIAL_KEY"
+	// encryptedCredentialsFile is the encrypted-file store, next to config.json
+	encryptedCredentialsFile = "credentials.enc"
+	// encryptedCredentialsVersion is the current file format version
+	encryptedCredentialsVersion = 1
+	// encryptedCredentialsKDF derives the key from the passphrase
+	encryptedCredentialsKDF = "pbkdf2-sha256"
+	// pbkdf2Iterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256
+	pbkdf2Iterations = 600000
+)
+
+var (
+	// ErrNoCredentialKey is returned when the encrypted-file store is used without a passphrase
+	ErrNoCredentialKey = errors.New("the encrypted-file credential store needs a passphrase: set AGENTBAY_CLI_CREDENTIAL_KEY")
+	// ErrCredentialKeyMismatch is returned when the passphrase does not decrypt the stored token
+	ErrCredentialKeyMismatch = errors.New("cannot decrypt the stored token: AGENTBAY_CLI_CREDENTIAL_KEY does not match the passphrase it was saved with")
+
+	// derivedKeys caches the keys derived by cipher, as a command may load and save the
+	// token several times and each derivation takes a noticeable fraction of a second
+	derivedKeys   = map[derivedKeyInput][]byte{}
+	derivedKeysMu sync.Mutex
+)
+
+// derivedKeyInput identifies a key derived from a passphrase
+type derivedKeyInput struct {
+	passphrase string
+	salt       string
+}
+
+// encryptedCredentials is the format of credentials.enc. Each account's token is
+// encrypted separately with AES-256-GCM under a key derived from the passphrase, so
+// that an entry can be deleted without the passphrase.
+type encryptedCredentials struct {
+	Version    int                       `json:"version"`
+	KDF        string                    `json:"kdf"`
+	Iterations int                       `json:"iterations"`
+	Salt       []byte                    `json:"salt"`
+	Entries    map[string]encryptedEntry `json:"entries"`
+}
+
+// encryptedEntry is one sealed token
+type encryptedEntry struct {
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// encryptedFileStore keeps the token in credentials.enc, encrypted with the passphrase
+// from AGENTBAY_CLI_CREDENTIAL_KEY. It works on hosts without a keyring, such as
+// headless Linux servers and CI runners.
+type encryptedFileStore struct {
+	path    string
+	account string
+}
+
+func (s *encryptedFileStore) Name() string { return CredentialStoreEncryptedFile }
+
+func (s *encryptedFileStore) Load() (*Token, error) {
+	file, err := readEncryptedCredentials(s.path)
+	if err != nil || file == nil {
+		return nil, err
+	}
+	entry, ok := file.Entries[s.account]
+	if !ok {
+		return nil, nil
+	}
+
+	aead, err := file.cipher()
+	if err != nil {
+		return nil, err
+	}
+	data, err := aead.Open(nil, entry.Nonce, entry.Ciphertext, []byte(s.account))
+	if err != nil {
+		return nil, ErrCredentialKeyMismatch
+	}
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("invalid encrypted credentials: %w", err)
+	}
+	return &token, nil
+}
+
+func (s *encryptedFileStore) Save(token *Token) error {
+	file, err := readEncryptedCredentials(s.path)
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		file = &encryptedCredentials{
+			Version:    encryptedCredentialsVersion,
+			KDF:        encryptedCredentialsKDF,
+			Iterations: pbkdf2Iterations,
+			Salt:       salt,
+			Entries:    map[string]encryptedEntry{},
+		}
+	}
+
+	aead, err := file.cipher()
+	if err != nil {
+		return err
+	}
+	// All entries share the key, so a different passphrase must not be mixed in
+	for account, entry := range file.Entries {
+		if _, err := aead.Open(nil, entry.Nonce, entry.Ciphertext, []byte(account)); err != nil {
+			return ErrCredentialKeyMismatch
+		}
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	file.Entries[s.account] = encryptedEntry{Nonce: nonce, Ciphertext: aead.Seal(nil, nonce, data, []byte(s.account))}
+	return writeEncryptedCredentials(s.path, file)
+}
+
+func (s *encryptedFileStore) Delete() error {
+	file, err := readEncryptedCredentials(s.path)
+	if err != nil || file == nil {
+		return err
+	}
+	if _, ok := file.Entries[s.account]; !ok {
+		return nil
+	}
+	delete(file.Entries, s.account)
+	if len(file.Entries) == 0 {
+		return os.Remove(s.path)
+	}
+	return writeEncryptedCredentials(s.path, file)
+}
+
+// cipher derives the key from the passphrase and returns the AEAD for the entries. The
+// iteration count is fixed, so that a tampered file cannot weaken the key or stall the
+// derivation.
+func (f *encryptedCredentials) cipher() (cipher.AEAD, error) {
+	if f.KDF != encryptedCredentialsKDF || f.Iterations != pbkdf2Iterations || len(f.Salt) == 0 {
+		return nil, fmt.Errorf("unsupported encrypted credentials key derivation %q with %d iterations", f.KDF, f.Iterations)
+	}
+	passphrase := os.Getenv(credentialKeyEnv)
+	if passphrase == "" {
+		return nil, ErrNoCredentialKey
+	}
+
+	in := derivedKeyInput{passphrase: passphrase, salt: string(f.Salt)}
+	derivedKeysMu.Lock()
+	key, ok := derivedKeys[in]
+	if !ok {
+		key = pbkdf2SHA256([]byte(passphrase), f.Salt, f.Iterations, 32)
+		derivedKeys[in] = key
+	}
+	derivedKeysMu.Unlock()
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readEncryptedCredentials reads the encrypted-file store, returning nil when it does
+// not exist
+func readEncryptedCredentials(path string) (*encryptedCredentials, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file encryptedCredentials
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid encrypted credentials file %s: %w", path, err)
+	}
+	if file.Version != encryptedCredentialsVersion {
+		return nil, fmt.Errorf("unsupported encrypted credentials file version %d in %s", file.Version, path)
+	}
+	if file.Entries == nil {
+		file.Entries = map[string]encryptedEntry{}
+	}
+	return &file, nil
+}
+
+// writeEncryptedCredentials writes the encrypted-file store
+func writeEncryptedCredentials(path string, file *encryptedCredentials) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0600)
+}
+
+// encryptedCredentialsPath returns the path of the encrypted-file store
+func encryptedCredentialsPath() (string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, encryptedCredentialsFile), nil
+}
+
+// pbkdf2SHA256 derives a key from password with PBKDF2-HMAC-SHA256 (RFC 8018 section 5.2).
+// crypto/pbkdf2 needs Go 1.24 and releases are built with Go 1.23, so it is implemented
+// here. TestEncryptedFileCredentialStoreFixture checks it against a file encrypted with a
+// key from crypto/pbkdf2.
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	key := make([]byte, 0, keyLen)
+	u := make([]byte, 0, sha256.Size)
+	block := make([]byte, sha256.Size)
+
+	for i := uint32(1); len(key) < keyLen; i++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, i))
+		u = prf.Sum(u[:0])
+		copy(block, u)
+		for n := 1; n < iterations; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range block {
+				block[j] ^= u[j]
+			}
+		}
+		key = append(key, block...)
+	}
+	return key[:keyLen]
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"unicode"
+)
+
+// keyringService identifies the CLI's entries in the system keyring
+const keyringService = "agentbay-cli"
+
+// errKeyringItemNotFound is returned by the keyring commands when there is no entry
+var errKeyringItemNotFound = errors.New("keyring item not found")
+
+// keyringStore keeps the token in the system keyring: the Secret Service (GNOME
+// Keyring, KWallet) through secret-tool on Linux and the login keychain through
+// security on macOS. The token is stored as base64-encoded JSON, one entry per account.
+type keyringStore struct {
+	account string
+}
+
+func (s *keyringStore) Name() string { return CredentialStoreKeyring }
+
+func (s *keyringStore) Load() (*Token, error) {
+	secret, err := keyringGet(s.account)
+	if errors.Is(err, errKeyringItemNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(secret))
+	if err != nil {
+		return nil, fmt.Errorf("invalid keyring entry: %w", err)
+	}
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("invalid keyring entry: %w", err)
+	}
+	return &token, nil
+}
+
+func (s *keyringStore) Save(token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return keyringSet(s.account, base64.StdEncoding.EncodeToString(data))
+}
+
+func (s *keyringStore) Delete() error {
+	err := keyringDelete(s.account)
+	if errors.Is(err, errKeyringItemNotFound) {
+		return nil
+	}
+	return err
+}
+
+// keyringGet returns the secret stored for account
+func keyringGet(account string) (string, error) {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return runKeyringCommand("secret-tool", nil, 1, "lookup", "service", keyringService, "account", account)
+	case "darwin":
+		return runKeyringCommand("security", nil, 44, "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	default:
+		return "", errKeyringUnsupported()
+	}
+}
+
+// keyringSet stores secret for account, replacing any previous secret. The secret is
+// passed on stdin so that it does not show up in the process list.
+func keyringSet(account, secret string) error {
+	label := fmt.Sprintf("AgentBay CLI (%s)", account)
+	var err error
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		_, err = runKeyringCommand("secret-tool", strings.NewReader(secret), 0,
+			"store", "--label", label, "service", keyringService, "account", account)
+	case "darwin":
+		// security -i reads the command from stdin
+		var command string
+		command, err = securityCommand("add-generic-password", "-U", "-s", keyringService, "-a", account, "-l", label, "-w", secret)
+		if err == nil {
+			_, err = runKeyringCommand("security", strings.NewReader(command), 0, "-i")
+		}
+	default:
+		err = errKeyringUnsupported()
+	}
+	return err
+}
+
+// keyringDelete removes the secret stored for account
+func keyringDelete(account string) error {
+	var err error
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		// secret-tool clear succeeds whether or not there was an entry
+		_, err = runKeyringCommand("secret-tool", nil, 0, "clear", "service", keyringService, "account", account)
+	case "darwin":
+		_, err = runKeyringCommand("security", nil, 44, "delete-generic-password", "-s", keyringService, "-a", account)
+	default:
+		err = errKeyringUnsupported()
+	}
+	return err
+}
+
+// securityCommand builds a command line for security -i, double-quoting every argument
+// so that spaces and quotes in a profile name cannot split it or add arguments. A newline
+// would end the command, so control characters are rejected.
+func securityCommand(name string, args ...string) (string, error) {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.IndexFunc(arg, unicode.IsControl) >= 0 {
+			return "", fmt.Errorf("invalid keyring entry name %q", arg)
+		}
+		quoted[i] = `"` + quote.Replace(arg) + `"`
+	}
+	return name + " " + strings.Join(quoted, " ") + "\n", nil
+}
+
+// runKeyringCommand runs a keyring tool and returns its output. An exit with
+// notFoundCode (when not 0) is reported as errKeyringItemNotFound. secret-tool exits
+// with 1 for any failure, so for it the error output must also be empty.
+func runKeyringCommand(name string, stdin *strings.Reader, notFoundCode int, args ...string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("%s not found: the keyring credential store needs %s; use --credential-store %s on hosts without a keyring",
+			name, keyringToolDescription(name), CredentialStoreEncryptedFile)
+	}
+
+	cmd := exec.Command(path, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && notFoundCode != 0 && exitErr.ExitCode() == notFoundCode &&
+			(name != "secret-tool" || stderr.Len() == 0) {
+			return "", errKeyringItemNotFound
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s %s failed: %s", name, args[0], msg)
+		}
+		return "", fmt.Errorf("%s %s failed: %w", name, args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// keyringToolDescription explains where a keyring tool comes from
+func keyringToolDescription(name string) string {
+	if name == "secret-tool" {
+		return "secret-tool from libsecret (package libsecret-tools or libsecret) and a running Secret Service such as GNOME Keyring"
+	}
+	return name
+}
+
+func errKeyringUnsupported() error {
+	return fmt.Errorf("the keyring credential store is not supported on %s; use --credential-store %s", runtime.GOOS, CredentialStoreEncryptedFile)
+}
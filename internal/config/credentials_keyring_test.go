@@ -0,0 +1,22 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSecurityCommand tests securityCommand directly: the keyring store only reaches it
+// on macOS, and the tests run on Linux in CI
+func TestSecurityCommand(t *testing.T) {
+	command, err := securityCommand("add-generic-password", "-a", `my "work" profile`, "-l", `back\slash`)
+	require.NoError(t, err)
+	assert.Equal(t, `add-generic-password "-a" "my \"work\" profile" "-l" "back\\slash"`+"\n", command)
+
+	_, err = securityCommand("add-generic-password", "-a", "evil\ndelete-keychain login.keychain")
+	assert.Error(t, err, "a newline would start another command")
+}
@@ -5,6 +5,7 @@ package config
 
 import (
 	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -60,34 +61,62 @@ var (
 	}
 )
 
-// GetEnvironment returns the current environment based on AGENTBAY_ENV
+// GetEnvironment returns the current environment based on AGENTBAY_ENV, or on the
+// environment of the active profile when that is unset.
 // Defaults to production if not set or invalid
 func GetEnvironment() Environment {
 	env := os.Getenv("AGENTBAY_ENV")
+	if env == "" {
+		env = activeProfile.Environment
+	}
 
-	switch env {
-	case "prerelease", "pre", "staging":
+	parsed, ok := ParseEnvironment(env)
+	if !ok {
+		log.Warnf("[WARN] Unknown environment '%s', defaulting to production", env)
+		return EnvProduction
+	}
+	switch parsed {
+	case EnvPreRelease:
 		log.Debugf("[DEBUG] Using pre-release environment")
-		return EnvPreRelease
-	case "international", "prod-international", "intl", "international-prod":
+	case EnvInternationalProduction:
 		log.Debugf("[DEBUG] Using international production environment")
-		return EnvInternationalProduction
-	case "international-pre", "pre-international", "intl-pre", "staging-international":
+	case EnvInternationalPreRelease:
 		log.Debugf("[DEBUG] Using international pre-release environment")
-		return EnvInternationalPreRelease
-	case "production", "prod", "":
+	default:
 		log.Debugf("[DEBUG] Using production environment")
-		return EnvProduction
+	}
+	return parsed
+}
+
+// ParseEnvironment returns the environment named by value, accepting the same aliases
+// as AGENTBAY_ENV. An empty value is production.
+func ParseEnvironment(value string) (Environment, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "prerelease", "pre", "staging":
+		return EnvPreRelease, true
+	case "international", "prod-international", "intl", "international-prod":
+		return EnvInternationalProduction, true
+	case "international-pre", "pre-international", "intl-pre", "staging-international":
+		return EnvInternationalPreRelease, true
+	case "production", "prod", "":
+		return EnvProduction, true
 	default:
-		log.Warnf("[WARN] Unknown environment '%s', defaulting to production", env)
-		return EnvProduction
+		return EnvProduction, false
 	}
 }
 
+// IsInternational reports whether env is served by Alibaba Cloud International
+func (env Environment) IsInternational() bool {
+	return env == EnvInternationalProduction || env == EnvInternationalPreRelease
+}
+
 // GetEnvironmentConfig returns the configuration for the current environment
 func GetEnvironmentConfig() EnvironmentConfig {
-	env := GetEnvironment()
+	return EnvironmentConfigFor(GetEnvironment())
+}
 
+// EnvironmentConfigFor returns the configuration for the given environment
+func EnvironmentConfigFor(env Environment) EnvironmentConfig {
 	switch env {
 	case EnvPreRelease:
 		return prereleaseConfig
@@ -101,7 +130,8 @@ func GetEnvironmentConfig() EnvironmentConfig {
 }
 
 // GetClientID returns the OAuth client ID for the current environment.
-// If AGENTBAY_OAUTH_CLIENT_ID is set, it overrides the environment default.
+// If AGENTBAY_OAUTH_CLIENT_ID or the client ID of the active profile is set, it
+// overrides the environment default.
 // Use this for international login: the client ID from domestic (aliyun.com) is not
 // valid on international (alibabacloud.com); set AGENTBAY_OAUTH_CLIENT_ID to the
 // client ID of an app registered on Alibaba Cloud International.
@@ -110,6 +140,10 @@ func GetClientID() string {
 		log.Debugf("[DEBUG] Using OAuth client ID from AGENTBAY_OAUTH_CLIENT_ID")
 		return id
 	}
+	if id := activeProfile.ClientID; id != "" {
+		log.Debugf("[DEBUG] Using OAuth client ID from profile %s", selectedProfile)
+		return id
+	}
 	return GetEnvironmentConfig().ClientID
 }
 
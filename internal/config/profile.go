@@ -0,0 +1,209 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultProfileName is the profile used when none is selected. It always exists.
+	DefaultProfileName = "default"
+	// profileEnv selects the profile, like --profile
+	profileEnv = "AGENTBAY_PROFILE"
+)
+
+// OAuth regions accepted by Profile.Region
+const (
+	OAuthRegionDomestic      = "domestic"
+	OAuthRegionInternational = "international"
+)
+
+// Profile is a named set of settings with its own login. Empty settings fall back to
+// the defaults of the environment, and environment variables such as AGENTBAY_ENV and
+// AGENTBAY_CLI_ENDPOINT take precedence over the profile.
+type Profile struct {
+	Environment string `json:"environment,omitempty"` // as accepted by AGENTBAY_ENV
+	Endpoint    string `json:"endpoint,omitempty"`    // API endpoint
+	ClientID    string `json:"client_id,omitempty"`   // OAuth client ID
+	Region      string `json:"region,omitempty"`      // OAuth region: domestic or international
+	Token       *Token `json:"token,omitempty"`       // kept here by the plaintext credential store only
+}
+
+var (
+	// ErrProfileNotFound is returned for a profile that is not in the configuration
+	ErrProfileNotFound = errors.New("profile not found")
+
+	profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+	// profileFlag is the profile named by --profile, passed to SelectProfile
+	profileFlag string
+	// selectedProfile is the profile chosen by SelectProfile, "" until it is called
+	selectedProfile string
+	// activeProfile holds the settings of the selected profile, without its token
+	activeProfile Profile
+)
+
+// SelectProfile selects the profile for this process: name (from --profile) if set,
+// else AGENTBAY_PROFILE, else the current profile of the configuration. Its settings
+// then apply to GetEnvironment, GetClientID, LoadAPIConfig and the OAuth region.
+//
+// When the configuration cannot be read or the profile does not exist, the error is
+// returned and the defaults apply, but name is still used by GetConfig, which reports
+// the same error to the commands that need the configuration.
+func SelectProfile(name string) error {
+	profileFlag = name
+	selectedProfile = ""
+	activeProfile = Profile{}
+	c, err := readConfig()
+	if err != nil {
+		return err
+	}
+	resolved := c.resolveProfileName()
+	if !c.HasProfile(resolved) {
+		return fmt.Errorf("%w: %q (see 'agentbay profile list')", ErrProfileNotFound, resolved)
+	}
+
+	selectedProfile = resolved
+	if p := c.Profiles[resolved]; p != nil {
+		activeProfile = *p
+		activeProfile.Token = nil
+	}
+	log.Debugf("[DEBUG] Using profile %s", resolved)
+	return nil
+}
+
+// ActiveProfile returns the settings of the profile selected by SelectProfile
+func ActiveProfile() Profile {
+	return activeProfile
+}
+
+// SelectedProfileName returns the name of the profile selected by SelectProfile. If
+// it could not be selected, it returns the profile named by --profile or
+// AGENTBAY_PROFILE, and the default profile when neither is set.
+func SelectedProfileName() string {
+	if selectedProfile == "" {
+		return (&Config{}).resolveProfileName()
+	}
+	return selectedProfile
+}
+
+// resolveProfileName returns the profile a configuration loaded now works on
+func (c *Config) resolveProfileName() string {
+	for _, name := range []string{profileFlag, os.Getenv(profileEnv), c.CurrentProfile} {
+		if name != "" {
+			return name
+		}
+	}
+	return DefaultProfileName
+}
+
+// ProfileName returns the name of the active profile, whose token the configuration holds
+func (c *Config) ProfileName() string {
+	if c.profile == "" {
+		return c.resolveProfileName()
+	}
+	return c.profile
+}
+
+// ProfileNames returns the names of all profiles, sorted
+func (c *Config) ProfileNames() []string {
+	names := []string{DefaultProfileName}
+	for name := range c.Profiles {
+		if name != DefaultProfileName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasProfile reports whether the profile exists
+func (c *Config) HasProfile(name string) bool {
+	_, ok := c.Profiles[name]
+	return ok || name == DefaultProfileName
+}
+
+// GetProfile returns the settings of a profile, without its token
+func (c *Config) GetProfile(name string) (Profile, error) {
+	if !c.HasProfile(name) {
+		return Profile{}, fmt.Errorf("%w: %q", ErrProfileNotFound, name)
+	}
+	var p Profile
+	if c.Profiles[name] != nil {
+		p = *c.Profiles[name]
+	}
+	p.Token = nil
+	return p, nil
+}
+
+// AddProfile adds a profile with the given settings and saves the configuration
+func (c *Config) AddProfile(name string, p Profile) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	if _, ok := c.Profiles[name]; ok {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	if p.Environment != "" {
+		if _, ok := ParseEnvironment(p.Environment); !ok {
+			return fmt.Errorf("unknown environment %q", p.Environment)
+		}
+	}
+	p.Region = strings.ToLower(strings.TrimSpace(p.Region))
+	if p.Region != "" && p.Region != OAuthRegionDomestic && p.Region != OAuthRegionInternational {
+		return fmt.Errorf("unknown region %q (supported: %s, %s)", p.Region, OAuthRegionDomestic, OAuthRegionInternational)
+	}
+
+	p.Token = nil
+	if c.Profiles == nil {
+		c.Profiles = map[string]*Profile{}
+	}
+	c.Profiles[name] = &p
+	return c.Save()
+}
+
+// RemoveProfile removes a profile and its token. The default profile and the profile
+// in use cannot be removed.
+func (c *Config) RemoveProfile(name string) error {
+	if name == DefaultProfileName {
+		return fmt.Errorf("the %s profile cannot be removed", DefaultProfileName)
+	}
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("%w: %q", ErrProfileNotFound, name)
+	}
+	if name == c.ProfileName() {
+		return fmt.Errorf("profile %q is in use; switch to another profile first", name)
+	}
+
+	store, err := c.credentialStore(name)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(); err != nil {
+		return fmt.Errorf("failed to delete the token of profile %q: %w", name, err)
+	}
+	delete(c.Profiles, name)
+	if c.CurrentProfile == name {
+		c.CurrentProfile = ""
+	}
+	return c.Save()
+}
+
+// UseProfile makes a profile the current one for later commands and saves the
+// configuration
+func (c *Config) UseProfile(name string) error {
+	if !c.HasProfile(name) {
+		return fmt.Errorf("%w: %q", ErrProfileNotFound, name)
+	}
+	c.CurrentProfile = name
+	return c.Save()
+}
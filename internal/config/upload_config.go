@@ -0,0 +1,86 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultUploadConcurrency is how many files are uploaded at the same time by default
+	DefaultUploadConcurrency = 10
+	// MaxUploadConcurrency bounds the upload concurrency
+	MaxUploadConcurrency = 64
+)
+
+// UploadConfig controls how build-context files are uploaded
+type UploadConfig struct {
+	// Concurrency is how many files are uploaded, and how many upload credentials are
+	// requested, at the same time
+	Concurrency int
+	// LimitRate caps the total upload bandwidth in bytes per second; 0 means unlimited
+	LimitRate int64
+}
+
+// LoadUploadConfig returns the upload defaults, overridden by the
+// AGENTBAY_CLI_UPLOAD_CONCURRENCY and AGENTBAY_CLI_UPLOAD_LIMIT_RATE environment variables
+func LoadUploadConfig() UploadConfig {
+	cfg := UploadConfig{Concurrency: DefaultUploadConcurrency}
+
+	if value := os.Getenv("AGENTBAY_CLI_UPLOAD_CONCURRENCY"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > MaxUploadConcurrency {
+			log.Warnf("Warning: AGENTBAY_CLI_UPLOAD_CONCURRENCY must be between 1 and %d, got %q, using default value %d", MaxUploadConcurrency, value, cfg.Concurrency)
+		} else {
+			cfg.Concurrency = n
+			log.Debugf("[DEBUG] Using AGENTBAY_CLI_UPLOAD_CONCURRENCY from environment: %d", n)
+		}
+	}
+
+	if value := os.Getenv("AGENTBAY_CLI_UPLOAD_LIMIT_RATE"); value != "" {
+		rate, err := ParseByteRate(value)
+		if err != nil {
+			log.Warnf("Warning: Failed to parse AGENTBAY_CLI_UPLOAD_LIMIT_RATE: %v, uploading without a limit", err)
+		} else {
+			cfg.LimitRate = rate
+			log.Debugf("[DEBUG] Using AGENTBAY_CLI_UPLOAD_LIMIT_RATE from environment: %d bytes/s", rate)
+		}
+	}
+
+	return cfg
+}
+
+// ParseByteRate parses a bandwidth such as "512K", "10MB/s" or "1.5M" into bytes per
+// second. Units are powers of 1024 and case-insensitive; a bare number is bytes. "0"
+// means unlimited.
+func ParseByteRate(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	s = strings.TrimSuffix(s, "/S")
+	s = strings.TrimSuffix(s, "B")
+	multiplier := int64(1)
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		}
+		if multiplier > 1 {
+			s = s[:len(s)-1]
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, fmt.Errorf("invalid rate %q (examples: 512K, 10M, 1.5MB/s, 0 for unlimited)", value)
+	}
+	return int64(n * float64(multiplier)), nil
+}
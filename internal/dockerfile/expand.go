@@ -0,0 +1,204 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerfile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expand performs docker's word processing on a shell-form word: $VAR and ${VAR}
+// substitution (including the ${VAR:-default}, ${VAR-default}, ${VAR:+alt},
+// ${VAR+alt}, ${VAR:?msg} and ${VAR?msg} forms), quote removal and escapes.
+// Variables missing from vars expand to the empty string.
+func Expand(word string, vars map[string]string, escapeToken rune) (string, error) {
+	e := &expander{src: []rune(word), vars: vars, escape: escapeToken, quotes: true}
+	return e.process(0)
+}
+
+// ExpandVars substitutes variables in s without quote removal or escape handling, as
+// docker does for the elements of JSON-form arguments
+func ExpandVars(s string, vars map[string]string) (string, error) {
+	e := &expander{src: []rune(s), vars: vars}
+	return e.process(0)
+}
+
+type expander struct {
+	src    []rune
+	pos    int
+	vars   map[string]string
+	escape rune
+	quotes bool
+}
+
+// process expands input until stop (0 for end of input) and leaves pos on stop
+func (e *expander) process(stop rune) (string, error) {
+	var sb strings.Builder
+	for e.pos < len(e.src) {
+		ch := e.src[e.pos]
+		switch {
+		case stop != 0 && ch == stop:
+			return sb.String(), nil
+		case e.quotes && ch == e.escape:
+			e.pos++
+			if e.pos < len(e.src) {
+				sb.WriteRune(e.src[e.pos])
+				e.pos++
+			} else {
+				sb.WriteRune(ch)
+			}
+		case e.quotes && ch == '\'':
+			s, err := e.singleQuoted()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		case e.quotes && ch == '"':
+			s, err := e.doubleQuoted()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		case ch == '$':
+			s, err := e.dollar()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		default:
+			sb.WriteRune(ch)
+			e.pos++
+		}
+	}
+	if stop != 0 {
+		return "", fmt.Errorf("missing '%c' in %q", stop, string(e.src))
+	}
+	return sb.String(), nil
+}
+
+func (e *expander) singleQuoted() (string, error) {
+	e.pos++
+	start := e.pos
+	for e.pos < len(e.src) {
+		if e.src[e.pos] == '\'' {
+			s := string(e.src[start:e.pos])
+			e.pos++
+			return s, nil
+		}
+		e.pos++
+	}
+	return "", fmt.Errorf("unexpected end of statement while looking for matching single-quote")
+}
+
+func (e *expander) doubleQuoted() (string, error) {
+	var sb strings.Builder
+	e.pos++
+	for e.pos < len(e.src) {
+		ch := e.src[e.pos]
+		switch {
+		case ch == '"':
+			e.pos++
+			return sb.String(), nil
+		case ch == e.escape && e.pos+1 < len(e.src) && strings.ContainsRune(`"$`+string(e.escape), e.src[e.pos+1]):
+			sb.WriteRune(e.src[e.pos+1])
+			e.pos += 2
+		case ch == '$':
+			s, err := e.dollar()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		default:
+			sb.WriteRune(ch)
+			e.pos++
+		}
+	}
+	return "", fmt.Errorf("unexpected end of statement while looking for matching double-quote")
+}
+
+// dollar expands the variable reference starting at the '$' under pos
+func (e *expander) dollar() (string, error) {
+	e.pos++
+	if e.pos >= len(e.src) {
+		return "$", nil
+	}
+	if e.src[e.pos] != '{' {
+		name := e.name()
+		if name == "" {
+			return "$", nil
+		}
+		return e.vars[name], nil
+	}
+
+	e.pos++
+	name := e.name()
+	if name == "" {
+		return "", fmt.Errorf("bad substitution in %q", string(e.src))
+	}
+	if e.pos >= len(e.src) {
+		return "", fmt.Errorf("missing '}' in %q", string(e.src))
+	}
+	if e.src[e.pos] == '}' {
+		e.pos++
+		return e.vars[name], nil
+	}
+
+	op := string(e.src[e.pos])
+	if op == ":" && e.pos+1 < len(e.src) {
+		e.pos++
+		op += string(e.src[e.pos])
+	}
+	e.pos++
+	word, err := e.process('}')
+	if err != nil {
+		return "", err
+	}
+	e.pos++
+
+	value, set := e.vars[name]
+	switch op {
+	case ":-":
+		if value == "" {
+			return word, nil
+		}
+		return value, nil
+	case "-":
+		if !set {
+			return word, nil
+		}
+		return value, nil
+	case ":+":
+		if value != "" {
+			return word, nil
+		}
+		return "", nil
+	case "+":
+		if set {
+			return word, nil
+		}
+		return "", nil
+	case ":?", "?":
+		if !set || (op == ":?" && value == "") {
+			if word == "" {
+				word = "is not allowed to be unset"
+			}
+			return "", fmt.Errorf("%s: %s", name, word)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("unsupported modifier (%s) in substitution of %s", op, name)
+	}
+}
+
+func (e *expander) name() string {
+	start := e.pos
+	for e.pos < len(e.src) {
+		ch := e.src[e.pos]
+		if ch != '_' && !(ch >= 'a' && ch <= 'z') && !(ch >= 'A' && ch <= 'Z') && !(ch >= '0' && ch <= '9') {
+			break
+		}
+		e.pos++
+	}
+	return string(e.src[start:e.pos])
+}
@@ -0,0 +1,324 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dockerfile parses Dockerfiles into instructions with their flags, arguments,
+// heredocs and line ranges, and expands build variables the way docker build does.
+package dockerfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultEscapeToken is the escape character used unless a '# escape=' directive says otherwise
+const DefaultEscapeToken = '\\'
+
+// Dockerfile is a parsed Dockerfile
+type Dockerfile struct {
+	// Directives holds the parser directives at the top of the file (syntax, escape,
+	// check), keyed by lower-case name
+	Directives map[string]string
+	// EscapeToken is the escape and line continuation character
+	EscapeToken  rune
+	Instructions []*Instruction
+}
+
+// Instruction is a single Dockerfile instruction
+type Instruction struct {
+	// Keyword is the upper-case instruction name, e.g. COPY
+	Keyword string
+	// Flags are the leading --name[=value] options, e.g. --chown=root:root
+	Flags []Flag
+	// Args are the remaining words. In shell form they are raw, with quotes, escapes and
+	// variables still in place; use ExpandArgs to process them. Shell-form RUN, CMD and
+	// ENTRYPOINT keep the whole command as a single argument. In JSON form (JSON is
+	// true) they are the decoded array elements.
+	Args     []string
+	JSON     bool
+	Heredocs []Heredoc
+	// StartLine and EndLine are the 1-based line range of the instruction, including
+	// continuation lines and heredoc bodies
+	StartLine int
+	EndLine   int
+	// Original is the instruction text with continuation lines joined, without heredoc bodies
+	Original string
+
+	escapeToken rune
+}
+
+// Flag is an instruction option such as --chown=root:root or --link
+type Flag struct {
+	Name     string
+	Value    string
+	HasValue bool
+}
+
+// Heredoc is an inline document introduced by <<NAME in RUN, COPY or ADD
+type Heredoc struct {
+	Name    string
+	Content string
+	// Expand is false when the delimiter was quoted (<<"EOF"), which disables variable expansion
+	Expand bool
+	// StripTabs is true for <<-NAME, which strips leading tabs from the body
+	StripTabs bool
+}
+
+var (
+	directivePattern = regexp.MustCompile(`^#\s*([a-zA-Z][a-zA-Z0-9]*)\s*=\s*(.+?)\s*$`)
+	heredocPattern   = regexp.MustCompile(`^<<(-?)(["']?)([a-zA-Z_][a-zA-Z0-9_]*)(["']?)$`)
+
+	knownDirectives = map[string]bool{"syntax": true, "escape": true, "check": true}
+	jsonKeywords    = map[string]bool{"RUN": true, "CMD": true, "ENTRYPOINT": true, "COPY": true, "ADD": true, "SHELL": true, "VOLUME": true}
+	heredocKeywords = map[string]bool{"RUN": true, "COPY": true, "ADD": true}
+	commandKeywords = map[string]bool{"RUN": true, "CMD": true, "ENTRYPOINT": true}
+)
+
+// Parse parses Dockerfile content
+func Parse(content []byte) (*Dockerfile, error) {
+	text := strings.TrimPrefix(string(content), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.ReplaceAll(text, "\r", "\n"), "\n")
+
+	d := &Dockerfile{
+		Directives:  make(map[string]string),
+		EscapeToken: DefaultEscapeToken,
+	}
+
+	i := parseDirectives(d, lines)
+	if v, ok := d.Directives["escape"]; ok {
+		if v != "\\" && v != "`" {
+			return nil, fmt.Errorf("invalid escape token '%s': must be ` or \\", v)
+		}
+		d.EscapeToken = rune(v[0])
+	}
+
+	for i < len(lines) {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			i++
+			continue
+		}
+
+		start := i
+		joined, next := joinContinuation(lines, i, d.EscapeToken)
+		inst, err := parseInstruction(joined, d.EscapeToken)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", start+1, err)
+		}
+		inst.StartLine = start + 1
+
+		for k := range inst.Heredocs {
+			inst.Heredocs[k].Content, next, err = readHeredoc(lines, next, inst.Heredocs[k])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start+1, err)
+			}
+		}
+
+		inst.EndLine = next
+		d.Instructions = append(d.Instructions, inst)
+		i = next
+	}
+	return d, nil
+}
+
+// parseDirectives reads the parser directives at the top of the file and returns the
+// index of the first line after them. Directives end at the first line that is not one.
+func parseDirectives(d *Dockerfile, lines []string) int {
+	for i, line := range lines {
+		m := directivePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			return i
+		}
+		key := strings.ToLower(m[1])
+		if !knownDirectives[key] {
+			return i
+		}
+		if _, dup := d.Directives[key]; dup {
+			return i
+		}
+		d.Directives[key] = m[2]
+	}
+	return len(lines)
+}
+
+// joinContinuation joins lines[i] with the lines it continues onto and returns the
+// joined text and the index of the line after it. Comment and blank lines inside a
+// continuation are skipped, as docker does.
+func joinContinuation(lines []string, i int, escape rune) (string, int) {
+	var sb strings.Builder
+	for j := i; j < len(lines); j++ {
+		line := lines[j]
+		if j > i {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+		}
+		stripped := strings.TrimRightFunc(line, unicode.IsSpace)
+		if strings.HasSuffix(stripped, string(escape)) {
+			sb.WriteString(strings.TrimSuffix(stripped, string(escape)))
+			continue
+		}
+		sb.WriteString(line)
+		return strings.TrimSpace(sb.String()), j + 1
+	}
+	return strings.TrimSpace(sb.String()), len(lines)
+}
+
+// readHeredoc reads a heredoc body starting at lines[i] and returns it along with the
+// index of the line after the terminating delimiter
+func readHeredoc(lines []string, i int, h Heredoc) (string, int, error) {
+	var sb strings.Builder
+	for j := i; j < len(lines); j++ {
+		line := lines[j]
+		if h.StripTabs {
+			line = strings.TrimLeft(line, "\t")
+		}
+		if line == h.Name {
+			return sb.String(), j + 1, nil
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return "", 0, fmt.Errorf("unterminated heredoc <<%s", h.Name)
+}
+
+// parseInstruction splits one joined instruction into keyword, flags and arguments
+func parseInstruction(text string, escape rune) (*Instruction, error) {
+	keyword, rest := text, ""
+	if idx := strings.IndexFunc(text, unicode.IsSpace); idx >= 0 {
+		keyword, rest = text[:idx], strings.TrimSpace(text[idx:])
+	}
+	inst := &Instruction{
+		Keyword:     strings.ToUpper(keyword),
+		Original:    text,
+		escapeToken: escape,
+	}
+
+	for strings.HasPrefix(rest, "--") {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		word := rest
+		if end >= 0 {
+			word = rest[:end]
+		}
+		if word == "--" {
+			break
+		}
+		flag := Flag{Name: strings.TrimPrefix(word, "--")}
+		if eq := strings.IndexByte(flag.Name, '='); eq >= 0 {
+			flag.Name, flag.Value, flag.HasValue = flag.Name[:eq], flag.Name[eq+1:], true
+		}
+		inst.Flags = append(inst.Flags, flag)
+		rest = strings.TrimSpace(rest[len(word):])
+	}
+
+	if jsonKeywords[inst.Keyword] && strings.HasPrefix(rest, "[") {
+		var args []string
+		if err := json.Unmarshal([]byte(rest), &args); err == nil {
+			inst.Args, inst.JSON = args, true
+			return inst, nil
+		}
+	}
+
+	if heredocKeywords[inst.Keyword] {
+		for _, word := range strings.Fields(rest) {
+			m := heredocPattern.FindStringSubmatch(word)
+			if m == nil || m[2] != m[4] {
+				continue
+			}
+			inst.Heredocs = append(inst.Heredocs, Heredoc{Name: m[3], Expand: m[2] == "", StripTabs: m[1] == "-"})
+		}
+	}
+
+	if commandKeywords[inst.Keyword] {
+		// Shell-form commands are handed to the shell as one string
+		if rest != "" {
+			inst.Args = []string{rest}
+		}
+		return inst, nil
+	}
+
+	args, err := splitWords(rest, escape)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", inst.Keyword, err)
+	}
+	inst.Args = args
+	return inst, nil
+}
+
+// splitWords splits shell-form arguments on unquoted whitespace, leaving quotes and
+// escapes in the words
+func splitWords(s string, escape rune) ([]string, error) {
+	var words []string
+	var sb strings.Builder
+	inWord := false
+	var quote rune
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		switch {
+		case quote != 0:
+			sb.WriteRune(ch)
+			if ch == escape && quote == '"' && i+1 < len(runes) {
+				i++
+				sb.WriteRune(runes[i])
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == escape:
+			inWord = true
+			sb.WriteRune(ch)
+			if i+1 < len(runes) {
+				i++
+				sb.WriteRune(runes[i])
+			}
+		case ch == '"' || ch == '\'':
+			inWord = true
+			quote = ch
+			sb.WriteRune(ch)
+		case unicode.IsSpace(ch):
+			if inWord {
+				words = append(words, sb.String())
+				sb.Reset()
+				inWord = false
+			}
+		default:
+			inWord = true
+			sb.WriteRune(ch)
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unclosed quote")
+	}
+	if inWord {
+		words = append(words, sb.String())
+	}
+	return words, nil
+}
+
+// Flag returns the value of the named flag and whether it is present
+func (i *Instruction) Flag(name string) (string, bool) {
+	for _, f := range i.Flags {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+// IsHeredocArg reports whether arg is a <<NAME marker for one of the instruction's heredocs
+func (i *Instruction) IsHeredocArg(arg string) bool {
+	m := heredocPattern.FindStringSubmatch(arg)
+	if m == nil {
+		return false
+	}
+	for _, h := range i.Heredocs {
+		if h.Name == m[3] {
+			return true
+		}
+	}
+	return false
+}
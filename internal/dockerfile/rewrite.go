@@ -0,0 +1,80 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var plainWordPattern = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]+$`)
+
+// ArgRewrite describes an ARG instruction changed by SetArgDefaults
+type ArgRewrite struct {
+	// Line is the first line of the instruction in the original content
+	Line  int
+	Names []string
+}
+
+// QuoteWord quotes value so that Expand turns it back into value. Values that need no
+// quoting are returned as-is; others are single-quoted, so variables are not expanded.
+func QuoteWord(value string, escapeToken rune) (string, error) {
+	if strings.ContainsAny(value, "\r\n") {
+		return "", fmt.Errorf("value must not contain line breaks: %q", value)
+	}
+	if plainWordPattern.MatchString(value) {
+		return value, nil
+	}
+	return "'" + strings.ReplaceAll(value, "'", "'"+string(escapeToken)+"''") + "'", nil
+}
+
+// SetArgDefaults rewrites every ARG instruction that declares a name in values so its
+// default becomes that value. This has the effect of docker build --build-arg for
+// builders that do not take build arguments. Each rewritten instruction is put on a
+// single line; all other lines are kept byte for byte.
+func SetArgDefaults(content []byte, values map[string]string) ([]byte, []ArgRewrite, error) {
+	if len(values) == 0 {
+		return content, nil, nil
+	}
+	df, err := Parse(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+
+	var rewrites []ArgRewrite
+	for i := len(df.Instructions) - 1; i >= 0; i-- {
+		inst := df.Instructions[i]
+		if inst.Keyword != "ARG" {
+			continue
+		}
+		words := make([]string, len(inst.Args))
+		var names []string
+		for k, arg := range inst.Args {
+			words[k] = arg
+			name, _, _ := strings.Cut(arg, "=")
+			value, ok := values[name]
+			if !ok {
+				continue
+			}
+			quoted, err := QuoteWord(value, df.EscapeToken)
+			if err != nil {
+				return nil, nil, fmt.Errorf("build arg %s: %w", name, err)
+			}
+			words[k] = name + "=" + quoted
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		last := lines[inst.EndLine-1]
+		ending := last[len(strings.TrimRight(last, "\r\n")):]
+		replacement := "ARG " + strings.Join(words, " ") + ending
+		lines = append(lines[:inst.StartLine-1], append([]string{replacement}, lines[inst.EndLine:]...)...)
+		rewrites = append([]ArgRewrite{{Line: inst.StartLine, Names: names}}, rewrites...)
+	}
+	return []byte(strings.Join(lines, "")), rewrites, nil
+}
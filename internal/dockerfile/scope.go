@@ -0,0 +1,147 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerfile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExpandArgs returns the instruction's arguments with variables substituted from vars.
+// Shell-form words also have quotes and escapes removed.
+func (i *Instruction) ExpandArgs(vars map[string]string) ([]string, error) {
+	out := make([]string, 0, len(i.Args))
+	for _, arg := range i.Args {
+		var expanded string
+		var err error
+		if i.JSON {
+			expanded, err = ExpandVars(arg, vars)
+		} else {
+			expanded, err = Expand(arg, vars, i.escapeToken)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, expanded)
+	}
+	return out, nil
+}
+
+// CopySources returns the expanded sources and destination of a COPY or ADD
+// instruction. Heredoc sources are left out because they are inline content, not files.
+// An instruction with fewer than two arguments has no sources.
+func (i *Instruction) CopySources(vars map[string]string) ([]string, string, error) {
+	if len(i.Args) < 2 {
+		return nil, "", nil
+	}
+	expanded, err := i.ExpandArgs(vars)
+	if err != nil {
+		return nil, "", err
+	}
+	var sources []string
+	for k, arg := range i.Args[:len(i.Args)-1] {
+		if !i.JSON && i.IsHeredocArg(arg) {
+			continue
+		}
+		sources = append(sources, expanded[k])
+	}
+	return sources, expanded[len(expanded)-1], nil
+}
+
+// Walk calls fn for every instruction with the build variables in scope at that point:
+// ARGs declared before the first FROM while handling FROM lines, and the stage's ARG
+// and ENV values otherwise. buildArgs override ARG defaults like docker build
+// --build-arg. fn must not modify vars.
+func (d *Dockerfile) Walk(buildArgs map[string]string, fn func(inst *Instruction, vars map[string]string) error) error {
+	global := make(map[string]string)
+	var stage, stageEnv map[string]string
+	for _, inst := range d.Instructions {
+		scope := stage
+		if inst.Keyword == "FROM" || stage == nil {
+			scope = global
+		}
+		if err := fn(inst, scope); err != nil {
+			return err
+		}
+
+		switch inst.Keyword {
+		case "FROM":
+			stage = make(map[string]string)
+			stageEnv = make(map[string]string)
+		case "ARG":
+			if err := applyArg(inst, scope, global, stage == nil, stageEnv, buildArgs); err != nil {
+				return fmt.Errorf("line %d: %w", inst.StartLine, err)
+			}
+		case "ENV":
+			if stage == nil {
+				continue
+			}
+			if err := applyEnv(inst, stage, stageEnv); err != nil {
+				return fmt.Errorf("line %d: %w", inst.StartLine, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyArg declares the ARG's variables in scope. Inside a stage an ARG without a
+// default inherits the value of a global ARG of the same name, and ENV takes precedence
+// over ARG.
+func applyArg(inst *Instruction, scope, global map[string]string, isGlobal bool, stageEnv, buildArgs map[string]string) error {
+	for _, arg := range inst.Args {
+		name, def, hasDefault := strings.Cut(arg, "=")
+		if _, ok := stageEnv[name]; ok && !isGlobal {
+			continue
+		}
+		if v, ok := buildArgs[name]; ok {
+			scope[name] = v
+			continue
+		}
+		if hasDefault {
+			v, err := Expand(def, scope, inst.escapeToken)
+			if err != nil {
+				return err
+			}
+			scope[name] = v
+			continue
+		}
+		if v, ok := global[name]; ok && !isGlobal {
+			scope[name] = v
+		}
+	}
+	return nil
+}
+
+// applyEnv sets the ENV's variables in the stage. Both the "ENV key=value ..." and the
+// legacy "ENV key value" forms are supported.
+func applyEnv(inst *Instruction, stage, stageEnv map[string]string) error {
+	if len(inst.Args) == 0 {
+		return nil
+	}
+	if !strings.Contains(inst.Args[0], "=") {
+		v, err := Expand(strings.Join(inst.Args[1:], " "), stage, inst.escapeToken)
+		if err != nil {
+			return err
+		}
+		stage[inst.Args[0]], stageEnv[inst.Args[0]] = v, v
+		return nil
+	}
+	// All values are expanded against the variables in scope before the instruction
+	pending := make(map[string]string)
+	for _, pair := range inst.Args {
+		name, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("ENV names can not be blank and must be followed by '=': %s", pair)
+		}
+		v, err := Expand(raw, stage, inst.escapeToken)
+		if err != nil {
+			return err
+		}
+		pending[name] = v
+	}
+	for name, v := range pending {
+		stage[name], stageEnv[name] = v, v
+	}
+	return nil
+}
@@ -4,11 +4,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/agentbay/agentbay-cli/cmd"
+	"github.com/agentbay/agentbay-cli/internal/config"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
@@ -34,6 +39,7 @@ func init() {
 	rootCmd.AddCommand(cmd.VersionCmd)
 	rootCmd.AddCommand(cmd.LoginCmd)
 	rootCmd.AddCommand(cmd.LogoutCmd)
+	rootCmd.AddCommand(cmd.ProfileCmd)
 	rootCmd.AddCommand(cmd.ImageCmd)
 	rootCmd.AddCommand(cmd.SkillsCmd)
 
@@ -41,10 +47,12 @@ func init() {
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
 	rootCmd.PersistentFlags().BoolP("help", "", false, "help for agentbay")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().String("output", "table", "Output format: table, json, or yaml")
+	rootCmd.PersistentFlags().String("profile", "", "Profile to use (default: AGENTBAY_PROFILE or the current profile)")
 	rootCmd.Flags().BoolP("version", "", false, "Display the version of AgentBay CLI")
 
-	// Handle version flag and verbose flag
-	rootCmd.PersistentPreRun = func(command *cobra.Command, args []string) {
+	// Handle verbose flag and output flag
+	rootCmd.PersistentPreRunE = func(command *cobra.Command, args []string) error {
 		// Set up logging based on verbose flag
 		verbose, _ := command.Flags().GetBool("verbose")
 		if verbose {
@@ -58,6 +66,18 @@ func init() {
 			DisableTimestamp: true,
 			DisableColors:    false,
 		})
+
+		// Select the profile before anything reads the environment settings. Commands
+		// that need the configuration report its errors when they load it, so that
+		// version, help and offline commands work without it.
+		profile, _ := command.Flags().GetString("profile")
+		if err := config.SelectProfile(profile); err != nil {
+			log.Debugf("[DEBUG] Failed to select profile: %v", err)
+		}
+
+		// Select result format; progress output moves to stderr for json/yaml
+		output, _ := command.Flags().GetString("output")
+		return cmd.SetOutputFormat(output)
 	}
 
 	// Handle version flag
@@ -77,10 +97,22 @@ func main() {
 	// Load environment variables
 	_ = godotenv.Load()
 
+	// Cancel the command context on Ctrl-C / SIGTERM so in-flight requests and
+	// pollers stop; a second signal falls back to the default (immediate exit).
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	// Execute root command
-	err := rootCmd.Execute()
+	err := rootCmd.ExecuteContext(ctx)
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "[INFO] Interrupted.")
+		os.Exit(cmd.ExitCodeInterrupted)
+	}
 	if err != nil {
 		// Error messages are already displayed by cobra
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -69,14 +69,9 @@ func TestGetMcpImageInfo(t *testing.T) {
 			t.Logf("      DataDiskSize: %d", dara.Int32Value(resp.Body.Data.ImageInfo.DataDiskSize))
 			t.Logf("      SystemDiskSize: %d", dara.Int32Value(resp.Body.Data.ImageInfo.SystemDiskSize))
 			t.Logf("      UpdateTime: %s", dara.StringValue(resp.Body.Data.ImageInfo.UpdateTime))
+			t.Logf("      ImageType: %s", dara.StringValue(resp.Body.Data.ImageInfo.ImageType))
 		}
-
-		// Check ImageType from response headers
-		if resp.Headers != nil {
-			if imageType, ok := resp.Headers["X-Image-Type"]; ok && imageType != nil {
-				t.Logf("    ImageType (from header): %s", dara.StringValue(imageType))
-			}
-		}
+		t.Logf("    ImageResourceStatus: %s", dara.StringValue(resp.Body.Data.ImageResourceStatus))
 
 		if resp.Body.Data.ImageBuildInfo != nil {
 			t.Logf("    ImageBuildInfo:")
@@ -0,0 +1,86 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+const lockTemplate = "FROM registry.example.com/base:1.0\nUSER root\nWORKDIR /home\n# your changes below\n"
+
+func TestDockerfileLock_RoundTrip(t *testing.T) {
+	dockerfilePath := filepath.Join(t.TempDir(), "Dockerfile")
+	lockPath := cmd.DockerfileLockPath(dockerfilePath)
+	assert.Equal(t, dockerfilePath+".agentbay.lock", lockPath)
+
+	missing, err := cmd.ReadDockerfileLock(lockPath)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	lock := cmd.NewDockerfileLock("code-space-debian-12", []byte(lockTemplate), 3)
+	require.NoError(t, cmd.WriteDockerfileLock(lockPath, lock))
+
+	got, err := cmd.ReadDockerfileLock(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, lock, got)
+	assert.Equal(t, []string{"FROM registry.example.com/base:1.0", "USER root", "WORKDIR /home"}, got.Header)
+}
+
+func TestDockerfileLock_ReadInvalid(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "Dockerfile.agentbay.lock")
+	require.NoError(t, os.WriteFile(lockPath, []byte(`{"nonEditLineNum": 2, "header": ["FROM x"]}`), 0644))
+
+	_, err := cmd.ReadDockerfileLock(lockPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid Dockerfile lockfile")
+}
+
+func TestDockerfileLock_Check(t *testing.T) {
+	lock := cmd.NewDockerfileLock("code-space-debian-12", []byte(lockTemplate), 3)
+
+	t.Run("unchanged header with appended content", func(t *testing.T) {
+		content := lockTemplate + "RUN apt-get update\r\n"
+		assert.NoError(t, lock.Check([]byte(content), "code-space-debian-12"))
+	})
+
+	t.Run("CRLF line endings and trailing spaces are ignored", func(t *testing.T) {
+		content := "FROM registry.example.com/base:1.0  \r\nUSER root\r\nWORKDIR /home\r\n"
+		assert.NoError(t, lock.Check([]byte(content), "code-space-debian-12"))
+	})
+
+	t.Run("modified header line", func(t *testing.T) {
+		content := "FROM ubuntu:22.04\nUSER root\nWORKDIR /home\n"
+		err := lock.Check([]byte(content), "code-space-debian-12")
+		var lockErr *cmd.DockerfileLockError
+		require.True(t, errors.As(err, &lockErr))
+		require.Len(t, lockErr.Diffs, 1)
+		assert.Equal(t, 1, lockErr.Diffs[0].Line)
+		assert.Equal(t, []string{"@@ line 1 @@", "- FROM registry.example.com/base:1.0", "+ FROM ubuntu:22.04"}, lockErr.DiffLines())
+	})
+
+	t.Run("truncated header", func(t *testing.T) {
+		err := lock.Check([]byte("FROM registry.example.com/base:1.0\n"), "code-space-debian-12")
+		var lockErr *cmd.DockerfileLockError
+		require.True(t, errors.As(err, &lockErr))
+		require.Len(t, lockErr.Diffs, 2)
+		assert.True(t, lockErr.Diffs[1].Missing)
+		assert.Contains(t, lockErr.DiffLines(), "+ (missing)")
+	})
+
+	t.Run("different source image", func(t *testing.T) {
+		err := lock.Check([]byte(lockTemplate), "other-image")
+		var lockErr *cmd.DockerfileLockError
+		require.True(t, errors.As(err, &lockErr))
+		assert.Equal(t, "code-space-debian-12", lockErr.SourceImageID)
+		assert.Empty(t, lockErr.Diffs)
+	})
+}
@@ -165,6 +165,7 @@ COPY local.txt /app/
 func TestExpandSource(t *testing.T) {
 	tempDir := t.TempDir()
 	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "file.txt"), []byte("x"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "..hidden"), []byte("x"), 0644))
 	subDir := filepath.Join(tempDir, "dir")
 	require.NoError(t, os.MkdirAll(subDir, 0755))
 	require.NoError(t, os.WriteFile(filepath.Join(subDir, "nested.txt"), []byte("y"), 0644))
@@ -179,6 +180,8 @@ func TestExpandSource(t *testing.T) {
 		{name: "single file in subdir", source: "dir/nested.txt"},
 		{name: "absolute path", source: "/absolute/path", wantErr: true, errContains: "absolute source path not supported"},
 		{name: "path escapes context", source: "../outside", wantErr: true, errContains: "source path escapes context"},
+		{name: "path escapes context after cleaning", source: "dir/../../outside", wantErr: true, errContains: "source path escapes context"},
+		{name: "name starting with dots", source: "..hidden"},
 		{name: "nonexistent file", source: "nonexistent.txt", wantErr: true, errContains: "source not found"},
 	}
 
@@ -213,6 +216,46 @@ func TestExpandSource_Directory(t *testing.T) {
 	assert.Equal(t, filepath.Join(subDir, "b.txt"), got[1])
 }
 
+func TestResolveContextDir(t *testing.T) {
+	repoDir := t.TempDir()
+	dockerfilePath := filepath.Join(repoDir, "images", "agent.Dockerfile")
+	require.NoError(t, os.MkdirAll(filepath.Dir(dockerfilePath), 0755))
+	require.NoError(t, os.WriteFile(dockerfilePath, []byte("FROM base\n"), 0644))
+
+	got, err := cmd.ResolveContextDir("", dockerfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Dir(dockerfilePath), got, "defaults to the Dockerfile directory")
+
+	got, err = cmd.ResolveContextDir(repoDir, dockerfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, repoDir, got)
+
+	_, err = cmd.ResolveContextDir(filepath.Join(repoDir, "missing"), dockerfilePath)
+	assert.ErrorContains(t, err, "build context not found")
+
+	_, err = cmd.ResolveContextDir(dockerfilePath, dockerfilePath)
+	assert.ErrorContains(t, err, "not a directory")
+}
+
+func TestCollectCOPYADDSources_SeparateContext(t *testing.T) {
+	repoDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "images"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "src", "main.py"), []byte("x"), 0644))
+	content := []byte("FROM base\nCOPY src/main.py /app/\n")
+
+	files, _, err := cmd.CollectCOPYADDSources(content, repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(repoDir, "src", "main.py")}, files)
+
+	// Relative to the Dockerfile directory, the same source is missing, and sources
+	// outside the context stay rejected
+	_, _, err = cmd.CollectCOPYADDSources(content, filepath.Join(repoDir, "images"))
+	assert.ErrorContains(t, err, "source not found")
+	_, _, err = cmd.CollectCOPYADDSources([]byte("FROM base\nCOPY ../src/main.py /app/\n"), filepath.Join(repoDir, "images"))
+	assert.ErrorContains(t, err, "source path escapes context")
+}
+
 func TestRelativePathForUpload(t *testing.T) {
 	tempDir := t.TempDir()
 	subDir := filepath.Join(tempDir, "code")
@@ -295,3 +338,25 @@ func TestIsURL(t *testing.T) {
 	assert.False(t, cmd.IsURL("local/file.txt"))
 	assert.False(t, cmd.IsURL("./relative"))
 }
+
+func TestParseCOPYADDSources_VariablesAndHeredocs(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "src"), 0755))
+	appPath := filepath.Join(tempDir, "src", "app-v2.py")
+	require.NoError(t, os.WriteFile(appPath, []byte("x"), 0644))
+
+	dockerfile := `# syntax=docker/dockerfile:1
+FROM ubuntu:20.04 AS build
+ARG VERSION=2
+ENV SRC=src
+COPY --chmod=644 --link ${SRC}/app-v${VERSION}.py /app/
+COPY <<EOF /app/config.ini
+[main]
+path = /does/not/exist
+EOF
+COPY --from=build /out /out
+`
+	got, err := cmd.ParseCOPYADDSources([]byte(dockerfile), tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{appPath}, got)
+}
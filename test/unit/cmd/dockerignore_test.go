@@ -0,0 +1,94 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/cmd"
+)
+
+func TestDockerIgnore_Excluded(t *testing.T) {
+	di, err := cmd.ParseDockerIgnore([]byte(`
+# comment
+.git
+/node_modules
+**/*.log
+build/
+*.tmp
+!keep.tmp
+docs/**/draft-?.md
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{".git", true},
+		{".git/HEAD", true},
+		{"node_modules/pkg/index.js", true},
+		{"src/node_modules/pkg/index.js", false},
+		{"app.log", true},
+		{"logs/deep/app.log", true},
+		{"build/out.bin", true},
+		{"a.tmp", true},
+		{"keep.tmp", false},
+		{"sub/a.tmp", false},
+		{"docs/draft-1.md", true},
+		{"docs/x/y/draft-2.md", true},
+		{"docs/draft-10.md", false},
+		{"src/main.py", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, di.Excluded(tt.path), tt.path)
+	}
+}
+
+func TestDockerIgnore_LastMatchWins(t *testing.T) {
+	di, err := cmd.ParseDockerIgnore([]byte("data\n!data/keep\ndata/keep/secret\n"))
+	require.NoError(t, err)
+
+	assert.True(t, di.Excluded("data/a.csv"))
+	assert.False(t, di.Excluded("data/keep/b.csv"))
+	assert.True(t, di.Excluded("data/keep/secret"))
+}
+
+func TestDockerIgnore_InvalidPattern(t *testing.T) {
+	_, err := cmd.ParseDockerIgnore([]byte("[abc\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 1")
+}
+
+func TestLoadDockerIgnore_Missing(t *testing.T) {
+	di, err := cmd.LoadDockerIgnore(t.TempDir())
+	require.NoError(t, err)
+	assert.False(t, di.Excluded("anything"))
+}
+
+func TestCollectCOPYADDSources_DockerIgnore(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, f := range []string{"app/main.py", "app/.git/HEAD", "app/node_modules/x/index.js", "app/debug.log", "notes.txt", "secret.env"} {
+		p := filepath.Join(tempDir, filepath.FromSlash(f))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte("x"), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".dockerignore"), []byte("**/.git\n**/node_modules\n*/*.log\nsecret.env\n"), 0644))
+
+	files, excluded, err := cmd.CollectCOPYADDSources([]byte("FROM base\nCOPY app /app\nCOPY *.txt /docs/\n"), tempDir)
+	require.NoError(t, err)
+	sort.Strings(files)
+	assert.Equal(t, []string{filepath.Join(tempDir, "app", "main.py"), filepath.Join(tempDir, "notes.txt")}, files)
+	assert.Len(t, excluded, 3)
+
+	_, _, err = cmd.CollectCOPYADDSources([]byte("FROM base\nCOPY secret.env /app/\n"), tempDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "excluded by .dockerignore")
+}
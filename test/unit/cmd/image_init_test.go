@@ -190,3 +190,61 @@ func TestImageInitCommand_SourceImageIdFlag(t *testing.T) {
 		assert.NotContains(t, longDescWithoutSourceImageId, "--source", "Should not mention --source flag in description")
 	})
 }
+
+func TestImageInitCommand_RefusesToOverwrite(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	tempDir := t.TempDir()
+
+	var imageInitCmd *cobra.Command
+	for _, subCmd := range cmd.ImageCmd.Commands() {
+		if subCmd.Use == "init" {
+			imageInitCmd = subCmd
+			break
+		}
+	}
+	require.NotNil(t, imageInitCmd, "image init command not found")
+	for _, name := range []string{"dockerfile", "force", "stdout"} {
+		require.NotNil(t, imageInitCmd.Flags().Lookup(name), "image init should have --%s flag", name)
+	}
+	defer func() {
+		imageInitCmd.Flags().Set("dockerfile", "")
+		imageInitCmd.Flags().Set("force", "false")
+	}()
+
+	existing := filepath.Join(tempDir, "Dockerfile")
+	existingContent := []byte("FROM existing:image\n")
+	require.NoError(t, os.WriteFile(existing, existingContent, 0644))
+	imageInitCmd.Flags().Set("sourceImageId", "test-image-id")
+
+	t.Run("existing file without --force fails before authenticating", func(t *testing.T) {
+		imageInitCmd.Flags().Set("dockerfile", existing)
+		err := imageInitCmd.RunE(imageInitCmd, []string{})
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "not authenticated")
+
+		content, err := os.ReadFile(existing)
+		require.NoError(t, err)
+		assert.Equal(t, existingContent, content, "existing Dockerfile must be kept")
+	})
+
+	t.Run("a directory means the Dockerfile inside it", func(t *testing.T) {
+		imageInitCmd.Flags().Set("dockerfile", tempDir)
+		err := imageInitCmd.RunE(imageInitCmd, []string{})
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "not authenticated")
+	})
+
+	t.Run("--force and new paths get past the check", func(t *testing.T) {
+		imageInitCmd.Flags().Set("dockerfile", existing)
+		imageInitCmd.Flags().Set("force", "true")
+		err := imageInitCmd.RunE(imageInitCmd, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not authenticated")
+
+		imageInitCmd.Flags().Set("force", "false")
+		imageInitCmd.Flags().Set("dockerfile", filepath.Join(tempDir, "images", "agent.Dockerfile"))
+		err = imageInitCmd.RunE(imageInitCmd, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not authenticated")
+	})
+}
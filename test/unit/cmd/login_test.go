@@ -4,6 +4,12 @@
 package cmd_test
 
 import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -12,6 +18,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/agentbay/agentbay-cli/cmd"
+	"github.com/agentbay/agentbay-cli/internal/auth"
+	"github.com/agentbay/agentbay-cli/internal/config"
 )
 
 func TestLoginCmd(t *testing.T) {
@@ -63,3 +71,63 @@ func TestLoginCmd(t *testing.T) {
 		assert.NoError(t, cmd.LoginCmd.ValidateArgs([]string{}))
 	})
 }
+
+func TestLoginCmd_NoCallback(t *testing.T) {
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "/v1/token", r.URL.Path)
+		assert.Equal(t, "pasted-code", r.Form.Get("code"))
+		assert.Equal(t, "http://localhost:"+cmd.DefaultCallbackPort+"/callback", r.Form.Get("redirect_uri"))
+		assert.NotEmpty(t, r.Form.Get("code_verifier"), "the PKCE verifier is sent with the code")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "access", "token_type": "Bearer", "expires_in": "3600", "refresh_token": "refresh"}`))
+	}))
+	defer server.Close()
+	t.Setenv("AGENTBAY_OAUTH_URL", server.URL)
+
+	require.NotNil(t, cmd.LoginCmd.Flags().Lookup("no-callback"))
+	require.NoError(t, cmd.LoginCmd.Flags().Set("no-callback", "true"))
+	defer cmd.LoginCmd.Flags().Set("no-callback", "false")
+	defer cmd.LoginCmd.SetIn(nil)
+
+	t.Run("a redirect URL from another login attempt is rejected", func(t *testing.T) {
+		cmd.LoginCmd.SetIn(strings.NewReader("http://localhost:3001/callback?code=pasted-code&state=stale\n"))
+		err := cmd.LoginCmd.RunE(cmd.LoginCmd, []string{})
+		assert.ErrorIs(t, err, auth.ErrStateMismatch)
+	})
+
+	t.Run("a bare code is rejected", func(t *testing.T) {
+		cmd.LoginCmd.SetIn(strings.NewReader("pasted-code\n"))
+		err := cmd.LoginCmd.RunE(cmd.LoginCmd, []string{})
+		assert.ErrorContains(t, err, "full redirect URL")
+	})
+
+	t.Run("the pasted redirect URL is exchanged for tokens", func(t *testing.T) {
+		// Answer the prompt with a redirect URL carrying the state of the printed login URL
+		stdinR, stdinW := io.Pipe()
+		cmd.LoginCmd.SetIn(stdinR)
+		outR, outW, err := os.Pipe()
+		require.NoError(t, err)
+		stdout := os.Stdout
+		os.Stdout = outW
+		defer func() { os.Stdout = stdout }()
+		go func() {
+			scanner := bufio.NewScanner(outR)
+			for scanner.Scan() {
+				if u, err := url.Parse(scanner.Text()); err == nil && u.Query().Get("state") != "" {
+					fmt.Fprintf(stdinW, "http://localhost:3001/callback?code=pasted-code&state=%s\n", url.QueryEscape(u.Query().Get("state")))
+				}
+			}
+		}()
+
+		err = cmd.LoginCmd.RunE(cmd.LoginCmd, []string{})
+		os.Stdout = stdout
+		outW.Close()
+		require.NoError(t, err)
+
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.IsAuthenticated())
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// newHangingClient returns a client whose server never answers until the test ends
+func newHangingClient(t *testing.T) (agentbay.Client, *int32) {
+	t.Helper()
+	var calls int32
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}, 3)
+	return c, &calls
+}
+
+func TestClientHonorsContextCancellation(t *testing.T) {
+	calls := []struct {
+		name string
+		call func(ctx context.Context, c agentbay.Client) error
+	}{
+		{"ListMcpImages", func(ctx context.Context, c agentbay.Client) error {
+			_, err := c.ListMcpImages(ctx, &client.ListMcpImagesRequest{})
+			return err
+		}},
+		{"GetDockerImageTask", func(ctx context.Context, c agentbay.Client) error {
+			_, err := c.GetDockerImageTask(ctx, &client.GetDockerImageTaskRequest{})
+			return err
+		}},
+		{"GetDockerImageTaskLog", func(ctx context.Context, c agentbay.Client) error {
+			_, err := c.GetDockerImageTaskLog(ctx, &client.GetDockerImageTaskLogRequest{})
+			return err
+		}},
+		{"GetMarketSkillCredential", func(ctx context.Context, c agentbay.Client) error {
+			_, err := c.GetMarketSkillCredential(ctx, &client.GetMarketSkillCredentialRequest{})
+			return err
+		}},
+		{"GetDockerfileTemplate", func(ctx context.Context, c agentbay.Client) error {
+			_, err := c.GetDockerfileTemplate(ctx, &client.GetDockerfileTemplateRequest{})
+			return err
+		}},
+	}
+
+	for _, tc := range calls {
+		t.Run(tc.name, func(t *testing.T) {
+			c, hits := newHangingClient(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(100*time.Millisecond, cancel)
+
+			start := time.Now()
+			err := tc.call(ctx, c)
+			require.Error(t, err)
+			assert.Less(t, time.Since(start), 5*time.Second, "call must return promptly after cancel")
+			assert.True(t, errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "context canceled"), "got %v", err)
+			assert.Equal(t, int32(1), atomic.LoadInt32(hits), "cancelled calls must not be retried")
+		})
+	}
+}
+
+func TestClientHonorsContextDeadline(t *testing.T) {
+	c, _ := newHangingClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetMcpImageInfo(ctx, &client.GetMcpImageInfoRequest{})
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
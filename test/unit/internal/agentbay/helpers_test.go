@@ -0,0 +1,42 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// newTestClient starts a TLS server with handler and returns an authenticated client
+// pointed at it, with fast retry delays.
+func newTestClient(t *testing.T, handler http.HandlerFunc, maxRetries int) agentbay.Client {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	// The wrapper builds on http.DefaultTransport; trust the test server's certificate
+	origTransport := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	t.Cleanup(func() { http.DefaultTransport = origTransport })
+
+	cfg := &config.Config{Token: &config.Token{
+		AccessToken: "test-access-token",
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}}
+	apiConfig := &config.APIConfig{
+		Endpoint:            strings.TrimPrefix(server.URL, "https://"),
+		TimeoutMs:           60000,
+		MaxRetries:          maxRetries,
+		RetryInitialDelayMs: 1,
+		RetryMaxDelayMs:     5,
+	}
+	return agentbay.NewClient(apiConfig, cfg)
+}
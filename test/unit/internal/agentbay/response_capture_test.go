@@ -0,0 +1,97 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// TestConcurrentXMLResponsesAreNotMixed issues many concurrent credential requests
+// whose XML responses arrive out of order and checks each caller gets its own OSS URL.
+// Run with -race to also catch unsynchronized access to the captured bodies.
+func TestConcurrentXMLResponsesAreNotMixed(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		filePath := r.URL.Query().Get("FilePath")
+		time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+		w.Header().Set("Content-Type", "text/xml;charset=utf-8")
+		fmt.Fprintf(w, `<?xml version='1.0' encoding='UTF-8'?><GetDockerFileStoreCredentialResponse><RequestId>req-%[1]s</RequestId><HttpStatusCode>200</HttpStatusCode><Data><TaskId>task-1</TaskId><OssUrl>https://bucket.example.com/%[1]s</OssUrl></Data><Code>success</Code><Success>true</Success></GetDockerFileStoreCredentialResponse>`, filePath)
+	}, 0)
+
+	const n = 30
+	var wg sync.WaitGroup
+	urls := make([]string, n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := c.GetDockerFileStoreCredential(context.Background(), &client.GetDockerFileStoreCredentialRequest{
+				Source:       dara.String("AgentBay"),
+				FilePath:     dara.String(fmt.Sprintf("file-%d", i)),
+				IsDockerfile: dara.String("false"),
+				TaskId:       dara.String("task-1"),
+			})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			urls[i] = dara.StringValue(resp.Body.Data.GetOssUrl())
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, errs[i], "request %d", i)
+		assert.Equal(t, fmt.Sprintf("https://bucket.example.com/file-%d", i), urls[i], "request %d got another request's response", i)
+	}
+}
+
+func TestClientDecodesXMLResponsesByContentType(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `<Error><RequestId>req-fail</RequestId><Code>ServiceUnavailable</Code><Message>busy</Message></Error>`)
+			return
+		}
+		fmt.Fprint(w, `<ListMcpImagesResponse><RequestId>req-ok</RequestId><Success>true</Success><TotalCount>1</TotalCount><Data><data><ImageId>imgc-1</ImageId></data></Data></ListMcpImagesResponse>`)
+	}, 1)
+
+	resp, err := c.ListMcpImages(context.Background(), &client.ListMcpImagesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "XML 503 must be retried like a JSON one")
+	assert.Equal(t, int32(200), dara.Int32Value(resp.StatusCode))
+	assert.Equal(t, "req-ok", dara.StringValue(resp.Body.RequestId))
+	require.Len(t, resp.Body.Data, 1)
+	assert.Equal(t, "imgc-1", dara.StringValue(resp.Body.Data[0].ImageId))
+}
+
+func TestClientReturnsXMLErrorResponses(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<Error><RequestId>req-bad</RequestId><Code>InvalidParameter</Code><Message>ImageId is invalid</Message></Error>`)
+	}, 3)
+
+	_, err := c.GetMcpImageInfo(context.Background(), &client.GetMcpImageInfoRequest{ImageId: dara.String("bad")})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, client.StatusCodeFromError(err))
+	assert.Contains(t, err.Error(), "InvalidParameter")
+	assert.Contains(t, err.Error(), "ImageId is invalid")
+	assert.Contains(t, err.Error(), "req-bad")
+}
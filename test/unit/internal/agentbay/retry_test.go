@@ -0,0 +1,91 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package agentbay
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/agentbay"
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+// newRetryTestClient returns a client whose server fails the first `failures` requests
+// with `status` and then answers with body, plus a counter of requests served.
+func newRetryTestClient(t *testing.T, failures int32, status int, body string, maxRetries int) (agentbay.Client, *int32) {
+	t.Helper()
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if n <= failures {
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"RequestId":"req-fail","Code":"ServiceUnavailable","Message":"try again"}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}, maxRetries)
+	return c, &calls
+}
+
+func TestClientRetriesIdempotentCalls(t *testing.T) {
+	for _, status := range []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusBadGateway} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			c, calls := newRetryTestClient(t, 2, status, `{"RequestId":"req-ok","Success":true,"TotalCount":0}`, 3)
+
+			resp, err := c.ListMcpImages(context.Background(), &client.ListMcpImagesRequest{})
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+		})
+	}
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	c, calls := newRetryTestClient(t, 100, http.StatusServiceUnavailable, `{}`, 2)
+
+	_, err := c.GetDockerImageTask(context.Background(), &client.GetDockerImageTaskRequest{})
+	require.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls), "1 attempt + 2 retries")
+}
+
+func TestClientDoesNotRetryClientErrors(t *testing.T) {
+	c, calls := newRetryTestClient(t, 100, http.StatusBadRequest, `{}`, 3)
+
+	_, err := c.ListMcpImages(context.Background(), &client.ListMcpImagesRequest{})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestClientDoesNotRetryNonIdempotentCalls(t *testing.T) {
+	t.Run("CreateDockerImageTask", func(t *testing.T) {
+		c, calls := newRetryTestClient(t, 100, http.StatusServiceUnavailable, `{}`, 3)
+		_, err := c.CreateDockerImageTask(context.Background(), &client.CreateDockerImageTaskRequest{})
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("CreateResourceGroup", func(t *testing.T) {
+		c, calls := newRetryTestClient(t, 100, http.StatusServiceUnavailable, `{}`, 3)
+		_, err := c.CreateResourceGroup(context.Background(), &client.CreateResourceGroupRequest{})
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+}
+
+func TestClientRetriesDisabled(t *testing.T) {
+	c, calls := newRetryTestClient(t, 100, http.StatusServiceUnavailable, `{}`, 0)
+
+	_, err := c.ListMcpImages(context.Background(), &client.ListMcpImagesRequest{})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
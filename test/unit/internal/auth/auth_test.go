@@ -5,6 +5,11 @@ package auth_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -26,7 +31,7 @@ func TestOAuthFlow(t *testing.T) {
 		redirectURI := "http://localhost:3001/callback"
 		state := "test-state"
 
-		authURL := auth.BuildAuthURL(clientID, redirectURI, state)
+		authURL := auth.BuildAuthURL(clientID, redirectURI, state, "")
 
 		parsedURL, err := url.Parse(authURL)
 		assert.NoError(t, err)
@@ -44,7 +49,7 @@ func TestOAuthFlow(t *testing.T) {
 		os.Unsetenv("AGENTBAY_ENV")
 		os.Setenv("AGENTBAY_OAUTH_REGION", "international")
 		defer os.Unsetenv("AGENTBAY_OAUTH_REGION")
-		authURL := auth.BuildAuthURL("client-id", "http://localhost:3001/callback", "state")
+		authURL := auth.BuildAuthURL("client-id", "http://localhost:3001/callback", "state", "")
 		parsedURL, err := url.Parse(authURL)
 		assert.NoError(t, err)
 		assert.Equal(t, "signin.alibabacloud.com", parsedURL.Host)
@@ -55,7 +60,7 @@ func TestOAuthFlow(t *testing.T) {
 		os.Unsetenv("AGENTBAY_OAUTH_REGION")
 		os.Setenv("AGENTBAY_ENV", "international")
 		defer os.Unsetenv("AGENTBAY_ENV")
-		authURL := auth.BuildAuthURL("client-id", "http://localhost:3001/callback", "state")
+		authURL := auth.BuildAuthURL("client-id", "http://localhost:3001/callback", "state", "")
 		parsedURL, err := url.Parse(authURL)
 		assert.NoError(t, err)
 		assert.Equal(t, "signin.alibabacloud.com", parsedURL.Host)
@@ -66,7 +71,7 @@ func TestOAuthFlow(t *testing.T) {
 		os.Unsetenv("AGENTBAY_OAUTH_REGION")
 		os.Setenv("AGENTBAY_ENV", "international-pre")
 		defer os.Unsetenv("AGENTBAY_ENV")
-		authURL := auth.BuildAuthURL("client-id", "http://localhost:3001/callback", "state")
+		authURL := auth.BuildAuthURL("client-id", "http://localhost:3001/callback", "state", "")
 		parsedURL, err := url.Parse(authURL)
 		assert.NoError(t, err)
 		assert.Equal(t, "signin.alibabacloud.com", parsedURL.Host)
@@ -110,7 +115,7 @@ func TestOAuthFlow(t *testing.T) {
 
 		// This test would need the auth package to be modified to accept custom endpoints
 		// For now, we'll test the function exists and has the right signature
-		_, err := auth.ExchangeCodeForToken(clientID, redirectURI, code)
+		_, err := auth.ExchangeCodeForToken(clientID, redirectURI, code, "")
 		// We expect an error since we're not hitting the real endpoint
 		assert.Error(t, err)
 	})
@@ -144,7 +149,7 @@ func TestCallbackServer(t *testing.T) {
 		errChan := make(chan error, 1)
 
 		go func() {
-			code, err := auth.StartCallbackServer(ctx, port)
+			code, err := auth.StartCallbackServer(ctx, port, "test-state")
 			if err != nil {
 				errChan <- err
 				return
@@ -185,7 +190,7 @@ func TestCallbackServer(t *testing.T) {
 		errChan := make(chan error, 1)
 
 		go func() {
-			code, err := auth.StartCallbackServer(ctx, port)
+			code, err := auth.StartCallbackServer(ctx, port, "test-state")
 			if err != nil {
 				errChan <- err
 				return
@@ -217,3 +222,111 @@ func TestCallbackServer(t *testing.T) {
 		}
 	})
 }
+
+func TestParseCallbackInput(t *testing.T) {
+	const state = "expected-state"
+
+	code, err := auth.ParseCallbackInput("  http://localhost:3001/callback?code=abc123&state=expected-state\n", state)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", code)
+
+	code, err = auth.ParseCallbackInput("code=abc123&state=expected-state", state)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", code, "the query string alone is accepted")
+
+	_, err = auth.ParseCallbackInput("abc123\n", state)
+	assert.ErrorContains(t, err, "full redirect URL", "a bare code cannot be checked against the state")
+
+	_, err = auth.ParseCallbackInput("code=abc123", state)
+	assert.ErrorIs(t, err, auth.ErrStateMismatch, "a query string without state is rejected")
+
+	_, err = auth.ParseCallbackInput("http://localhost:3001/callback?code=abc123&state=other", state)
+	assert.ErrorIs(t, err, auth.ErrStateMismatch)
+
+	_, err = auth.ParseCallbackInput("http://localhost:3001/callback?code=abc123", state)
+	assert.ErrorIs(t, err, auth.ErrStateMismatch, "a URL without state is rejected")
+
+	_, err = auth.ParseCallbackInput("http://localhost:3001/callback?error=access_denied&error_description=User+denied&state=expected-state", state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access_denied (User denied)")
+
+	_, err = auth.ParseCallbackInput("   ", state)
+	assert.Error(t, err)
+}
+
+func TestCallbackServer_RejectsBadCallbacks(t *testing.T) {
+	cases := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{"state mismatch", "code=test-code&state=other-state", "state mismatch"},
+		{"missing state", "code=test-code", "state mismatch"},
+		{"user denied consent", "error=access_denied&error_description=User+denied+the+request&state=test-state", "access_denied (User denied the request)"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", ":0")
+			require.NoError(t, err)
+			port := fmt.Sprintf("%d", listener.Addr().(*net.TCPAddr).Port)
+			listener.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			errChan := make(chan error, 1)
+			go func() {
+				_, err := auth.StartCallbackServer(ctx, port, "test-state")
+				errChan <- err
+			}()
+			time.Sleep(100 * time.Millisecond)
+
+			resp, err := http.Get("http://localhost:" + port + "/callback?" + tc.query)
+			require.NoError(t, err)
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Contains(t, string(body), "Authentication Failed")
+
+			select {
+			case err := <-errChan:
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			case <-time.After(2 * time.Second):
+				t.Fatal("the login must fail right away instead of waiting for the timeout")
+			}
+		})
+	}
+}
+
+func TestPKCE(t *testing.T) {
+	verifier, challenge, err := auth.GeneratePKCE()
+	require.NoError(t, err)
+	assert.Len(t, verifier, 43, "32 random bytes, base64url without padding")
+	sum := sha256.Sum256([]byte(verifier))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(sum[:]), challenge)
+
+	authURL, err := url.Parse(auth.BuildAuthURL("client-id", "http://localhost:3001/callback", "state", challenge))
+	require.NoError(t, err)
+	assert.Equal(t, challenge, authURL.Query().Get("code_challenge"))
+	assert.Equal(t, "S256", authURL.Query().Get("code_challenge_method"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.Form.Get("code_verifier") != verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_grant", "error_description": "code_verifier mismatch"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token": "access", "expires_in": "3600"}`))
+	}))
+	defer server.Close()
+	t.Setenv("AGENTBAY_OAUTH_URL", server.URL)
+
+	token, err := auth.ExchangeCodeForToken("client-id", "http://localhost:3001/callback", "code", verifier)
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+
+	_, err = auth.ExchangeCodeForToken("client-id", "http://localhost:3001/callback", "code", "wrong")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_grant (code_verifier mismatch)")
+}
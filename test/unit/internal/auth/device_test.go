@@ -0,0 +1,135 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/auth"
+)
+
+// fakeDeviceServer is a local OAuth server for the device authorization grant. The
+// token endpoint answers each device code with its scripted responses in order,
+// repeating the last one.
+type fakeDeviceServer struct {
+	t         *testing.T
+	responses map[string][]string // device_code -> error codes, "" for a token
+
+	mu    sync.Mutex
+	polls map[string]int
+}
+
+func newFakeDeviceServer(t *testing.T, responses map[string][]string) *fakeDeviceServer {
+	f := &fakeDeviceServer{t: t, responses: responses, polls: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(server.Close)
+	t.Setenv("AGENTBAY_OAUTH_URL", server.URL)
+	return f
+}
+
+func (f *fakeDeviceServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, http.MethodPost, r.Method)
+	require.NoError(f.t, r.ParseForm())
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.URL.Path {
+	case "/v1/device/code":
+		assert.Equal(f.t, "test-client", r.Form.Get("client_id"))
+		w.Write([]byte(`{"device_code": "dev-1", "user_code": "ABCD-EFGH",
+			"verification_uri": "https://example.com/device", "expires_in": 600, "interval": 1}`))
+	case "/v1/token":
+		assert.Equal(f.t, "urn:ietf:params:oauth:grant-type:device_code", r.Form.Get("grant_type"))
+		assert.Equal(f.t, "test-client", r.Form.Get("client_id"))
+		code := r.Form.Get("device_code")
+		f.mu.Lock()
+		script := f.responses[code]
+		i := f.polls[code]
+		f.polls[code]++
+		f.mu.Unlock()
+		if i >= len(script) {
+			i = len(script) - 1
+		}
+		if script[i] == "" {
+			w.Write([]byte(`{"access_token": "access", "token_type": "Bearer", "expires_in": "3600", "refresh_token": "refresh"}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "` + script[i] + `", "error_description": "scripted"}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeDeviceServer) pollCount(code string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.polls[code]
+}
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	fake := newFakeDeviceServer(t, map[string][]string{
+		"dev-1":   {"authorization_pending", ""},
+		"denied":  {"access_denied"},
+		"expired": {"expired_token"},
+		"invalid": {"invalid_client"},
+		"pending": {"authorization_pending"},
+		"slow":    {"slow_down", ""},
+	})
+
+	t.Run("pending then authorized", func(t *testing.T) {
+		t.Parallel()
+		da, err := auth.RequestDeviceAuthorization(context.Background(), "test-client")
+		require.NoError(t, err)
+		assert.Equal(t, "ABCD-EFGH", da.UserCode)
+		assert.Equal(t, "https://example.com/device", da.VerificationURI)
+
+		token, err := auth.PollDeviceToken(context.Background(), "test-client", da)
+		require.NoError(t, err)
+		assert.Equal(t, "access", token.AccessToken)
+		assert.Equal(t, "refresh", token.RefreshToken)
+		assert.Equal(t, 2, fake.pollCount("dev-1"))
+	})
+
+	t.Run("access_denied", func(t *testing.T) {
+		t.Parallel()
+		_, err := auth.PollDeviceToken(context.Background(), "test-client", &auth.DeviceAuthorization{DeviceCode: "denied", Interval: 1})
+		assert.ErrorIs(t, err, auth.ErrDeviceAccessDenied)
+	})
+
+	t.Run("expired_token", func(t *testing.T) {
+		t.Parallel()
+		_, err := auth.PollDeviceToken(context.Background(), "test-client", &auth.DeviceAuthorization{DeviceCode: "expired", Interval: 1})
+		assert.ErrorIs(t, err, auth.ErrDeviceCodeExpired)
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		t.Parallel()
+		_, err := auth.PollDeviceToken(context.Background(), "test-client", &auth.DeviceAuthorization{DeviceCode: "invalid", Interval: 1})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid_client (scripted)")
+	})
+
+	t.Run("code expires while pending", func(t *testing.T) {
+		t.Parallel()
+		_, err := auth.PollDeviceToken(context.Background(), "test-client", &auth.DeviceAuthorization{DeviceCode: "pending", Interval: 1, ExpiresIn: 2})
+		assert.ErrorIs(t, err, auth.ErrDeviceCodeExpired)
+	})
+
+	t.Run("slow_down increases the interval", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		_, err := auth.PollDeviceToken(ctx, "test-client", &auth.DeviceAuthorization{DeviceCode: "slow", Interval: 1})
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "the caller's deadline is not reported as an expired code")
+		assert.Equal(t, 1, fake.pollCount("slow"), "no second poll within the slowed-down interval")
+	})
+}
@@ -117,7 +117,7 @@ func TestStartCallbackServer_PortBinding(t *testing.T) {
 		errChan := make(chan error, 1)
 
 		go func() {
-			code, err := auth.StartCallbackServer(ctx, port)
+			code, err := auth.StartCallbackServer(ctx, port, "test-state")
 			if err != nil {
 				errChan <- err
 				return
@@ -162,7 +162,7 @@ func TestStartCallbackServer_PortBinding(t *testing.T) {
 		defer cancel()
 
 		// Try to start server on occupied port
-		code, err := auth.StartCallbackServer(ctx, port)
+		code, err := auth.StartCallbackServer(ctx, port, "test-state")
 
 		// Should fail immediately
 		assert.Error(t, err, "Should fail when port is occupied")
@@ -191,7 +191,7 @@ func TestStartCallbackServer_PortBinding(t *testing.T) {
 
 		// Start both servers at nearly the same time
 		go func() {
-			_, err := auth.StartCallbackServer(ctx, port)
+			_, err := auth.StartCallbackServer(ctx, port, "test-state")
 			errChan1 <- err
 		}()
 
@@ -199,7 +199,7 @@ func TestStartCallbackServer_PortBinding(t *testing.T) {
 		time.Sleep(50 * time.Millisecond)
 
 		go func() {
-			_, err := auth.StartCallbackServer(ctx, port)
+			_, err := auth.StartCallbackServer(ctx, port, "test-state")
 			errChan2 <- err
 		}()
 
@@ -241,7 +241,7 @@ func TestStartCallbackServer_PortOccupied(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 		defer cancel()
 
-		_, err = auth.StartCallbackServer(ctx, port)
+		_, err = auth.StartCallbackServer(ctx, port, "test-state")
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "port")
@@ -0,0 +1,145 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/client"
+)
+
+func TestResponseFormat(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		{contentType: "application/json", body: `{}`, want: client.FormatJSON},
+		{contentType: "application/json;charset=utf-8", body: `<not-json/>`, want: client.FormatJSON},
+		{contentType: "text/xml;charset=utf-8", body: `<a/>`, want: client.FormatXML},
+		{contentType: "application/xml", body: `{}`, want: client.FormatXML},
+		{contentType: "application/problem+xml", body: `<a/>`, want: client.FormatXML},
+		{contentType: "", body: "  \n<a/>", want: client.FormatXML},
+		{contentType: "text/plain", body: `{"a":1}`, want: client.FormatJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType+" "+tt.body, func(t *testing.T) {
+			assert.Equal(t, tt.want, client.ResponseFormat(tt.contentType, []byte(tt.body)))
+		})
+	}
+}
+
+func TestDecodeResponseBodyXMLList(t *testing.T) {
+	body := `<?xml version='1.0' encoding='UTF-8'?>
+<ListMcpImagesResponse>
+  <RequestId>req-1</RequestId>
+  <HttpStatusCode>200</HttpStatusCode>
+  <Success>true</Success>
+  <TotalCount>2</TotalCount>
+  <Data>
+    <data>
+      <ImageId>imgc-1</ImageId>
+      <ImageResourceGroupInfo><ResourceGroupId>rg-1</ResourceGroupId></ImageResourceGroupInfo>
+      <ImageInfo><OsName>Linux</OsName><DataDiskSize>40</DataDiskSize></ImageInfo>
+      <ToolInfo>
+        <McpServerName>browser</McpServerName>
+        <ToolList><Tool>open</Tool></ToolList>
+        <ToolList><Tool>click</Tool></ToolList>
+      </ToolInfo>
+      <ToolInfo><McpServerName>shell</McpServerName></ToolInfo>
+    </data>
+    <data><ImageId>imgc-2</ImageId></data>
+  </Data>
+</ListMcpImagesResponse>`
+
+	var out client.ListMcpImagesResponseBody
+	require.NoError(t, client.DecodeResponseBody("text/xml", []byte(body), &out))
+
+	assert.Equal(t, "req-1", dara.StringValue(out.RequestId))
+	assert.Equal(t, int32(200), dara.Int32Value(out.HttpStatusCode))
+	assert.True(t, dara.BoolValue(out.Success))
+	assert.Equal(t, int32(2), dara.Int32Value(out.TotalCount))
+	require.Len(t, out.Data, 2)
+
+	first := out.Data[0]
+	assert.Equal(t, "imgc-1", dara.StringValue(first.ImageId))
+	assert.Equal(t, "rg-1", dara.StringValue(first.ImageResourceGroupInfo.ResourceGroupId))
+	assert.Equal(t, "Linux", dara.StringValue(first.ImageInfo.OsName))
+	assert.Equal(t, int32(40), dara.Int32Value(first.ImageInfo.DataDiskSize))
+	require.Len(t, first.ToolInfo, 2)
+	assert.Equal(t, "browser", dara.StringValue(first.ToolInfo[0].McpServerName))
+	require.Len(t, first.ToolInfo[0].ToolList, 2)
+	assert.Equal(t, "click", dara.StringValue(first.ToolInfo[0].ToolList[1].Tool))
+	assert.Equal(t, "shell", dara.StringValue(first.ToolInfo[1].McpServerName))
+
+	assert.Equal(t, "imgc-2", dara.StringValue(out.Data[1].ImageId))
+	assert.Nil(t, out.Data[1].ImageInfo)
+}
+
+func TestDecodeResponseBodyXMLEmptyValues(t *testing.T) {
+	body := `<ListMcpImagesResponse><Data/><Message></Message><PageSize></PageSize></ListMcpImagesResponse>`
+
+	var out client.ListMcpImagesResponseBody
+	require.NoError(t, client.DecodeResponseBody("application/xml", []byte(body), &out))
+
+	assert.Empty(t, out.Data)
+	require.NotNil(t, out.Message)
+	assert.Equal(t, "", *out.Message)
+	assert.Nil(t, out.PageSize)
+}
+
+func TestDecodeResponseBodyXMLConvertsScalars(t *testing.T) {
+	body := `<GetDockerfileTemplateResponse>
+  <Data>
+    <NonEditLineNum> 3 </NonEditLineNum>
+    <DockerfileContent>FROM base
+RUN echo hi
+</DockerfileContent>
+  </Data>
+</GetDockerfileTemplateResponse>`
+
+	var out client.GetDockerfileTemplateResponseBody
+	require.NoError(t, client.DecodeResponseBody("text/xml", []byte(body), &out))
+	assert.Equal(t, int32(3), dara.Int32Value(out.Data.NonEditLineNum))
+	assert.Equal(t, "FROM base\nRUN echo hi\n", dara.StringValue(out.Data.DockerfileContent))
+
+	bad := `<GetDockerfileTemplateResponse><Data><NonEditLineNum>three</NonEditLineNum></Data></GetDockerfileTemplateResponse>`
+	err := client.DecodeResponseBody("text/xml", []byte(bad), &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NonEditLineNum")
+}
+
+func TestDecodeResponseBodyJSON(t *testing.T) {
+	body := `{"RequestId":"req-2","Data":{"ImageId":"imgc-1","ImageResourceStatus":"RESOURCE_PUBLISHED","ImageInfo":{"ImageType":"User"}}}`
+
+	var out client.GetMcpImageInfoResponseBody
+	require.NoError(t, client.DecodeResponseBody("application/json", []byte(body), &out))
+	assert.Equal(t, "req-2", dara.StringValue(out.RequestId))
+	assert.Equal(t, "RESOURCE_PUBLISHED", dara.StringValue(out.Data.ImageResourceStatus))
+	assert.Equal(t, "User", dara.StringValue(out.Data.ImageInfo.ImageType))
+
+	err := client.DecodeResponseBody("application/json", []byte(`<xml/>`), &out)
+	require.Error(t, err, "Content-Type wins over body sniffing")
+}
+
+func TestDecodeResponse(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "text/xml")
+	header.Set("X-Acs-Request-Id", "req-3")
+	body := `<GetMcpImageInfoResponse><Success>true</Success><Data><ImageResourceStatus>IMAGE_AVAILABLE</ImageResourceStatus><ImageInfo><ImageType>System</ImageType></ImageInfo></Data></GetMcpImageInfoResponse>`
+
+	var out client.GetMcpImageInfoResponse
+	require.NoError(t, client.DecodeResponse(http.StatusOK, header, []byte(body), &out))
+	assert.Equal(t, int32(200), dara.Int32Value(out.StatusCode))
+	assert.Equal(t, "req-3", dara.StringValue(out.Headers["x-acs-request-id"]))
+	require.NotNil(t, out.Body)
+	assert.True(t, dara.BoolValue(out.Body.Success))
+	assert.Equal(t, "IMAGE_AVAILABLE", dara.StringValue(out.Body.Data.ImageResourceStatus))
+	assert.Equal(t, "System", dara.StringValue(out.Body.Data.ImageInfo.ImageType))
+}
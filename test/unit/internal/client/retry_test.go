@@ -4,13 +4,19 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"net"
 	"net/http"
 	"testing"
+	"time"
+
+	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
+	"github.com/alibabacloud-go/tea/dara"
 
 	"github.com/agentbay/agentbay-cli/internal/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestIsRetryableError tests the IsRetryableError function
@@ -171,3 +177,135 @@ func TestNetErrorRetryable(t *testing.T) {
 	// This should be retryable because it contains "i/o timeout"
 	assert.True(t, result)
 }
+
+// TestRetryConfigBackoff tests exponential growth, the MaxDelay cap and jitter bounds
+func TestRetryConfigBackoff(t *testing.T) {
+	cfg := &client.RetryConfig{
+		MaxRetries:    5,
+		InitialDelay:  100 * time.Millisecond,
+		MaxDelay:      1 * time.Second,
+		BackoffFactor: 2.0,
+	}
+
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{attempt: 1, ceiling: 100 * time.Millisecond},
+		{attempt: 2, ceiling: 200 * time.Millisecond},
+		{attempt: 3, ceiling: 400 * time.Millisecond},
+		{attempt: 5, ceiling: 1 * time.Second},
+		{attempt: 50, ceiling: 1 * time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			d := cfg.Backoff(tt.attempt)
+			assert.GreaterOrEqual(t, d, tt.ceiling/2, "attempt %d", tt.attempt)
+			assert.LessOrEqual(t, d, tt.ceiling, "attempt %d", tt.attempt)
+		}
+	}
+}
+
+// TestParseRetryAfter tests both the delta-seconds and HTTP-date forms
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d, ok := client.ParseRetryAfter("7", now)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, d)
+
+	d, ok = client.ParseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+
+	d, ok = client.ParseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	for _, invalid := range []string{"", "  ", "-1", "soon"} {
+		_, ok = client.ParseRetryAfter(invalid, now)
+		assert.False(t, ok, "value %q", invalid)
+	}
+}
+
+// TestStatusCodeAndRetryAfterFromError tests extraction from SDK error types
+func TestStatusCodeAndRetryAfterFromError(t *testing.T) {
+	serverErr := &openapi.ServerError{StatusCode: dara.Int(503)}
+	assert.Equal(t, 503, client.StatusCodeFromError(serverErr))
+	assert.Equal(t, time.Duration(0), client.RetryAfterFromError(serverErr))
+
+	throttled := &openapi.ThrottlingError{StatusCode: dara.Int(400), RetryAfter: dara.Int64(1500)}
+	assert.Equal(t, 400, client.StatusCodeFromError(throttled))
+	assert.Equal(t, 1500*time.Millisecond, client.RetryAfterFromError(throttled))
+
+	assert.Equal(t, 0, client.StatusCodeFromError(errors.New("connection reset by peer")))
+}
+
+// TestDoWithRetry tests the retry loop's stop conditions
+func TestDoWithRetry(t *testing.T) {
+	cfg := &client.RetryConfig{MaxRetries: 3, InitialDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond, BackoffFactor: 2.0}
+	retryAll := func(error) client.RetryDecision { return client.RetryDecision{Retry: true} }
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		attempts := 0
+		result, err := client.DoWithRetry(context.Background(), cfg, func(ctx context.Context, attempt int) (string, error) {
+			attempts++
+			if attempt < 2 {
+				return "", errors.New("connection reset by peer")
+			}
+			return "ok", nil
+		}, retryAll)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", result)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("stops after MaxRetries", func(t *testing.T) {
+		attempts := 0
+		_, err := client.DoWithRetry(context.Background(), cfg, func(ctx context.Context, attempt int) (int, error) {
+			attempts++
+			return 0, errors.New("still failing")
+		}, retryAll)
+		require.Error(t, err)
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("stops on non-retryable error", func(t *testing.T) {
+		attempts := 0
+		_, err := client.DoWithRetry(context.Background(), cfg, func(ctx context.Context, attempt int) (int, error) {
+			attempts++
+			return 0, errors.New("bad request")
+		}, func(error) client.RetryDecision { return client.RetryDecision{} })
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		_, err := client.DoWithRetry(ctx, cfg, func(ctx context.Context, attempt int) (int, error) {
+			attempts++
+			cancel()
+			return 0, errors.New("timeout")
+		}, retryAll)
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("server-suggested wait is honored", func(t *testing.T) {
+		start := time.Now()
+		attempts := 0
+		_, err := client.DoWithRetry(context.Background(), cfg, func(ctx context.Context, attempt int) (int, error) {
+			attempts++
+			if attempt == 0 {
+				return 0, errors.New("throttled")
+			}
+			return 1, nil
+		}, func(error) client.RetryDecision {
+			return client.RetryDecision{Retry: true, Wait: 50 * time.Millisecond}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+func TestLoadAPIConfigRetrySettings(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_MAX_RETRIES", "")
+		t.Setenv("AGENTBAY_CLI_RETRY_INITIAL_DELAY_MS", "")
+		t.Setenv("AGENTBAY_CLI_RETRY_MAX_DELAY_MS", "")
+
+		cfg := config.LoadAPIConfig(nil)
+		assert.Equal(t, 3, cfg.MaxRetries)
+		assert.Equal(t, 0, cfg.RetryInitialDelayMs)
+		assert.Equal(t, 0, cfg.RetryMaxDelayMs)
+	})
+
+	t.Run("environment overrides", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_MAX_RETRIES", "0")
+		t.Setenv("AGENTBAY_CLI_RETRY_INITIAL_DELAY_MS", "250")
+		t.Setenv("AGENTBAY_CLI_RETRY_MAX_DELAY_MS", "4000")
+
+		cfg := config.LoadAPIConfig(nil)
+		assert.Equal(t, 0, cfg.MaxRetries)
+		assert.Equal(t, 250, cfg.RetryInitialDelayMs)
+		assert.Equal(t, 4000, cfg.RetryMaxDelayMs)
+	})
+
+	t.Run("invalid values keep defaults", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_MAX_RETRIES", "many")
+		t.Setenv("AGENTBAY_CLI_RETRY_INITIAL_DELAY_MS", "-5")
+
+		cfg := config.LoadAPIConfig(nil)
+		assert.Equal(t, 3, cfg.MaxRetries)
+		assert.Equal(t, 0, cfg.RetryInitialDelayMs)
+	})
+
+	t.Run("explicit config is copied", func(t *testing.T) {
+		cfg := config.LoadAPIConfig(&config.APIConfig{Endpoint: "example.com", TimeoutMs: 1000, MaxRetries: 5, RetryMaxDelayMs: 100})
+		assert.Equal(t, 5, cfg.MaxRetries)
+		assert.Equal(t, 100, cfg.RetryMaxDelayMs)
+	})
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// setupCredentialTest points the configuration at a temporary directory with the
+// given credential store and returns the directory
+func setupCredentialTest(t *testing.T, store string) string {
+	dir := t.TempDir()
+	t.Setenv("AGENTBAY_CLI_CONFIG_DIR", dir)
+	t.Setenv("AGENTBAY_CLI_CREDENTIAL_STORE", store)
+	t.Setenv("AGENTBAY_CLI_CREDENTIAL_KEY", "correct horse battery staple")
+	return dir
+}
+
+func readFile(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestEncryptedFileCredentialStore(t *testing.T) {
+	dir := setupCredentialTest(t, config.CredentialStoreEncryptedFile)
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.SaveTokens("secret-access", "Bearer", 3600, "secret-refresh", "id"))
+
+	assert.NotContains(t, readFile(t, filepath.Join(dir, "config.json")), "secret-access")
+	encrypted := readFile(t, filepath.Join(dir, "credentials.enc"))
+	assert.NotContains(t, encrypted, "secret-access")
+	assert.NotContains(t, encrypted, "secret-refresh")
+	info, err := os.Stat(filepath.Join(dir, "credentials.enc"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := config.GetConfig()
+	require.NoError(t, err)
+	token, err := loaded.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "secret-access", token.AccessToken)
+	assert.Equal(t, "secret-refresh", token.RefreshToken)
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_CREDENTIAL_KEY", "wrong")
+		cfg, err := config.GetConfig()
+		require.NoError(t, err, "an unusable store leaves the profile logged out")
+		assert.False(t, cfg.IsAuthenticated())
+		assert.ErrorIs(t, cfg.CredentialStoreError(), config.ErrCredentialKeyMismatch)
+	})
+
+	t.Run("missing passphrase", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_CREDENTIAL_KEY", "")
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.ErrorIs(t, cfg.CredentialStoreError(), config.ErrNoCredentialKey)
+	})
+
+	t.Run("tampered iteration count", func(t *testing.T) {
+		path := filepath.Join(dir, "credentials.enc")
+		tampered := strings.Replace(encrypted, `"iterations": 600000`, `"iterations": 1`, 1)
+		require.NotEqual(t, encrypted, tampered)
+		require.NoError(t, os.WriteFile(path, []byte(tampered), 0600))
+		defer os.WriteFile(path, []byte(encrypted), 0600)
+
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.IsAuthenticated())
+		assert.ErrorContains(t, cfg.CredentialStoreError(), "with 1 iterations")
+	})
+
+	require.NoError(t, loaded.ClearTokens())
+	assert.NoFileExists(t, filepath.Join(dir, "credentials.enc"))
+	cleared, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.False(t, cleared.IsAuthenticated())
+}
+
+// TestEncryptedFileCredentialStoreFixture loads testdata/credentials.enc, written with
+// the key that Go's crypto/pbkdf2 and Python's hashlib.pbkdf2_hmac derive from the
+// passphrase of setupCredentialTest, to pin the key derivation to other implementations
+func TestEncryptedFileCredentialStoreFixture(t *testing.T) {
+	dir := setupCredentialTest(t, config.CredentialStoreEncryptedFile)
+	fixture, err := os.ReadFile(filepath.Join("testdata", "credentials.enc"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "credentials.enc"), fixture, 0600))
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.CredentialStoreError())
+	token, err := cfg.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "fixture-access", token.AccessToken)
+	assert.Equal(t, "fixture-refresh", token.RefreshToken)
+}
+
+func TestCredentialStoreMigration(t *testing.T) {
+	dir := setupCredentialTest(t, "")
+	configPath := filepath.Join(dir, "config.json")
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.SaveTokens("access", "Bearer", 3600, "refresh", "id"))
+	assert.Contains(t, readFile(t, configPath), "access", "plaintext is the default store")
+
+	t.Run("switching store moves the token", func(t *testing.T) {
+		require.NoError(t, cfg.SetCredentialStore(config.CredentialStoreEncryptedFile))
+		content := readFile(t, configPath)
+		assert.Contains(t, content, `"credential_store": "encrypted-file"`)
+		assert.NotContains(t, content, "access_token")
+
+		loaded, err := config.GetConfig()
+		require.NoError(t, err)
+		token, err := loaded.GetToken()
+		require.NoError(t, err)
+		assert.Equal(t, "access", token.AccessToken)
+
+		require.NoError(t, loaded.SetCredentialStore(config.CredentialStorePlaintext))
+		assert.Contains(t, readFile(t, configPath), `"access_token": "access"`)
+		assert.NoFileExists(t, filepath.Join(dir, "credentials.enc"))
+	})
+
+	t.Run("token left in config.json is migrated on load", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_CREDENTIAL_STORE", config.CredentialStoreEncryptedFile)
+
+		loaded, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.True(t, loaded.IsAuthenticated())
+		assert.NotContains(t, readFile(t, configPath), "access_token")
+		assert.FileExists(t, filepath.Join(dir, "credentials.enc"))
+
+		name, err := loaded.CredentialStoreName()
+		require.NoError(t, err)
+		assert.Equal(t, config.CredentialStoreEncryptedFile, name)
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		assert.ErrorContains(t, cfg.SetCredentialStore("vault"), `unknown credential store "vault"`)
+	})
+}
+
+// fakeSecretTool is a secret-tool replacement that keeps each secret in a file
+const fakeSecretTool = `#!/bin/sh
+command=$1; shift
+if [ "$command" = store ]; then shift 2; fi
+entry="$FAKE_KEYRING_DIR/$2-$4"
+case $command in
+store) cat > "$entry" ;;
+lookup) [ -f "$entry" ] || exit 1; cat "$entry" ;;
+clear) rm -f "$entry" ;;
+esac
+`
+
+func TestKeyringCredentialStore(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the fake keyring replaces secret-tool, which is only used on Linux")
+	}
+	dir := setupCredentialTest(t, config.CredentialStoreKeyring)
+
+	t.Run("without secret-tool", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.IsAuthenticated())
+		assert.ErrorContains(t, cfg.CredentialStoreError(), "secret-tool not found")
+	})
+
+	binDir := t.TempDir()
+	keyringDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "secret-tool"), []byte(fakeSecretTool), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_KEYRING_DIR", keyringDir)
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.False(t, cfg.IsAuthenticated())
+	require.NoError(t, cfg.SaveTokens("access", "Bearer", 3600, "refresh", "id"))
+
+	assert.FileExists(t, filepath.Join(keyringDir, "agentbay-cli-default"))
+	assert.NotContains(t, readFile(t, filepath.Join(dir, "config.json")), "access")
+
+	loaded, err := config.GetConfig()
+	require.NoError(t, err)
+	token, err := loaded.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", token.RefreshToken)
+
+	require.NoError(t, loaded.ClearTokens())
+	assert.NoFileExists(t, filepath.Join(keyringDir, "agentbay-cli-default"))
+}
+
+func TestUnusableCredentialStore(t *testing.T) {
+	dir := setupCredentialTest(t, "")
+	configPath := filepath.Join(dir, "config.json")
+
+	t.Run("unknown store", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte(`{"credential_store": "bogus"}`), 0600))
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.IsAuthenticated())
+		assert.ErrorContains(t, cfg.CredentialStoreError(), `unknown credential store "bogus"`)
+		require.NoError(t, cfg.ClearTokens(), "logout works without the store")
+	})
+
+	t.Run("switching away from a broken store", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("the keyring is only missing without secret-tool on Linux")
+		}
+		t.Setenv("PATH", t.TempDir())
+		require.NoError(t, os.WriteFile(configPath, []byte(`{"credential_store": "keyring"}`), 0600))
+
+		cfg, err := config.ReadConfig()
+		require.NoError(t, err)
+		err = cfg.SetCredentialStore(config.CredentialStorePlaintext)
+		assert.ErrorIs(t, err, config.ErrCredentialsNotMoved)
+		assert.ErrorContains(t, err, "secret-tool not found")
+
+		loaded, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.Equal(t, config.CredentialStorePlaintext, loaded.CredentialStore, "the store is switched all the same")
+		assert.NoError(t, loaded.CredentialStoreError())
+		require.NoError(t, loaded.SaveTokens("access", "Bearer", 3600, "refresh", "id"))
+		assert.Contains(t, readFile(t, configPath), "access")
+	})
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+// setupProfileTest points the configuration at a temporary directory with no profile
+// selected and returns the path of config.json
+func setupProfileTest(t *testing.T) string {
+	dir := setupCredentialTest(t, "")
+	t.Setenv("AGENTBAY_PROFILE", "")
+	t.Setenv("AGENTBAY_ENV", "")
+	t.Setenv("AGENTBAY_CLI_ENDPOINT", "")
+	t.Setenv("AGENTBAY_OAUTH_CLIENT_ID", "")
+
+	emptyDir := t.TempDir()
+	t.Cleanup(func() {
+		// Deselect the profile so that its settings do not leak into other tests
+		os.Setenv("AGENTBAY_CLI_CONFIG_DIR", emptyDir)
+		require.NoError(t, config.SelectProfile(""))
+	})
+	return filepath.Join(dir, "config.json")
+}
+
+func TestProfiles(t *testing.T) {
+	configPath := setupProfileTest(t)
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, cfg.ProfileNames(), "the default profile always exists")
+	require.NoError(t, cfg.SaveTokens("default-access", "Bearer", 3600, "default-refresh", "id"))
+	require.NoError(t, cfg.AddProfile("intl", config.Profile{Environment: "intl", ClientID: "intl-client", Region: "International"}))
+
+	t.Run("invalid profiles are rejected", func(t *testing.T) {
+		assert.ErrorContains(t, cfg.AddProfile("intl", config.Profile{}), "already exists")
+		assert.ErrorContains(t, cfg.AddProfile("has space", config.Profile{}), "invalid profile name")
+		assert.ErrorContains(t, cfg.AddProfile("x", config.Profile{Environment: "mars"}), "unknown environment")
+		assert.ErrorContains(t, cfg.AddProfile("x", config.Profile{Region: "moon"}), "unknown region")
+	})
+
+	t.Run("login is scoped to the profile", func(t *testing.T) {
+		t.Setenv("AGENTBAY_PROFILE", "intl")
+		intl, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "intl", intl.ProfileName())
+		assert.False(t, intl.IsAuthenticated())
+		require.NoError(t, intl.SaveTokens("intl-access", "Bearer", 3600, "intl-refresh", "id"))
+
+		require.NoError(t, intl.ClearTokens())
+		require.NoError(t, intl.SaveTokens("intl-access-2", "Bearer", 3600, "intl-refresh", "id"))
+
+		t.Setenv("AGENTBAY_PROFILE", "")
+		def, err := config.GetConfig()
+		require.NoError(t, err)
+		token, err := def.GetToken()
+		require.NoError(t, err)
+		assert.Equal(t, "default-access", token.AccessToken)
+	})
+
+	t.Run("use selects the current profile", func(t *testing.T) {
+		def, err := config.GetConfig()
+		require.NoError(t, err)
+		require.NoError(t, def.UseProfile("intl"))
+		assert.ErrorIs(t, def.UseProfile("missing"), config.ErrProfileNotFound)
+
+		current, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "intl", current.ProfileName())
+		token, err := current.GetToken()
+		require.NoError(t, err)
+		assert.Equal(t, "intl-access-2", token.AccessToken)
+
+		assert.ErrorContains(t, current.RemoveProfile("intl"), "in use")
+		assert.ErrorContains(t, current.RemoveProfile("default"), "cannot be removed")
+		require.NoError(t, current.UseProfile("default"))
+	})
+
+	t.Run("remove deletes the profile and its token", func(t *testing.T) {
+		current, err := config.GetConfig()
+		require.NoError(t, err)
+		require.NoError(t, current.RemoveProfile("intl"))
+		assert.Equal(t, []string{"default"}, current.ProfileNames())
+		assert.NotContains(t, readFile(t, configPath), "intl-access")
+	})
+}
+
+func TestLegacyTokenMovesToDefaultProfile(t *testing.T) {
+	configPath := setupProfileTest(t)
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"token": {"access_token": "legacy"}}`), 0600))
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "default", cfg.ProfileName())
+	token, err := cfg.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", token.AccessToken)
+
+	require.NoError(t, cfg.Save())
+	loaded, err := config.GetConfig()
+	require.NoError(t, err)
+	p, err := loaded.GetProfile("default")
+	require.NoError(t, err)
+	assert.Nil(t, p.Token, "GetProfile leaves out the token")
+	assert.Contains(t, readFile(t, configPath), `"profiles"`)
+	assert.True(t, loaded.IsAuthenticated())
+}
+
+func TestSelectProfile(t *testing.T) {
+	setupProfileTest(t)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.AddProfile("intl", config.Profile{
+		Environment: "international",
+		Endpoint:    "agentbay.example.com",
+		ClientID:    "intl-client",
+	}))
+
+	assert.ErrorIs(t, config.SelectProfile("missing"), config.ErrProfileNotFound)
+
+	require.NoError(t, config.SelectProfile("intl"))
+	assert.Equal(t, "intl", config.SelectedProfileName())
+	assert.Equal(t, config.EnvInternationalProduction, config.GetEnvironment())
+	assert.Equal(t, "intl-client", config.GetClientID())
+	assert.Equal(t, "agentbay.example.com", config.LoadAPIConfig(nil).Endpoint)
+
+	loaded, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "intl", loaded.ProfileName(), "--profile selects the profile of the configuration")
+
+	t.Run("environment variables take precedence", func(t *testing.T) {
+		t.Setenv("AGENTBAY_ENV", "prerelease")
+		t.Setenv("AGENTBAY_CLI_ENDPOINT", "override.example.com")
+		t.Setenv("AGENTBAY_OAUTH_CLIENT_ID", "override-client")
+		assert.Equal(t, config.EnvPreRelease, config.GetEnvironment())
+		assert.Equal(t, "override-client", config.GetClientID())
+		assert.Equal(t, "override.example.com", config.LoadAPIConfig(nil).Endpoint)
+	})
+}
+
+func TestSelectProfileWithoutConfig(t *testing.T) {
+	configPath := setupProfileTest(t)
+
+	t.Run("unknown profile", func(t *testing.T) {
+		assert.ErrorIs(t, config.SelectProfile("missing"), config.ErrProfileNotFound)
+		assert.Equal(t, "missing", config.SelectedProfileName(), "the named profile is still reported")
+		assert.Equal(t, config.Profile{}, config.ActiveProfile(), "the defaults apply")
+
+		_, err := config.GetConfig()
+		assert.ErrorIs(t, err, config.ErrProfileNotFound, "commands that load the configuration report it")
+
+		cfg, err := config.ReadConfig()
+		require.NoError(t, err)
+		require.NoError(t, cfg.AddProfile("missing", config.Profile{Environment: "international"}))
+		require.NoError(t, config.SelectProfile("missing"))
+		assert.Equal(t, config.EnvInternationalProduction, config.GetEnvironment())
+	})
+
+	t.Run("corrupt configuration", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte("{not json"), 0600))
+		err := config.SelectProfile("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), configPath, "the error names the file")
+		assert.Equal(t, "default", config.SelectedProfileName())
+
+		_, err = config.ReadConfig()
+		assert.ErrorContains(t, err, configPath)
+	})
+}
+
+func TestReadConfigKeepsTokens(t *testing.T) {
+	configPath := setupProfileTest(t)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.SaveTokens("default-access", "Bearer", 3600, "default-refresh", "id"))
+
+	// The keyring cannot be used in tests; the tokens stay where they are
+	t.Setenv("AGENTBAY_CLI_CREDENTIAL_STORE", config.CredentialStoreKeyring)
+	t.Setenv("PATH", t.TempDir())
+	read, err := config.ReadConfig()
+	require.NoError(t, err)
+	require.NoError(t, read.AddProfile("intl", config.Profile{}))
+	assert.Contains(t, readFile(t, configPath), "default-access", "saving a configuration read without tokens keeps them")
+}
+
+func TestSetCredentialStoreMovesAllProfiles(t *testing.T) {
+	configPath := setupProfileTest(t)
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.SaveTokens("default-access", "Bearer", 3600, "r", "id"))
+	require.NoError(t, cfg.AddProfile("work", config.Profile{}))
+
+	t.Setenv("AGENTBAY_PROFILE", "work")
+	work, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.False(t, work.IsAuthenticated())
+	require.NoError(t, work.SaveTokens("work-access", "Bearer", 3600, "r", "id"))
+
+	require.NoError(t, work.SetCredentialStore(config.CredentialStoreEncryptedFile))
+	assert.NotContains(t, readFile(t, configPath), "access_token")
+
+	t.Setenv("AGENTBAY_PROFILE", "")
+	def, err := config.GetConfig()
+	require.NoError(t, err)
+	token, err := def.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "default-access", token.AccessToken)
+
+	require.NoError(t, def.SetCredentialStore(config.CredentialStorePlaintext))
+	content := readFile(t, configPath)
+	assert.Contains(t, content, "default-access")
+	assert.Contains(t, content, "work-access")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(configPath), "credentials.enc"))
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/config"
+)
+
+func TestLoadUploadConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_UPLOAD_CONCURRENCY", "")
+		t.Setenv("AGENTBAY_CLI_UPLOAD_LIMIT_RATE", "")
+
+		cfg := config.LoadUploadConfig()
+		assert.Equal(t, config.DefaultUploadConcurrency, cfg.Concurrency)
+		assert.Equal(t, int64(0), cfg.LimitRate)
+	})
+
+	t.Run("environment overrides", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_UPLOAD_CONCURRENCY", "32")
+		t.Setenv("AGENTBAY_CLI_UPLOAD_LIMIT_RATE", "2M")
+
+		cfg := config.LoadUploadConfig()
+		assert.Equal(t, 32, cfg.Concurrency)
+		assert.Equal(t, int64(2<<20), cfg.LimitRate)
+	})
+
+	t.Run("invalid values keep defaults", func(t *testing.T) {
+		t.Setenv("AGENTBAY_CLI_UPLOAD_CONCURRENCY", "0")
+		t.Setenv("AGENTBAY_CLI_UPLOAD_LIMIT_RATE", "fast")
+
+		cfg := config.LoadUploadConfig()
+		assert.Equal(t, config.DefaultUploadConcurrency, cfg.Concurrency)
+		assert.Equal(t, int64(0), cfg.LimitRate)
+	})
+}
+
+func TestParseByteRate(t *testing.T) {
+	tests := map[string]int64{
+		"0":       0,
+		"2048":    2048,
+		"512K":    512 << 10,
+		"512kb":   512 << 10,
+		"10M":     10 << 20,
+		"1.5MB/s": 3 << 19,
+		" 1G ":    1 << 30,
+	}
+	for value, want := range tests {
+		got, err := config.ParseByteRate(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	for _, value := range []string{"", "fast", "-1M", "10T", "Inf"} {
+		_, err := config.ParseByteRate(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/dockerfile"
+)
+
+func TestParse_InstructionsAndLineRanges(t *testing.T) {
+	content := `# syntax=docker/dockerfile:1
+# escape=\
+FROM ubuntu:22.04 AS base
+# a comment
+RUN apt-get update && \
+    # comment inside a continuation
+    apt-get install -y curl
+COPY --chown=root:root --chmod=755 --link app.py "my file.txt" /app/
+CMD ["python3", "/app/app.py"]
+`
+	df, err := dockerfile.Parse([]byte(content))
+	require.NoError(t, err)
+
+	assert.Equal(t, "docker/dockerfile:1", df.Directives["syntax"])
+	assert.Equal(t, '\\', df.EscapeToken)
+	require.Len(t, df.Instructions, 4)
+
+	from := df.Instructions[0]
+	assert.Equal(t, "FROM", from.Keyword)
+	assert.Equal(t, []string{"ubuntu:22.04", "AS", "base"}, from.Args)
+	assert.Equal(t, 3, from.StartLine)
+	assert.Equal(t, 3, from.EndLine)
+
+	run := df.Instructions[1]
+	assert.Equal(t, "RUN", run.Keyword)
+	assert.Equal(t, 5, run.StartLine)
+	assert.Equal(t, 7, run.EndLine)
+	require.Len(t, run.Args, 1)
+	assert.Contains(t, run.Args[0], "apt-get install -y curl")
+
+	cp := df.Instructions[2]
+	assert.Equal(t, []dockerfile.Flag{
+		{Name: "chown", Value: "root:root", HasValue: true},
+		{Name: "chmod", Value: "755", HasValue: true},
+		{Name: "link"},
+	}, cp.Flags)
+	_, ok := cp.Flag("link")
+	assert.True(t, ok)
+	_, ok = cp.Flag("from")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"app.py", `"my file.txt"`, "/app/"}, cp.Args)
+
+	cmd := df.Instructions[3]
+	assert.True(t, cmd.JSON)
+	assert.Equal(t, []string{"python3", "/app/app.py"}, cmd.Args)
+}
+
+func TestParse_EscapeDirective(t *testing.T) {
+	df, err := dockerfile.Parse([]byte("# escape=`\nFROM windows\nCOPY a.txt `\n  b.txt C:\\dest\\\n"))
+	require.NoError(t, err)
+	assert.Equal(t, '`', df.EscapeToken)
+	require.Len(t, df.Instructions, 2)
+	assert.Equal(t, []string{"a.txt", "b.txt", `C:\dest\`}, df.Instructions[1].Args)
+	assert.Equal(t, 4, df.Instructions[1].EndLine)
+
+	_, err = dockerfile.Parse([]byte("# escape=x\nFROM a\n"))
+	require.Error(t, err)
+}
+
+func TestParse_DirectivesOnlyAtTop(t *testing.T) {
+	df, err := dockerfile.Parse([]byte("FROM a\n# syntax=ignored\n"))
+	require.NoError(t, err)
+	assert.Empty(t, df.Directives)
+}
+
+func TestParse_Heredocs(t *testing.T) {
+	content := "FROM alpine\n" +
+		"COPY <<EOF /etc/motd\n" +
+		"hello $USER\n" +
+		"EOF\n" +
+		"RUN <<-'SCRIPT' bash\n" +
+		"\techo hi\n" +
+		"\tSCRIPT\n" +
+		"COPY app.py /app/\n"
+	df, err := dockerfile.Parse([]byte(content))
+	require.NoError(t, err)
+	require.Len(t, df.Instructions, 4)
+
+	cp := df.Instructions[1]
+	require.Len(t, cp.Heredocs, 1)
+	assert.Equal(t, "EOF", cp.Heredocs[0].Name)
+	assert.Equal(t, "hello $USER\n", cp.Heredocs[0].Content)
+	assert.True(t, cp.Heredocs[0].Expand)
+	assert.Equal(t, 2, cp.StartLine)
+	assert.Equal(t, 4, cp.EndLine)
+
+	run := df.Instructions[2]
+	require.Len(t, run.Heredocs, 1)
+	assert.Equal(t, "echo hi\n", run.Heredocs[0].Content)
+	assert.False(t, run.Heredocs[0].Expand)
+	assert.True(t, run.Heredocs[0].StripTabs)
+
+	assert.Equal(t, 8, df.Instructions[3].StartLine)
+
+	sources, dest, err := cp.CopySources(nil)
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+	assert.Equal(t, "/etc/motd", dest)
+
+	_, err = dockerfile.Parse([]byte("FROM a\nCOPY <<EOF /x\nno end\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unterminated heredoc")
+}
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"NAME": "app", "EMPTY": "", "DIR": "src"}
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"$NAME", "app"},
+		{"${NAME}.py", "app.py"},
+		{"${DIR}/$NAME", "src/app"},
+		{"${MISSING:-default}", "default"},
+		{"${EMPTY:-default}", "default"},
+		{"${EMPTY-default}", ""},
+		{"${NAME:+set}", "set"},
+		{"${MISSING+set}", ""},
+		{"${MISSING:-${NAME}}", "app"},
+		{`"with space $NAME"`, "with space app"},
+		{`'$NAME'`, "$NAME"},
+		{`\$NAME`, "$NAME"},
+		{"$", "$"},
+		{"cost$", "cost$"},
+	}
+	for _, tt := range tests {
+		got, err := dockerfile.Expand(tt.word, vars, dockerfile.DefaultEscapeToken)
+		require.NoError(t, err, tt.word)
+		assert.Equal(t, tt.want, got, tt.word)
+	}
+
+	_, err := dockerfile.Expand("${MISSING:?must be set}", vars, dockerfile.DefaultEscapeToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be set")
+
+	_, err = dockerfile.Expand(`"unclosed`, vars, dockerfile.DefaultEscapeToken)
+	require.Error(t, err)
+}
+
+func TestWalk_ArgAndEnvScope(t *testing.T) {
+	content := `ARG VERSION=1
+ARG BASE=ubuntu
+FROM ${BASE}:${VERSION}
+ARG VERSION
+ARG APP=web
+ENV DIR=/srv/$APP OTHER=$APP
+COPY ${APP}-v${VERSION}.tar.gz $DIR/
+ENV APP=api
+ARG APP=ignored
+COPY ["$APP/config.json", "/etc/"]
+FROM base AS second
+COPY ${APP}x /y
+`
+	df, err := dockerfile.Parse([]byte(content))
+	require.NoError(t, err)
+
+	var copies [][]string
+	var froms [][]string
+	err = df.Walk(map[string]string{"VERSION": "2"}, func(inst *dockerfile.Instruction, vars map[string]string) error {
+		switch inst.Keyword {
+		case "FROM":
+			args, err := inst.ExpandArgs(vars)
+			require.NoError(t, err)
+			froms = append(froms, args)
+		case "COPY":
+			sources, dest, err := inst.CopySources(vars)
+			require.NoError(t, err)
+			copies = append(copies, append(sources, dest))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ubuntu:2"}, froms[0])
+	assert.Equal(t, []string{"web-v2.tar.gz", "/srv/web/"}, copies[0])
+	assert.Equal(t, []string{"api/config.json", "/etc/"}, copies[1])
+	assert.Equal(t, []string{"x", "/y"}, copies[2], "stage variables do not leak into the next stage")
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 AgentBay CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dockerfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentbay/agentbay-cli/internal/dockerfile"
+)
+
+func TestQuoteWord_RoundTrip(t *testing.T) {
+	values := []string{
+		"1.2.3",
+		"registry.example.com/app:latest",
+		"",
+		"with space",
+		"it's",
+		`$HOME and ${PATH}`,
+		`back\slash`,
+		`"double"`,
+	}
+	for _, escape := range []rune{'\\', '`'} {
+		for _, value := range values {
+			quoted, err := dockerfile.QuoteWord(value, escape)
+			require.NoError(t, err, value)
+			got, err := dockerfile.Expand(quoted, map[string]string{"HOME": "/root"}, escape)
+			require.NoError(t, err, quoted)
+			assert.Equal(t, value, got, "escape %q, quoted %s", escape, quoted)
+		}
+	}
+
+	quoted, err := dockerfile.QuoteWord("1.2.3", '\\')
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", quoted, "plain words are not quoted")
+
+	_, err = dockerfile.QuoteWord("two\nlines", '\\')
+	require.Error(t, err)
+}
+
+func TestSetArgDefaults(t *testing.T) {
+	content := "ARG BASE=ubuntu\r\n" +
+		"FROM ${BASE}\r\n" +
+		"ARG VERSION=1 \\\r\n" +
+		"    CHANNEL\r\n" +
+		"RUN echo $VERSION\r\n" +
+		"ARG OTHER=keep\r\n" +
+		"COPY app-${VERSION}-${CHANNEL}.tar.gz /app/\r\n"
+
+	out, rewrites, err := dockerfile.SetArgDefaults([]byte(content), map[string]string{
+		"VERSION": "2.0",
+		"CHANNEL": "beta build",
+		"MISSING": "x",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ARG BASE=ubuntu\r\n"+
+		"FROM ${BASE}\r\n"+
+		"ARG VERSION=2.0 CHANNEL='beta build'\r\n"+
+		"RUN echo $VERSION\r\n"+
+		"ARG OTHER=keep\r\n"+
+		"COPY app-${VERSION}-${CHANNEL}.tar.gz /app/\r\n", string(out))
+	require.Len(t, rewrites, 1)
+	assert.Equal(t, 3, rewrites[0].Line)
+	assert.Equal(t, []string{"VERSION", "CHANNEL"}, rewrites[0].Names)
+
+	// The rewritten defaults behave like build args
+	df, err := dockerfile.Parse(out)
+	require.NoError(t, err)
+	var sources []string
+	err = df.Walk(nil, func(inst *dockerfile.Instruction, vars map[string]string) error {
+		if inst.Keyword == "COPY" {
+			sources, _, err = inst.CopySources(vars)
+			return err
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app-2.0-beta build.tar.gz"}, sources)
+}
+
+func TestSetArgDefaults_NoValues(t *testing.T) {
+	content := []byte("FROM ubuntu\nARG VERSION=1\n")
+	out, rewrites, err := dockerfile.SetArgDefaults(content, nil)
+	require.NoError(t, err)
+	assert.Equal(t, content, out)
+	assert.Empty(t, rewrites)
+}